@@ -0,0 +1,28 @@
+package main
+
+import (
+	"collectionsservice/internal/config"
+	"collectionsservice/internal/database"
+	"collectionsservice/internal/mock"
+	"collectionsservice/internal/repository"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+func main() {
+	config.LoadEnv()
+
+	db, err := database.ConnectToDatabase()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to connect to the database")
+	}
+
+	repo := repository.NewCollectionRepository(db)
+	addr := config.GetMockServerAddr()
+
+	log.Info().Str("addr", addr).Msg("Mock server listening")
+	if err := http.ListenAndServe(addr, mock.NewServer(repo)); err != nil {
+		log.Fatal().Err(err).Msg("Mock server stopped")
+	}
+}
@@ -1,11 +1,24 @@
 package main
 
 import (
+	"collectionsservice/internal/audit"
+	"collectionsservice/internal/auth"
 	"collectionsservice/internal/config"
 	"collectionsservice/internal/database"
+	"collectionsservice/internal/executor"
 	"collectionsservice/internal/grpc"
+	"collectionsservice/internal/idempotency"
+	"collectionsservice/internal/monitor"
+	"collectionsservice/internal/outbox"
+	pb "collectionsservice/internal/proto"
+	"collectionsservice/internal/rbac"
 	"collectionsservice/internal/repository"
+	"collectionsservice/internal/scripting"
+	"collectionsservice/internal/secrets"
 	"collectionsservice/internal/service"
+	"collectionsservice/internal/webhook"
+	"context"
+	"time"
 
 	"github.com/rs/zerolog/log"
 )
@@ -18,8 +31,94 @@ func main() {
 		log.Fatal().Err(err).Msg("Failed to connect to the database")
 	}
 
+	authCfg := config.GetAuthConfig()
+
+	memberRepo := repository.NewMemberRepository(db)
+	authz := rbac.NewAuthorizer(memberRepo, authCfg.AdminSubjects)
+
+	secretsCfg := config.GetSecretsConfig()
+	keyring, err := secrets.LoadKeyring(secretsCfg.KeyFile, secretsCfg.Keys, secretsCfg.PrimaryKeyID)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load secret encryption keys")
+	}
+	if !keyring.Enabled() {
+		log.Warn().Msg("No secret encryption keys configured; requests with secret values will be rejected")
+	}
+
 	repo := repository.NewCollectionRepository(db)
-	ser := service.NewCollectionService(repo)
+	exec := executor.New(config.GetExecutorTimeout(), scripting.Limits{
+		Timeout: config.GetScriptTimeout(),
+		Heap:    config.GetScriptHeapLimit(),
+	})
+	publisher := webhook.NewPublisher(repo)
+	ser := service.NewCollectionService(repo, memberRepo, authz, keyring, exec, config.GetRunHistoryBodyLimit())
+
+	monitorCfg := config.GetMonitorConfig()
+	if monitorCfg.Enabled {
+		sqlDB, err := db.DB()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to get sql instance for monitors")
+		}
+		notifier := monitor.Notifiers{monitor.NewWebhookNotifier(10 * time.Second), publisher}
+		scheduler := monitor.NewScheduler(repo, ser, notifier,
+			monitor.NewLeader(sqlDB, monitor.LockKey), monitorCfg.PollInterval)
+		go scheduler.Start(context.Background())
+	}
+
+	webhookCfg := config.GetWebhookConfig()
+	if webhookCfg.Enabled {
+		dispatcher := webhook.NewDispatcher(repo, keyring.Open, webhookCfg.Timeout, webhookCfg.PollInterval, webhookCfg.MaxAttempts)
+		go dispatcher.Start(context.Background())
+	}
+
+	outboxCfg := config.GetOutboxConfig()
+	if outboxCfg.Enabled {
+		var sinks []outbox.Sink
+		for _, name := range outboxCfg.Sinks {
+			switch name {
+			case "log":
+				sinks = append(sinks, outbox.LogSink{})
+			case "webhook":
+				sinks = append(sinks, outbox.NewWebhookSink(publisher))
+			default:
+				log.Fatal().Str("sink", name).Msg("Unknown outbox sink; expected log or webhook")
+			}
+		}
+		relay := outbox.NewRelay(repo, sinks, outboxCfg.PollInterval, outboxCfg.Retention)
+		go relay.Start(context.Background())
+	}
+
+	wsRepo := repository.NewWorkspaceRepository(db)
+	wsSer := service.NewWorkspaceService(wsRepo, memberRepo, authz)
+
+	keyRepo := repository.NewAPIKeyRepository(db)
+	authSer := service.NewAuthService(keyRepo, authz)
+
+	if authCfg.BootstrapAPIKey != "" {
+		if err := authSer.EnsureBootstrapKey(context.Background(), authCfg.BootstrapAPIKey); err != nil {
+			log.Fatal().Err(err).Msg("Failed to register bootstrap API key")
+		}
+	}
+
+	verifier, err := auth.NewJWTVerifier(authCfg.JWTSecret, authCfg.JWKSFile, authCfg.JWTIssuer, authCfg.JWTAudience)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to configure JWT validation")
+	}
+	if authCfg.Disabled {
+		log.Warn().Msg("Authentication is disabled; every caller is anonymous")
+	}
+	authn := auth.NewAuthenticator(keyRepo, verifier, authCfg.Disabled,
+		pb.CollectionService_GetSharedCollection_FullMethodName,
+		pb.CollectionService_ExecuteRequest_FullMethodName,
+		pb.CollectionService_RunCollection_FullMethodName,
+	)
+
+	guard := idempotency.NewGuard(repo, config.GetIdempotencyTTL(), config.GetIdempotencyLease())
+	go guard.Start(context.Background())
 
-	grpc.StartGRPCServer(ser)
+	grpc.StartGRPCServer(grpc.Services{
+		Collections: ser,
+		Workspaces:  wsSer,
+		Auth:        authSer,
+	}, authn, audit.NewRecorder(repo), guard)
 }
@@ -0,0 +1,184 @@
+// Package apperr defines the errors the service returns to clients. Each
+// error has a kind, which becomes its gRPC code, and a reason, sent as an
+// ErrorInfo detail, so clients can branch on what went wrong without
+// parsing messages.
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Domain is the ErrorInfo domain of every error.
+const Domain = "collectionsservice"
+
+// ReasonInvalidArgument is the reason of every InvalidArgument error; its
+// BadRequest detail says which fields are wrong.
+const ReasonInvalidArgument = "INVALID_ARGUMENT"
+
+type Kind int
+
+const (
+	KindInvalidArgument Kind = iota + 1
+	KindNotFound
+	KindAlreadyExists
+	KindFailedPrecondition
+	KindAborted
+	KindPermissionDenied
+)
+
+var kindCodes = map[Kind]codes.Code{
+	KindInvalidArgument:    codes.InvalidArgument,
+	KindNotFound:           codes.NotFound,
+	KindAlreadyExists:      codes.AlreadyExists,
+	KindFailedPrecondition: codes.FailedPrecondition,
+	KindAborted:            codes.Aborted,
+	KindPermissionDenied:   codes.PermissionDenied,
+}
+
+// Violation names a request field and what is wrong with it.
+type Violation struct {
+	Field       string
+	Description string
+}
+
+// Error is a domain error. Reason is a stable UPPER_SNAKE_CASE identifier;
+// Message is for people.
+type Error struct {
+	Kind       Kind
+	Reason     string
+	Message    string
+	Violations []Violation
+	// ResourceType and ResourceName identify what was missing or clashed.
+	ResourceType string
+	ResourceName string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Code returns the gRPC code of the error's kind.
+func (e *Error) Code() codes.Code {
+	if c, ok := kindCodes[e.Kind]; ok {
+		return c
+	}
+	return codes.Unknown
+}
+
+// GRPCStatus renders the error with its details, so it keeps them even when
+// returned without going through the interceptor.
+func (e *Error) GRPCStatus() *status.Status {
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: e.Reason, Domain: Domain}}
+	if len(e.Violations) > 0 {
+		br := &errdetails.BadRequest{}
+		for _, v := range e.Violations {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+		details = append(details, br)
+	}
+	if e.ResourceType != "" {
+		details = append(details, &errdetails.ResourceInfo{
+			ResourceType: e.ResourceType,
+			ResourceName: e.ResourceName,
+			Description:  e.Message,
+		})
+	}
+
+	st := status.New(e.Code(), e.Message)
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// Is matches errors of the same kind and reason, so a sentinel like
+// ErrCollectionProtected matches every error built from it.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Kind == e.Kind && t.Reason == e.Reason
+}
+
+// Invalid reports one invalid request field.
+func Invalid(field, format string, args ...interface{}) *Error {
+	msg := fmt.Sprintf(format, args...)
+	return &Error{
+		Kind:       KindInvalidArgument,
+		Reason:     ReasonInvalidArgument,
+		Message:    msg,
+		Violations: []Violation{{Field: field, Description: msg}},
+	}
+}
+
+// InvalidFields reports every invalid field of a request at once.
+func InvalidFields(violations ...Violation) *Error {
+	msgs := make([]string, len(violations))
+	for i, v := range violations {
+		msgs[i] = v.Field + ": " + v.Description
+	}
+	return &Error{
+		Kind:       KindInvalidArgument,
+		Reason:     ReasonInvalidArgument,
+		Message:    strings.Join(msgs, "; "),
+		Violations: violations,
+	}
+}
+
+// NotFound reports a missing resource, such as NotFound("collection", id).
+// Its reason is COLLECTION_NOT_FOUND.
+func NotFound(resourceType, name string) *Error {
+	return &Error{
+		Kind:         KindNotFound,
+		Reason:       reason(resourceType) + "_NOT_FOUND",
+		Message:      resourceType + " not found",
+		ResourceType: resourceType,
+		ResourceName: name,
+	}
+}
+
+// AlreadyExists reports a clash with an existing resource.
+func AlreadyExists(resourceType, name, format string, args ...interface{}) *Error {
+	return &Error{
+		Kind:         KindAlreadyExists,
+		Reason:       reason(resourceType) + "_ALREADY_EXISTS",
+		Message:      fmt.Sprintf(format, args...),
+		ResourceType: resourceType,
+		ResourceName: name,
+	}
+}
+
+// FailedPrecondition reports a request that is valid but cannot apply to
+// the current state, such as a change to a protected collection.
+func FailedPrecondition(reason, format string, args ...interface{}) *Error {
+	return &Error{Kind: KindFailedPrecondition, Reason: reason, Message: fmt.Sprintf(format, args...)}
+}
+
+// Aborted reports a conflict with a concurrent change; the client should
+// reload and retry.
+func Aborted(reason, format string, args ...interface{}) *Error {
+	return &Error{Kind: KindAborted, Reason: reason, Message: fmt.Sprintf(format, args...)}
+}
+
+func PermissionDenied(reason, format string, args ...interface{}) *Error {
+	return &Error{Kind: KindPermissionDenied, Reason: reason, Message: fmt.Sprintf(format, args...)}
+}
+
+// As returns the domain error in err's chain, if there is one.
+func As(err error) (*Error, bool) {
+	var e *Error
+	ok := errors.As(err, &e)
+	return e, ok
+}
+
+func reason(resourceType string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_").Replace(resourceType))
+}
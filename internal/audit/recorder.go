@@ -0,0 +1,256 @@
+// Package audit records who changed which collection, when and how. Every
+// call to an audited method is stored, whether it succeeded, failed or was
+// denied, with its payload and snapshots of the collection around it.
+package audit
+
+import (
+	"collectionsservice/internal/auth"
+	"collectionsservice/internal/models"
+	pb "collectionsservice/internal/proto"
+	"collectionsservice/internal/utils"
+	"collectionsservice/internal/workspace"
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/datatypes"
+)
+
+type Store interface {
+	CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error
+	GetByIDWithRequests(ctx context.Context, id string) (*models.Collection, error)
+	GetCollectionByName(ctx context.Context, workspaceID, name string) (*models.Collection, error)
+	GetProposal(ctx context.Context, id string) (*models.ChangeProposal, error)
+}
+
+// target names the collection a call changes, by ID or by name, or the
+// change proposal whose upstream collection it changes.
+type target struct {
+	id       string
+	name     string
+	proposal string
+}
+
+// audited maps each audited method to the collection its request targets.
+// Methods that create a collection have none until they return, and
+// DeleteWorkspace targets no single collection.
+var audited = map[string]func(req interface{}) target{
+	pb.CollectionService_CreateCollection_FullMethodName: func(interface{}) target {
+		return target{}
+	},
+	pb.CollectionService_AddRequestToCollection_FullMethodName: func(req interface{}) target {
+		return target{name: req.(*pb.AddRequestToCollectionRequest).GetCollectionName()}
+	},
+	pb.CollectionService_AddRequestToCollectionByID_FullMethodName: func(req interface{}) target {
+		return target{id: req.(*pb.AddRequestToCollectionByIDRequest).GetCollectionId()}
+	},
+	pb.CollectionService_BatchAddRequestsToCollection_FullMethodName: func(req interface{}) target {
+		r := req.(*pb.BatchAddRequestsToCollectionRequest)
+		if r.GetCollectionId() != "" {
+			return target{id: r.GetCollectionId()}
+		}
+		return target{name: r.GetCollectionName()}
+	},
+	pb.CollectionService_BatchUpdateRequestsInCollection_FullMethodName: func(req interface{}) target {
+		return target{id: req.(*pb.BatchUpdateRequestsInCollectionRequest).GetCollectionId()}
+	},
+	pb.CollectionService_BatchDeleteRequestsFromCollection_FullMethodName: func(req interface{}) target {
+		return target{id: req.(*pb.BatchDeleteRequestsFromCollectionRequest).GetCollectionId()}
+	},
+	pb.CollectionService_MergeCollections_FullMethodName: func(req interface{}) target {
+		return target{id: req.(*pb.MergeCollectionsRequest).GetTargetCollectionId()}
+	},
+	pb.CollectionService_ForkCollection_FullMethodName: func(interface{}) target {
+		return target{}
+	},
+	pb.CollectionService_SetCollectionProtected_FullMethodName: func(req interface{}) target {
+		return target{id: req.(*pb.SetCollectionProtectedRequest).GetCollectionId()}
+	},
+	pb.CollectionService_ReviewChangeProposal_FullMethodName: func(req interface{}) target {
+		return target{proposal: req.(*pb.ReviewChangeProposalRequest).GetProposalId()}
+	},
+	pb.WorkspaceService_DeleteWorkspace_FullMethodName: func(interface{}) target {
+		return target{}
+	},
+	pb.CollectionService_UpdateCollection_FullMethodName: func(req interface{}) target {
+		return target{id: req.(*pb.UpdateCollectionRequest).GetId()}
+	},
+	pb.CollectionService_UpdateRequestInCollection_FullMethodName: func(req interface{}) target {
+		return target{id: req.(*pb.UpdateRequestInCollectionRequest).GetCollectionId()}
+	},
+	pb.CollectionService_DeleteCollection_FullMethodName: func(req interface{}) target {
+		return target{id: req.(*pb.DeleteCollectionRequest).GetId()}
+	},
+	pb.CollectionService_DeleteRequestFromCollection_FullMethodName: func(req interface{}) target {
+		return target{id: req.(*pb.DeleteRequestFromCollectionRequest).GetCollectionId()}
+	},
+}
+
+// created returns the ID of the collection returned by a call that targeted
+// none, which is the collection it created.
+func created(resp interface{}) string {
+	switch r := resp.(type) {
+	case *pb.CreateCollectionResponse:
+		return r.GetId()
+	case *pb.CollectionResponse:
+		return r.GetId()
+	}
+	return ""
+}
+
+var jsonOptions = protojson.MarshalOptions{UseProtoNames: true}
+
+// Recorder writes an audit event for every call to an audited method.
+type Recorder struct {
+	store Store
+}
+
+func NewRecorder(store Store) *Recorder {
+	return &Recorder{store: store}
+}
+
+// UnaryInterceptor must run after authentication so the caller is known.
+// The event is written before the response is returned; a failure to write
+// it is logged rather than failing the call.
+func (r *Recorder) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		locate, ok := audited[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+		workspaceID, _ := workspace.FromContext(ctx)
+		t := r.resolve(ctx, locate(req))
+		creates := t == target{}
+		before := r.snapshot(ctx, workspaceID, t)
+		if before != nil {
+			t = target{id: before.ID}
+		}
+
+		resp, err := handler(ctx, req)
+
+		if creates && err == nil {
+			t = target{id: created(resp)}
+		}
+		var after *models.Collection
+		if err == nil && t.id != "" {
+			after = r.snapshot(ctx, workspaceID, t)
+		}
+
+		event := newEvent(ctx, info.FullMethod, req, err)
+		event.WorkspaceID = workspaceID
+		if t.id != "" {
+			event.CollectionID = &t.id
+		}
+		event.Before = encodeCollection(before)
+		event.After = encodeCollection(after)
+		event.DurationMs = time.Since(start).Milliseconds()
+		event.CreatedAt = start
+
+		if werr := r.store.CreateAuditEvent(context.WithoutCancel(ctx), event); werr != nil {
+			log.Error().Err(werr).Str("method", event.Method).Msg("Failed to write audit event")
+		}
+		return resp, err
+	}
+}
+
+// resolve replaces a proposal target with the upstream collection the
+// proposal changes.
+func (r *Recorder) resolve(ctx context.Context, t target) target {
+	if t.proposal == "" {
+		return t
+	}
+	p, err := r.store.GetProposal(ctx, t.proposal)
+	if err != nil {
+		return target{}
+	}
+	return target{id: p.UpstreamCollectionID}
+}
+
+// snapshot loads the targeted collection. Collections of other workspaces
+// are left out so the event shows nothing the caller's workspace cannot.
+func (r *Recorder) snapshot(ctx context.Context, workspaceID string, t target) *models.Collection {
+	var (
+		col *models.Collection
+		err error
+	)
+	switch {
+	case t.id != "":
+		col, err = r.store.GetByIDWithRequests(ctx, t.id)
+	case t.name != "":
+		col, err = r.store.GetCollectionByName(ctx, workspaceID, t.name)
+	default:
+		return nil
+	}
+	if err != nil || col.WorkspaceID != workspaceID {
+		return nil
+	}
+	return col
+}
+
+func newEvent(ctx context.Context, method string, req interface{}, err error) *models.AuditEvent {
+	event := &models.AuditEvent{
+		ID:      uuid.New().String(),
+		Method:  path.Base(method),
+		Outcome: outcome(err),
+		Code:    status.Code(err).String(),
+	}
+	if err != nil {
+		msg := status.Convert(err).Message()
+		event.Error = &msg
+	}
+	if id, ok := auth.FromContext(ctx); ok {
+		event.Subject = id.Subject
+		event.AuthMethod = string(id.Method)
+		event.APIKeyID = id.KeyID
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		event.PeerAddress = p.Addr.String()
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		event.ForwardedFor = strings.Join(md.Get("x-forwarded-for"), ", ")
+	}
+	if m, ok := req.(proto.Message); ok {
+		event.Payload = encode(m)
+	}
+	return event
+}
+
+func outcome(err error) models.AuditOutcome {
+	switch status.Code(err) {
+	case codes.OK:
+		return models.AuditSucceeded
+	case codes.PermissionDenied, codes.Unauthenticated:
+		return models.AuditDenied
+	default:
+		return models.AuditFailed
+	}
+}
+
+func encodeCollection(col *models.Collection) datatypes.JSON {
+	if col == nil {
+		return nil
+	}
+	return encode(utils.ConvertModelCollectionToProto(col))
+}
+
+// encode renders m as JSON with its secret values redacted.
+func encode(m proto.Message) datatypes.JSON {
+	raw, err := jsonOptions.Marshal(Redact(m))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encode audit payload")
+		return nil
+	}
+	return datatypes.JSON(raw)
+}
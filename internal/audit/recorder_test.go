@@ -0,0 +1,134 @@
+package audit
+
+import (
+	"collectionsservice/internal/models"
+	pb "collectionsservice/internal/proto"
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+)
+
+// fakeStore holds collections of the default workspace by ID and name, and
+// one proposal against the upstream collection.
+type fakeStore struct {
+	collections map[string]*models.Collection
+	events      []*models.AuditEvent
+}
+
+func newFakeStore(ids ...string) *fakeStore {
+	s := &fakeStore{collections: make(map[string]*models.Collection)}
+	for _, id := range ids {
+		s.collections[id] = &models.Collection{ID: id, Name: "name-" + id, WorkspaceID: models.DefaultWorkspaceID}
+	}
+	return s
+}
+
+func (s *fakeStore) CreateAuditEvent(_ context.Context, event *models.AuditEvent) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *fakeStore) GetByIDWithRequests(_ context.Context, id string) (*models.Collection, error) {
+	if col, ok := s.collections[id]; ok {
+		return col, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *fakeStore) GetCollectionByName(_ context.Context, workspaceID, name string) (*models.Collection, error) {
+	for _, col := range s.collections {
+		if col.WorkspaceID == workspaceID && col.Name == name {
+			return col, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *fakeStore) GetProposal(_ context.Context, id string) (*models.ChangeProposal, error) {
+	if id != "p1" {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &models.ChangeProposal{ID: id, ForkCollectionID: "fork", UpstreamCollectionID: "upstream"}, nil
+}
+
+func TestRecorderTargets(t *testing.T) {
+	tests := []struct {
+		method string
+		req    interface{}
+		resp   interface{}
+		err    error
+		want   string
+	}{
+		{
+			method: pb.CollectionService_AddRequestToCollectionByID_FullMethodName,
+			req:    &pb.AddRequestToCollectionByIDRequest{CollectionId: "upstream"},
+			resp:   &pb.CollectionResponse{Id: "upstream"},
+			want:   "upstream",
+		},
+		{
+			method: pb.CollectionService_BatchAddRequestsToCollection_FullMethodName,
+			req:    &pb.BatchAddRequestsToCollectionRequest{CollectionName: "name-upstream"},
+			resp:   &pb.BatchRequestsResponse{},
+			want:   "upstream",
+		},
+		{
+			method: pb.CollectionService_BatchDeleteRequestsFromCollection_FullMethodName,
+			req:    &pb.BatchDeleteRequestsFromCollectionRequest{CollectionId: "upstream"},
+			err:    errors.New("failed"),
+			want:   "upstream",
+		},
+		{
+			method: pb.CollectionService_MergeCollections_FullMethodName,
+			req:    &pb.MergeCollectionsRequest{SourceCollectionId: "fork", TargetCollectionId: "upstream"},
+			resp:   &pb.MergeCollectionsResponse{},
+			want:   "upstream",
+		},
+		{
+			method: pb.CollectionService_ForkCollection_FullMethodName,
+			req:    &pb.ForkCollectionRequest{CollectionId: "upstream"},
+			resp:   &pb.CollectionResponse{Id: "fork"},
+			want:   "fork",
+		},
+		{
+			method: pb.CollectionService_SetCollectionProtected_FullMethodName,
+			req:    &pb.SetCollectionProtectedRequest{CollectionId: "upstream", Protected: true},
+			resp:   &pb.CollectionResponse{Id: "upstream"},
+			want:   "upstream",
+		},
+		{
+			method: pb.CollectionService_ReviewChangeProposal_FullMethodName,
+			req:    &pb.ReviewChangeProposalRequest{ProposalId: "p1"},
+			resp:   &pb.ChangeProposalResponse{Id: "p1"},
+			want:   "upstream",
+		},
+		{
+			method: pb.WorkspaceService_DeleteWorkspace_FullMethodName,
+			req:    &pb.DeleteWorkspaceRequest{Id: "w1", Force: true},
+			resp:   &pb.DeleteResponse{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			store := newFakeStore("upstream", "fork")
+			interceptor := NewRecorder(store).UnaryInterceptor()
+			_, _ = interceptor(context.Background(), tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method},
+				func(context.Context, interface{}) (interface{}, error) {
+					return tt.resp, tt.err
+				})
+
+			if len(store.events) != 1 {
+				t.Fatalf("recorded %d events, want 1", len(store.events))
+			}
+			got := ""
+			if id := store.events[0].CollectionID; id != nil {
+				got = *id
+			}
+			if got != tt.want {
+				t.Errorf("collection id = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package audit
+
+import (
+	pb "collectionsservice/internal/proto"
+	"collectionsservice/internal/secrets"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// credentialFields are the AuthConfig fields that hold credentials.
+var credentialFields = map[protoreflect.Name]bool{
+	"password":              true,
+	"token":                 true,
+	"api_key_value":         true,
+	"client_secret":         true,
+	"aws_secret_access_key": true,
+	"aws_session_token":     true,
+}
+
+// Redact returns a copy of m with every secret replaced by secrets.Redacted:
+// auth credentials, the values of secret headers and variables, and the
+// same inside fields that carry them as JSON strings.
+func Redact(m proto.Message) proto.Message {
+	m = proto.Clone(m)
+	redact(m.ProtoReflect())
+	return m
+}
+
+func redact(m protoreflect.Message) {
+	_, isAuth := m.Interface().(*pb.AuthConfig)
+	secret := false
+	if fd := m.Descriptor().Fields().ByName("secret"); fd != nil && fd.Kind() == protoreflect.BoolKind {
+		secret = m.Get(fd).Bool()
+	}
+
+	redacted := make(map[protoreflect.FieldDescriptor]string)
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+		case fd.Kind() == protoreflect.MessageKind && fd.IsList():
+			for i := 0; i < v.List().Len(); i++ {
+				redact(v.List().Get(i).Message())
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			redact(v.Message())
+		case fd.Kind() == protoreflect.StringKind && !fd.IsList():
+			s := v.String()
+			switch {
+			case isAuth && credentialFields[fd.Name()], secret && fd.Name() == "value":
+				redacted[fd] = secrets.Redacted
+			default:
+				if r := secrets.RedactField(string(fd.Name()), s); r != s {
+					redacted[fd] = r
+				}
+			}
+		}
+		return true
+	})
+	for fd, s := range redacted {
+		m.Set(fd, protoreflect.ValueOfString(s))
+	}
+}
@@ -0,0 +1,95 @@
+package audit
+
+import (
+	pb "collectionsservice/internal/proto"
+	"collectionsservice/internal/secrets"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name string
+		in   proto.Message
+		want proto.Message
+	}{
+		{
+			name: "collection auth and secret variables",
+			in: &pb.CreateCollectionRequest{
+				Name: "api",
+				Auth: &pb.AuthConfig{Type: pb.AuthType_AUTH_TYPE_BASIC, Username: "user", Password: "hunter2"},
+				Variables: []*pb.VariableInput{
+					{Key: "host", Value: "example.com"},
+					{Key: "token", Value: "s3cr3t", Secret: true},
+				},
+			},
+			want: &pb.CreateCollectionRequest{
+				Name: "api",
+				Auth: &pb.AuthConfig{Type: pb.AuthType_AUTH_TYPE_BASIC, Username: "user", Password: secrets.Redacted},
+				Variables: []*pb.VariableInput{
+					{Key: "host", Value: "example.com"},
+					{Key: "token", Value: secrets.Redacted, Secret: true},
+				},
+			},
+		},
+		{
+			name: "nested secret headers",
+			in: &pb.AddRequestToCollectionRequest{
+				CollectionName: "api",
+				Request: &pb.CollectionRequestInput{
+					Http: &pb.HTTPRequestInput{Headers: []*pb.HeaderInput{
+						{Key: "Accept", Value: "*/*"},
+						{Key: "X-Api-Key", Value: "k3y", Secret: true},
+					}},
+					Auth: &pb.AuthConfig{Type: pb.AuthType_AUTH_TYPE_BEARER, Token: "t0ken"},
+				},
+			},
+			want: &pb.AddRequestToCollectionRequest{
+				CollectionName: "api",
+				Request: &pb.CollectionRequestInput{
+					Http: &pb.HTTPRequestInput{Headers: []*pb.HeaderInput{
+						{Key: "Accept", Value: "*/*"},
+						{Key: "X-Api-Key", Value: secrets.Redacted, Secret: true},
+					}},
+					Auth: &pb.AuthConfig{Type: pb.AuthType_AUTH_TYPE_BEARER, Token: secrets.Redacted},
+				},
+			},
+		},
+		{
+			name: "headers sent as JSON strings",
+			in: &pb.UpdateRequestInCollectionRequest{
+				HttpHeaders:    `[{"key":"Accept","value":"*/*"},{"key":"Authorization","value":"Bearer x","secret":true}]`,
+				GraphqlHeaders: `[{"key":"X-Token","value":"y","secret":true}]`,
+				HttpBody:       `{"password":"not a field secret"}`,
+			},
+			want: &pb.UpdateRequestInCollectionRequest{
+				HttpHeaders:    `[{"key":"Accept","value":"*/*"},{"key":"Authorization","value":"********","secret":true}]`,
+				GraphqlHeaders: `[{"key":"X-Token","value":"********","secret":true}]`,
+				HttpBody:       `{"password":"not a field secret"}`,
+			},
+		},
+		{
+			name: "empty credentials stay empty",
+			in:   &pb.CreateCollectionRequest{Auth: &pb.AuthConfig{Type: pb.AuthType_AUTH_TYPE_BEARER}},
+			want: &pb.CreateCollectionRequest{Auth: &pb.AuthConfig{Type: pb.AuthType_AUTH_TYPE_BEARER}},
+		},
+		{
+			name: "nothing secret",
+			in:   &pb.DeleteCollectionRequest{Id: "c1"},
+			want: &pb.DeleteCollectionRequest{Id: "c1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := proto.Clone(tt.in)
+			got := Redact(tt.in)
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("Redact =\n%v\nwant\n%v", got, tt.want)
+			}
+			if !proto.Equal(tt.in, original) {
+				t.Error("Redact changed its input")
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"collectionsservice/internal/models"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+// APIKeyPrefix marks a bearer token as a static API key rather than a JWT.
+const APIKeyPrefix = "csk_"
+
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+type KeyStore interface {
+	FindAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	TouchAPIKey(ctx context.Context, id string, at time.Time) error
+}
+
+// GenerateAPIKey returns a new plaintext key together with the prefix that is
+// safe to display and the hash that is stored.
+func GenerateAPIKey() (plaintext, prefix, hash string, err error) {
+	return generateToken(APIKeyPrefix)
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateToken(tokenPrefix string) (plaintext, prefix, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", "", err
+	}
+	plaintext = tokenPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return plaintext, plaintext[:len(tokenPrefix)+6], HashToken(plaintext), nil
+}
+
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+func verifyAPIKey(ctx context.Context, store KeyStore, key string) (*Identity, error) {
+	rec, err := store.FindAPIKeyByHash(ctx, HashToken(key))
+	if err != nil {
+		return nil, ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if rec.RevokedAt != nil || (rec.ExpiresAt != nil && now.After(*rec.ExpiresAt)) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	// Last-used tracking is best effort and must not fail the call.
+	_ = store.TouchAPIKey(ctx, rec.ID, now)
+
+	return &Identity{
+		Subject: rec.Subject,
+		Method:  MethodAPIKey,
+		KeyID:   rec.ID,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"collectionsservice/internal/models"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeKeys finds keys by hash and logs every touch.
+type fakeKeys struct {
+	byHash  map[string]*models.APIKey
+	touched []string
+}
+
+func (f *fakeKeys) FindAPIKeyByHash(_ context.Context, hash string) (*models.APIKey, error) {
+	if k, ok := f.byHash[hash]; ok {
+		return k, nil
+	}
+	return nil, errors.New("record not found")
+}
+
+func (f *fakeKeys) TouchAPIKey(_ context.Context, id string, _ time.Time) error {
+	f.touched = append(f.touched, id)
+	return errors.New("touch is best effort")
+}
+
+func TestGenerateAPIKey(t *testing.T) {
+	plain, prefix, hash, err := GenerateAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsAPIKey(plain) || !strings.HasPrefix(plain, prefix) || len(prefix) != len(APIKeyPrefix)+6 {
+		t.Errorf("key %q with prefix %q", plain, prefix)
+	}
+	if hash != HashToken(plain) || strings.Contains(hash, plain) {
+		t.Errorf("hash %q does not match the key", hash)
+	}
+	if other, _, _, _ := GenerateAPIKey(); other == plain {
+		t.Error("two generated keys are equal")
+	}
+}
+
+func TestVerifyAPIKey(t *testing.T) {
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	keys := map[string]*models.APIKey{
+		"csk_live":    {ID: "k1", Subject: "alice"},
+		"csk_expires": {ID: "k2", Subject: "bob", ExpiresAt: &future},
+		"csk_expired": {ID: "k3", Subject: "bob", ExpiresAt: &past},
+		"csk_revoked": {ID: "k4", Subject: "carol", RevokedAt: &past},
+	}
+	store := &fakeKeys{byHash: make(map[string]*models.APIKey)}
+	for plain, k := range keys {
+		store.byHash[HashToken(plain)] = k
+	}
+
+	tests := []struct {
+		key     string
+		wantSub string
+	}{
+		{"csk_live", "alice"},
+		{"csk_expires", "bob"},
+		{"csk_expired", ""},
+		{"csk_revoked", ""},
+		{"csk_unknown", ""},
+	}
+	for _, tt := range tests {
+		store.touched = nil
+		id, err := verifyAPIKey(context.Background(), store, tt.key)
+		if tt.wantSub == "" {
+			if !errors.Is(err, ErrInvalidAPIKey) {
+				t.Errorf("%s: Verify = %v, %v; want ErrInvalidAPIKey", tt.key, id, err)
+			}
+			if len(store.touched) > 0 {
+				t.Errorf("%s: rejected key was touched", tt.key)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.key, err)
+			continue
+		}
+		if id.Subject != tt.wantSub || id.Method != MethodAPIKey || id.KeyID != keys[tt.key].ID {
+			t.Errorf("%s: identity %+v", tt.key, id)
+		}
+		if len(store.touched) != 1 {
+			t.Errorf("%s: touched %v, want once", tt.key, store.touched)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package auth
+
+import "context"
+
+type Method string
+
+const (
+	MethodAPIKey    Method = "api_key"
+	MethodJWT       Method = "jwt"
+	MethodAnonymous Method = "anonymous"
+)
+
+// Identity describes the authenticated caller of an RPC.
+type Identity struct {
+	Subject string
+	Method  Method
+	KeyID   string
+	Claims  map[string]interface{}
+}
+
+type ctxKey struct{}
+
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(ctxKey{}).(*Identity)
+	return id, ok && id != nil
+}
+
+// Subject returns the caller's subject, or fallback for unauthenticated calls.
+func Subject(ctx context.Context, fallback string) string {
+	if id, ok := FromContext(ctx); ok && id.Method != MethodAnonymous {
+		return id.Subject
+	}
+	return fallback
+}
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const APIKeyMetadataKey = "x-api-key"
+
+type Authenticator struct {
+	keys     KeyStore
+	jwt      *JWTVerifier
+	disabled bool
+	public   map[string]bool
+}
+
+// NewAuthenticator builds the credential check. Calls to publicMethods may
+// omit credentials; they then run without an identity.
+func NewAuthenticator(keys KeyStore, jwt *JWTVerifier, disabled bool, publicMethods ...string) *Authenticator {
+	public := make(map[string]bool, len(publicMethods))
+	for _, m := range publicMethods {
+		public[m] = true
+	}
+	return &Authenticator{
+		keys:     keys,
+		jwt:      jwt,
+		disabled: disabled,
+		public:   public,
+	}
+}
+
+func (a *Authenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			log.Warn().Err(err).Str("method", info.FullMethod).Msg("Rejected unauthenticated call")
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a *Authenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			log.Warn().Err(err).Str("method", info.FullMethod).Msg("Rejected unauthenticated stream")
+			return err
+		}
+		return handler(srv, &identityStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (a *Authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if a.disabled {
+		return NewContext(ctx, &Identity{Subject: "anonymous", Method: MethodAnonymous}), nil
+	}
+
+	token := credentialsFromMetadata(ctx)
+	if token == "" && a.public[method] {
+		return ctx, nil
+	}
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing credentials: send an API key or a bearer token")
+	}
+
+	var (
+		id  *Identity
+		err error
+	)
+	switch {
+	case IsAPIKey(token):
+		id, err = verifyAPIKey(ctx, a.keys, token)
+	case a.jwt != nil && a.jwt.Enabled():
+		id, err = a.jwt.Verify(token)
+	default:
+		err = ErrInvalidToken
+	}
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return NewContext(ctx, id), nil
+}
+
+func credentialsFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if v := md.Get(APIKeyMetadataKey); len(v) > 0 && v[0] != "" {
+		return v[0]
+	}
+	if v := md.Get("authorization"); len(v) > 0 {
+		if token, ok := strings.CutPrefix(v[0], "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
+
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"collectionsservice/internal/models"
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthenticate(t *testing.T) {
+	store := &fakeKeys{byHash: map[string]*models.APIKey{HashToken("csk_live"): {ID: "k1", Subject: "alice"}}}
+	jwtV, _ := NewJWTVerifier(testSecret, "", "", "")
+	token := signHS(t, claims("bob", time.Now().Add(time.Hour)), testSecret)
+	const public = "/pkg.Service/Public"
+
+	tests := []struct {
+		name     string
+		disabled bool
+		method   string
+		md       metadata.MD
+		wantCode codes.Code
+		// wantID is nil when the call runs without an identity.
+		wantID *Identity
+	}{
+		{name: "auth disabled", disabled: true, method: "/pkg.Service/Get",
+			wantID: &Identity{Subject: "anonymous", Method: MethodAnonymous}},
+		{name: "auth disabled ignores bad credentials", disabled: true, md: metadata.Pairs(APIKeyMetadataKey, "csk_bad"),
+			wantID: &Identity{Subject: "anonymous", Method: MethodAnonymous}},
+		{name: "missing credentials", wantCode: codes.Unauthenticated},
+		{name: "public method without credentials", method: public},
+		{name: "public method with bad credentials", method: public, md: metadata.Pairs(APIKeyMetadataKey, "csk_bad"), wantCode: codes.Unauthenticated},
+		{name: "api key header", md: metadata.Pairs(APIKeyMetadataKey, "csk_live"),
+			wantID: &Identity{Subject: "alice", Method: MethodAPIKey, KeyID: "k1"}},
+		{name: "api key as bearer", md: metadata.Pairs("authorization", "Bearer csk_live"),
+			wantID: &Identity{Subject: "alice", Method: MethodAPIKey, KeyID: "k1"}},
+		{name: "jwt bearer", md: metadata.Pairs("authorization", "Bearer "+token),
+			wantID: &Identity{Subject: "bob", Method: MethodJWT}},
+		{name: "unknown api key", md: metadata.Pairs(APIKeyMetadataKey, "csk_nope"), wantCode: codes.Unauthenticated},
+		{name: "not a bearer scheme", md: metadata.Pairs("authorization", "Basic abc"), wantCode: codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAuthenticator(store, jwtV, tt.disabled, public)
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			method := tt.method
+			if method == "" {
+				method = "/pkg.Service/Get"
+			}
+
+			var got *Identity
+			_, err := a.UnaryInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+				got, _ = FromContext(ctx)
+				return nil, nil
+			})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %s, want %s (%v)", code, tt.wantCode, err)
+			}
+			switch {
+			case tt.wantID == nil && got != nil:
+				t.Errorf("identity %+v, want none", got)
+			case tt.wantID != nil && (got == nil || got.Subject != tt.wantID.Subject || got.Method != tt.wantID.Method || got.KeyID != tt.wantID.KeyID):
+				t.Errorf("identity %+v, want %+v", got, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestSubject(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"no identity", context.Background(), "fallback"},
+		{"anonymous", NewContext(context.Background(), &Identity{Subject: "anonymous", Method: MethodAnonymous}), "fallback"},
+		{"jwt", NewContext(context.Background(), &Identity{Subject: "bob", Method: MethodJWT}), "bob"},
+	}
+	for _, tt := range tests {
+		if got := Subject(tt.ctx, "fallback"); got != tt.want {
+			t.Errorf("%s: Subject = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
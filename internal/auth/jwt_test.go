@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "hs256-test-secret"
+
+func rsaKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// writeJWKS stores the public halves of keys, by kid, as a JWKS file.
+func writeJWKS(t *testing.T, keys map[string]*rsa.PrivateKey) string {
+	t.Helper()
+	type jwk struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	for kid, k := range keys {
+		set.Keys = append(set.Keys, jwk{
+			Kty: "RSA", Kid: kid, Use: "sig",
+			N: base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			E: base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		})
+	}
+	raw, _ := json.Marshal(set)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func claims(sub string, exp time.Time) jwt.MapClaims {
+	return jwt.MapClaims{"sub": sub, "exp": exp.Unix(), "iss": "https://issuer.test", "aud": "collections"}
+}
+
+func signHS(t *testing.T, c jwt.MapClaims, secret string) string {
+	t.Helper()
+	s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func signRS(t *testing.T, c jwt.MapClaims, key *rsa.PrivateKey, kid string) string {
+	t.Helper()
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, c)
+	if kid != "" {
+		tok.Header["kid"] = kid
+	}
+	s, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestJWTVerify(t *testing.T) {
+	first, second, stranger := rsaKey(t), rsaKey(t), rsaKey(t)
+	jwks := writeJWKS(t, map[string]*rsa.PrivateKey{"first": first, "second": second})
+	v, err := NewJWTVerifier(testSecret, jwks, "https://issuer.test", "collections")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hsOnly, _ := NewJWTVerifier(testSecret, "", "", "")
+	rsOnly, _ := NewJWTVerifier("", writeJWKS(t, map[string]*rsa.PrivateKey{"only": first}), "", "")
+
+	valid := time.Now().Add(time.Hour)
+	tests := []struct {
+		name     string
+		v        *JWTVerifier
+		token    string
+		wantSub  string
+		wantFail bool
+	}{
+		{name: "HS256", v: v, token: signHS(t, claims("alice", valid), testSecret), wantSub: "alice"},
+		{name: "HS256 with the wrong secret", v: v, token: signHS(t, claims("alice", valid), "other"), wantFail: true},
+		{name: "RS256 first kid", v: v, token: signRS(t, claims("bob", valid), first, "first"), wantSub: "bob"},
+		{name: "RS256 second kid", v: v, token: signRS(t, claims("carol", valid), second, "second"), wantSub: "carol"},
+		{name: "RS256 kid of another key", v: v, token: signRS(t, claims("bob", valid), first, "second"), wantFail: true},
+		{name: "RS256 unknown kid", v: v, token: signRS(t, claims("bob", valid), first, "third"), wantFail: true},
+		{name: "RS256 unlisted key", v: v, token: signRS(t, claims("bob", valid), stranger, "first"), wantFail: true},
+		{name: "RS256 without kid and several keys", v: v, token: signRS(t, claims("bob", valid), first, ""), wantFail: true},
+		{name: "RS256 without kid and one key", v: rsOnly, token: signRS(t, claims("dave", valid), first, ""), wantSub: "dave"},
+		{name: "RS256 when only HS256 is configured", v: hsOnly, token: signRS(t, claims("bob", valid), first, "first"), wantFail: true},
+		{name: "HS256 when only RS256 is configured", v: rsOnly, token: signHS(t, claims("alice", valid), ""), wantFail: true},
+		{name: "expired", v: v, token: signHS(t, claims("alice", time.Now().Add(-time.Minute)), testSecret), wantFail: true},
+		{name: "no expiry", v: v, token: signHS(t, jwt.MapClaims{"sub": "alice", "iss": "https://issuer.test", "aud": "collections"}, testSecret), wantFail: true},
+		{name: "wrong issuer", v: v, token: signHS(t, jwt.MapClaims{"sub": "alice", "exp": valid.Unix(), "iss": "https://evil.test", "aud": "collections"}, testSecret), wantFail: true},
+		{name: "wrong audience", v: v, token: signHS(t, jwt.MapClaims{"sub": "alice", "exp": valid.Unix(), "iss": "https://issuer.test", "aud": "billing"}, testSecret), wantFail: true},
+		{name: "no subject", v: v, token: signHS(t, claims("", valid), testSecret), wantFail: true},
+		{name: "unsigned", v: v, token: func() string {
+			s, _ := jwt.NewWithClaims(jwt.SigningMethodNone, claims("alice", valid)).SignedString(jwt.UnsafeAllowNoneSignatureType)
+			return s
+		}(), wantFail: true},
+		{name: "garbage", v: v, token: "not.a.jwt", wantFail: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := tt.v.Verify(tt.token)
+			if tt.wantFail {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Fatalf("Verify = %v, %v; want ErrInvalidToken", id, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id.Subject != tt.wantSub || id.Method != MethodJWT {
+				t.Errorf("identity %+v, want subject %q via JWT", id, tt.wantSub)
+			}
+		})
+	}
+}
+
+func TestNewJWTVerifier(t *testing.T) {
+	if v, _ := NewJWTVerifier("", "", "", ""); v.Enabled() {
+		t.Error("verifier without keys is enabled")
+	}
+
+	dir := t.TempDir()
+	noRSA := filepath.Join(dir, "ec.json")
+	_ = os.WriteFile(noRSA, []byte(`{"keys":[{"kty":"EC","kid":"e"}]}`), 0o600)
+	encOnly := filepath.Join(dir, "enc.json")
+	_ = os.WriteFile(encOnly, []byte(`{"keys":[{"kty":"RSA","kid":"e","use":"enc","n":"AQAB","e":"AQAB"}]}`), 0o600)
+	broken := filepath.Join(dir, "broken.json")
+	_ = os.WriteFile(broken, []byte(`{`), 0o600)
+
+	for _, path := range []string{noRSA, encOnly, broken, filepath.Join(dir, "missing.json")} {
+		if _, err := NewJWTVerifier("", path, "", ""); err == nil {
+			t.Errorf("JWKS file %s accepted", filepath.Base(path))
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package auth
+
+import "strings"
+
+// ShareTokenPrefix marks a collection share token. Share tokens are passed in
+// request messages, never as call credentials.
+const ShareTokenPrefix = "css_"
+
+func GenerateShareToken() (plaintext, prefix, hash string, err error) {
+	return generateToken(ShareTokenPrefix)
+}
+
+func IsShareToken(token string) bool {
+	return strings.HasPrefix(token, ShareTokenPrefix)
+}
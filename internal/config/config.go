@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -46,3 +49,175 @@ func GetPostgresDSN() string {
 		host, user, password, dbname, port, sslmode, timezone,
 	)
 }
+
+type AuthConfig struct {
+	Disabled        bool
+	JWTSecret       string
+	JWKSFile        string
+	JWTIssuer       string
+	JWTAudience     string
+	BootstrapAPIKey string
+	AdminSubjects   []string
+}
+
+func GetAuthConfig() AuthConfig {
+	disabled, _ := strconv.ParseBool(GetEnvWithDefault("AUTH_DISABLED", "false"))
+	var admins []string
+	for _, subject := range strings.Split(os.Getenv("AUTH_ADMIN_SUBJECTS"), ",") {
+		if subject = strings.TrimSpace(subject); subject != "" {
+			admins = append(admins, subject)
+		}
+	}
+	return AuthConfig{
+		Disabled:        disabled,
+		JWTSecret:       os.Getenv("AUTH_JWT_SECRET"),
+		JWKSFile:        os.Getenv("AUTH_JWKS_FILE"),
+		JWTIssuer:       os.Getenv("AUTH_JWT_ISSUER"),
+		JWTAudience:     os.Getenv("AUTH_JWT_AUDIENCE"),
+		BootstrapAPIKey: os.Getenv("AUTH_BOOTSTRAP_API_KEY"),
+		AdminSubjects:   admins,
+	}
+}
+
+type SecretsConfig struct {
+	KeyFile      string
+	Keys         string
+	PrimaryKeyID string
+}
+
+func GetSecretsConfig() SecretsConfig {
+	return SecretsConfig{
+		KeyFile:      os.Getenv("SECRETS_KEY_FILE"),
+		Keys:         os.Getenv("SECRETS_KEYS"),
+		PrimaryKeyID: os.Getenv("SECRETS_PRIMARY_KEY_ID"),
+	}
+}
+
+func GetExecutorTimeout() time.Duration {
+	seconds, err := strconv.Atoi(GetEnvWithDefault("EXECUTOR_TIMEOUT_SECONDS", "30"))
+	if err != nil || seconds <= 0 {
+		seconds = 30
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func GetScriptTimeout() time.Duration {
+	seconds, err := strconv.Atoi(GetEnvWithDefault("SCRIPT_TIMEOUT_SECONDS", "5"))
+	if err != nil || seconds <= 0 {
+		seconds = 5
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func GetScriptHeapLimit() uint64 {
+	mb, err := strconv.Atoi(GetEnvWithDefault("SCRIPT_HEAP_LIMIT_MB", "1024"))
+	if err != nil || mb <= 0 {
+		mb = 1024
+	}
+	return uint64(mb) << 20
+}
+
+func GetMockServerAddr() string {
+	return GetEnvWithDefault("MOCK_SERVER_ADDR", "localhost:8081")
+}
+
+func GetRunHistoryBodyLimit() int {
+	limit, err := strconv.Atoi(GetEnvWithDefault("RUN_HISTORY_MAX_BODY_BYTES", "65536"))
+	if err != nil || limit < 0 {
+		limit = 65536
+	}
+	return limit
+}
+
+type MonitorConfig struct {
+	Enabled      bool
+	PollInterval time.Duration
+}
+
+func GetMonitorConfig() MonitorConfig {
+	enabled, _ := strconv.ParseBool(GetEnvWithDefault("MONITORS_ENABLED", "true"))
+	seconds, err := strconv.Atoi(GetEnvWithDefault("MONITOR_POLL_SECONDS", "30"))
+	if err != nil || seconds <= 0 {
+		seconds = 30
+	}
+	return MonitorConfig{
+		Enabled:      enabled,
+		PollInterval: time.Duration(seconds) * time.Second,
+	}
+}
+
+type WebhookConfig struct {
+	Enabled      bool
+	PollInterval time.Duration
+	MaxAttempts  int
+	Timeout      time.Duration
+}
+
+func GetWebhookConfig() WebhookConfig {
+	enabled, _ := strconv.ParseBool(GetEnvWithDefault("WEBHOOKS_ENABLED", "true"))
+	seconds, err := strconv.Atoi(GetEnvWithDefault("WEBHOOK_POLL_SECONDS", "5"))
+	if err != nil || seconds <= 0 {
+		seconds = 5
+	}
+	attempts, err := strconv.Atoi(GetEnvWithDefault("WEBHOOK_MAX_ATTEMPTS", "8"))
+	if err != nil || attempts <= 0 {
+		attempts = 8
+	}
+	timeout, err := strconv.Atoi(GetEnvWithDefault("WEBHOOK_TIMEOUT_SECONDS", "10"))
+	if err != nil || timeout <= 0 {
+		timeout = 10
+	}
+	return WebhookConfig{
+		Enabled:      enabled,
+		PollInterval: time.Duration(seconds) * time.Second,
+		MaxAttempts:  attempts,
+		Timeout:      time.Duration(timeout) * time.Second,
+	}
+}
+
+type OutboxConfig struct {
+	Enabled      bool
+	PollInterval time.Duration
+	Retention    time.Duration
+	Sinks        []string
+}
+
+func GetOutboxConfig() OutboxConfig {
+	enabled, _ := strconv.ParseBool(GetEnvWithDefault("OUTBOX_RELAY_ENABLED", "true"))
+	seconds, err := strconv.Atoi(GetEnvWithDefault("OUTBOX_POLL_SECONDS", "2"))
+	if err != nil || seconds <= 0 {
+		seconds = 2
+	}
+	hours, err := strconv.Atoi(GetEnvWithDefault("OUTBOX_RETENTION_HOURS", "168"))
+	if err != nil || hours <= 0 {
+		hours = 168
+	}
+	var sinks []string
+	for _, sink := range strings.Split(GetEnvWithDefault("OUTBOX_SINKS", "webhook"), ",") {
+		if sink = strings.TrimSpace(sink); sink != "" {
+			sinks = append(sinks, sink)
+		}
+	}
+	return OutboxConfig{
+		Enabled:      enabled,
+		PollInterval: time.Duration(seconds) * time.Second,
+		Retention:    time.Duration(hours) * time.Hour,
+		Sinks:        sinks,
+	}
+}
+
+func GetIdempotencyTTL() time.Duration {
+	hours, err := strconv.Atoi(GetEnvWithDefault("IDEMPOTENCY_TTL_HOURS", "24"))
+	if err != nil || hours <= 0 {
+		hours = 24
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+func GetIdempotencyLease() time.Duration {
+	seconds, err := strconv.Atoi(GetEnvWithDefault("IDEMPOTENCY_LEASE_SECONDS", "300"))
+	if err != nil || seconds <= 0 {
+		seconds = 300
+	}
+	return time.Duration(seconds) * time.Second
+}
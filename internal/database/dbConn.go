@@ -3,6 +3,7 @@ package database
 import (
 	"collectionsservice/internal/config"
 	"collectionsservice/internal/models"
+	"fmt"
 
 	"github.com/rs/zerolog/log"
 	"gorm.io/driver/postgres"
@@ -12,7 +13,7 @@ import (
 func ConnectToDatabase() (*gorm.DB, error) {
 	dsn := config.GetPostgresDSN()
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Error().Err(err).Msg("Error connecting to the database")
 		return nil, err
@@ -29,10 +30,117 @@ func ConnectToDatabase() (*gorm.DB, error) {
 		return nil, err
 	}
 
-	if err := db.AutoMigrate(&models.Collection{}, &models.Request{}); err != nil {
+	if err := db.AutoMigrate(&models.Workspace{}); err != nil {
+		log.Error().Err(err).Msg("Failed auto-migrating workspaces")
+		return nil, err
+	}
+
+	defaultWorkspace := models.Workspace{ID: models.DefaultWorkspaceID, Name: "Default"}
+	if err := db.FirstOrCreate(&defaultWorkspace, "id = ?", models.DefaultWorkspaceID).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to create default workspace")
+		return nil, err
+	}
+
+	if err := prepareCollectionScope(db); err != nil {
+		log.Error().Err(err).Msg("Failed to prepare collections for workspace scoping")
+		return nil, err
+	}
+
+	if err := db.AutoMigrate(&models.Collection{}, &models.Request{}, &models.ChangeProposal{}, &models.ProposalComment{}, &models.APIKey{}, &models.WorkspaceMember{}, &models.CollectionMember{}, &models.CollectionShare{}, &models.Example{}, &models.RequestRun{}, &models.Monitor{}, &models.Webhook{}, &models.WebhookDelivery{}, &models.WebhookAttempt{}, &models.OutboxEvent{}, &models.AuditEvent{}, &models.IdempotencyKey{}); err != nil {
 		log.Error().Err(err).Msg("Failed auto-migrating tables")
 		return nil, err
 	}
 
+	if err := db.Exec("CREATE SEQUENCE IF NOT EXISTS outbox_positions").Error; err != nil {
+		log.Error().Err(err).Msg("Failed to create the outbox position sequence")
+		return nil, err
+	}
+
 	return db, nil
 }
+
+// prepareCollectionScope moves collections created before workspaces existed
+// into the default workspace and renames duplicate names within a workspace,
+// so the unique (workspace_id, name) index can be built by AutoMigrate.
+func prepareCollectionScope(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&models.Collection{}) {
+		return nil
+	}
+	if !migrator.HasColumn(&models.Collection{}, "WorkspaceID") {
+		if err := migrator.AddColumn(&models.Collection{}, "WorkspaceID"); err != nil {
+			return err
+		}
+	}
+
+	if err := db.Model(&models.Collection{}).Where("workspace_id IS NULL").
+		Update("workspace_id", models.DefaultWorkspaceID).Error; err != nil {
+		return err
+	}
+
+	var rows []collectionName
+	if err := db.Model(&models.Collection{}).Select("id", "workspace_id", "name").Order("id").Find(&rows).Error; err != nil {
+		return err
+	}
+	renames := dedupeNames(rows)
+	if len(renames) == 0 {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, row := range rows {
+			name, ok := renames[row.ID]
+			if !ok {
+				continue
+			}
+			if err := tx.Model(&models.Collection{}).Where("id = ?", row.ID).Update("name", name).Error; err != nil {
+				return err
+			}
+			log.Info().Str("collection_id", row.ID).Str("name", name).Msg("Renamed duplicate collection")
+		}
+		return nil
+	})
+}
+
+type collectionName struct {
+	ID          string
+	WorkspaceID string
+	Name        string
+}
+
+// dedupeNames picks a new name for every collection whose name an earlier one
+// (by id) already holds in the same workspace. The first free "name (n)",
+// counting from 2, is used; a name is free when no collection has it and no
+// earlier rename took it.
+func dedupeNames(rows []collectionName) map[string]string {
+	taken := make(map[string]map[string]bool)
+	for _, row := range rows {
+		if taken[row.WorkspaceID] == nil {
+			taken[row.WorkspaceID] = make(map[string]bool)
+		}
+		taken[row.WorkspaceID][row.Name] = true
+	}
+
+	renames := make(map[string]string)
+	kept := make(map[[2]string]bool)
+	next := make(map[[2]string]int)
+	for _, row := range rows {
+		key := [2]string{row.WorkspaceID, row.Name}
+		if !kept[key] {
+			kept[key] = true
+			continue
+		}
+		n := next[key]
+		if n == 0 {
+			n = 2
+		}
+		candidate := fmt.Sprintf("%s (%d)", row.Name, n)
+		for taken[row.WorkspaceID][candidate] {
+			n++
+			candidate = fmt.Sprintf("%s (%d)", row.Name, n)
+		}
+		next[key] = n + 1
+		taken[row.WorkspaceID][candidate] = true
+		renames[row.ID] = candidate
+	}
+	return renames
+}
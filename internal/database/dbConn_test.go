@@ -0,0 +1,45 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDedupeNames(t *testing.T) {
+	tests := []struct {
+		name string
+		rows []collectionName
+		want map[string]string
+	}{
+		{
+			name: "unique names are kept",
+			rows: []collectionName{{"1", "w", "a"}, {"2", "w", "b"}, {"3", "v", "a"}},
+			want: map[string]string{},
+		},
+		{
+			name: "duplicates are numbered from 2",
+			rows: []collectionName{{"1", "w", "a"}, {"2", "w", "a"}, {"3", "w", "a"}},
+			want: map[string]string{"2": "a (2)", "3": "a (3)"},
+		},
+		{
+			name: "existing numbered name is skipped",
+			rows: []collectionName{{"1", "w", "a"}, {"2", "w", "a"}, {"3", "w", "a (2)"}, {"4", "w", "a (3)"}},
+			want: map[string]string{"2": "a (4)"},
+		},
+		{
+			name: "renamed duplicate clashing with its own name is skipped",
+			rows: []collectionName{{"1", "w", "a (2)"}, {"2", "w", "a (2)"}, {"3", "w", "a"}, {"4", "w", "a"}, {"5", "w", "a"}},
+			want: map[string]string{"2": "a (2) (2)", "4": "a (3)", "5": "a (4)"},
+		},
+		{
+			name: "other workspaces do not block a name",
+			rows: []collectionName{{"1", "w", "a"}, {"2", "w", "a"}, {"3", "v", "a (2)"}},
+			want: map[string]string{"2": "a (2)"},
+		},
+	}
+	for _, tt := range tests {
+		if got := dedupeNames(tt.rows); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: dedupeNames = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
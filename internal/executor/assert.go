@@ -0,0 +1,253 @@
+package executor
+
+import (
+	"bytes"
+	"collectionsservice/internal/models"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/PaesslerAG/jsonpath"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"gorm.io/datatypes"
+)
+
+type AssertionResult struct {
+	Index     int
+	Assertion models.Assertion
+	Passed    bool
+	Message   string
+}
+
+// ParseAssertions decodes a stored assertion list.
+func ParseAssertions(raw datatypes.JSON) ([]models.Assertion, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var list []models.Assertion
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("invalid assertions: %w", err)
+	}
+	return list, nil
+}
+
+// CheckAssertion reports whether a is complete and its expression, pattern
+// or schema compiles.
+func CheckAssertion(a models.Assertion) error {
+	switch a.Type {
+	case models.AssertStatusEquals:
+		if a.Number < 100 || a.Number > 599 {
+			return fmt.Errorf("expected status must be between 100 and 599")
+		}
+	case models.AssertStatusInRange:
+		if a.Min < 100 || a.Max > 599 || a.Min > a.Max {
+			return fmt.Errorf("status range must satisfy 100 <= min <= max <= 599")
+		}
+	case models.AssertHeaderPresent, models.AssertHeaderEquals:
+		if a.Target == "" {
+			return fmt.Errorf("header name cannot be empty")
+		}
+	case models.AssertJSONPathEquals, models.AssertJSONPathContains, models.AssertJSONPathMatches:
+		if _, err := jsonpath.New(a.Target); err != nil {
+			return fmt.Errorf("invalid JSONPath %q: %w", a.Target, err)
+		}
+		if a.Type == models.AssertJSONPathMatches {
+			if _, err := regexp.Compile(a.Value); err != nil {
+				return fmt.Errorf("invalid regular expression: %w", err)
+			}
+		}
+	case models.AssertResponseTimeBelow:
+		if a.Number <= 0 {
+			return fmt.Errorf("response time limit must be positive")
+		}
+	case models.AssertJSONSchema:
+		if _, err := compileSchema(a.Value); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown assertion type %q", a.Type)
+	}
+	return nil
+}
+
+// Assert evaluates every assertion against res.
+func Assert(list []models.Assertion, res *Result) []AssertionResult {
+	if len(list) == 0 {
+		return nil
+	}
+
+	var body interface{}
+	var bodyErr error
+	bodyParsed := false
+	parsedBody := func() (interface{}, error) {
+		if !bodyParsed {
+			bodyParsed = true
+			dec := json.NewDecoder(bytes.NewReader(res.Body))
+			dec.UseNumber()
+			if bodyErr = dec.Decode(&body); bodyErr != nil {
+				bodyErr = fmt.Errorf("response body is not JSON")
+			}
+		}
+		return body, bodyErr
+	}
+
+	results := make([]AssertionResult, 0, len(list))
+	for i, a := range list {
+		passed, msg := assert(a, res, parsedBody)
+		results = append(results, AssertionResult{Index: i, Assertion: a, Passed: passed, Message: msg})
+	}
+	return results
+}
+
+func assert(a models.Assertion, res *Result, parsedBody func() (interface{}, error)) (bool, string) {
+	switch a.Type {
+	case models.AssertStatusEquals:
+		return int64(res.StatusCode) == a.Number, fmt.Sprintf("expected status %d, got %d", a.Number, res.StatusCode)
+	case models.AssertStatusInRange:
+		code := int64(res.StatusCode)
+		return code >= a.Min && code <= a.Max, fmt.Sprintf("expected status in %d..%d, got %d", a.Min, a.Max, res.StatusCode)
+	case models.AssertHeaderPresent:
+		_, ok := res.Headers[http.CanonicalHeaderKey(a.Target)]
+		return ok, fmt.Sprintf("expected header %s to be present", a.Target)
+	case models.AssertHeaderEquals:
+		got := res.Headers.Get(a.Target)
+		return got == a.Value, fmt.Sprintf("expected header %s to be %q, got %q", a.Target, a.Value, got)
+	case models.AssertResponseTimeBelow:
+		ms := res.Duration.Milliseconds()
+		return ms < a.Number, fmt.Sprintf("expected response time below %dms, got %dms", a.Number, ms)
+	case models.AssertJSONSchema:
+		body, err := parsedBody()
+		if err != nil {
+			return false, err.Error()
+		}
+		schema, err := compileSchema(a.Value)
+		if err != nil {
+			return false, err.Error()
+		}
+		if err := schema.Validate(body); err != nil {
+			return false, fmt.Sprintf("body does not match schema: %v", err)
+		}
+		return true, "body matches schema"
+	}
+
+	body, err := parsedBody()
+	if err != nil {
+		return false, err.Error()
+	}
+	got, err := jsonpath.Get(a.Target, body)
+	if err != nil {
+		return false, fmt.Sprintf("%s: %v", a.Target, err)
+	}
+
+	switch a.Type {
+	case models.AssertJSONPathEquals:
+		return jsonEqual(got, expectedValue(a.Value)), fmt.Sprintf("expected %s to equal %s, got %s", a.Target, a.Value, render(got))
+	case models.AssertJSONPathContains:
+		return contains(got, a.Value), fmt.Sprintf("expected %s to contain %s, got %s", a.Target, a.Value, render(got))
+	case models.AssertJSONPathMatches:
+		re, err := regexp.Compile(a.Value)
+		if err != nil {
+			return false, err.Error()
+		}
+		return re.MatchString(text(got)), fmt.Sprintf("expected %s to match %s, got %s", a.Target, a.Value, render(got))
+	}
+	return false, fmt.Sprintf("unknown assertion type %q", a.Type)
+}
+
+const schemaURL = "urn:collections:assertion"
+
+func compileSchema(src string) (*jsonschema.Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(src))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON Schema: %w", err)
+	}
+	c := jsonschema.NewCompiler()
+	// Schemas are self-contained: $ref may not reach files or the network.
+	c.UseLoader(jsonschema.SchemeURLLoader{})
+	if err := c.AddResource(schemaURL, doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON Schema: %w", err)
+	}
+	schema, err := c.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON Schema: %w", err)
+	}
+	return schema, nil
+}
+
+// expectedValue reads an expected value as JSON, falling back to the raw
+// string, so "42", "true" and "\"42\"" are told apart.
+func expectedValue(s string) interface{} {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return s
+	}
+	return v
+}
+
+func jsonEqual(a, b interface{}) bool {
+	return reflect.DeepEqual(normalizeNumbers(a), normalizeNumbers(b))
+}
+
+func contains(got interface{}, expected string) bool {
+	switch v := got.(type) {
+	case string:
+		return strings.Contains(v, expected)
+	case []interface{}:
+		want := expectedValue(expected)
+		for _, item := range v {
+			if jsonEqual(item, want) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		_, ok := v[expected]
+		return ok
+	}
+	return false
+}
+
+// normalizeNumbers turns json.Number values into float64 so numbers compare
+// equal however they were written.
+func normalizeNumbers(v interface{}) interface{} {
+	switch t := v.(type) {
+	case json.Number:
+		f, err := t.Float64()
+		if err != nil {
+			return t.String()
+		}
+		return f
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, item := range t {
+			out[i] = normalizeNumbers(item)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, item := range t {
+			out[k] = normalizeNumbers(item)
+		}
+		return out
+	}
+	return v
+}
+
+func text(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return render(v)
+}
+
+func render(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(raw)
+}
@@ -0,0 +1,110 @@
+package executor
+
+import (
+	"collectionsservice/internal/models"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAssert(t *testing.T) {
+	res := &Result{
+		StatusCode: 201,
+		Headers:    http.Header{"Content-Type": {"application/json"}, "X-Request-Id": {"abc"}},
+		Body:       []byte(`{"id": 42, "name": "Ada Lovelace", "ratio": 1.50, "active": true, "tags": ["admin", "ops"], "owner": {"id": "42"}, "items": [{"n": 1}, {"n": 2}]}`),
+		Duration:   120 * time.Millisecond,
+	}
+	tests := []struct {
+		name string
+		a    models.Assertion
+		want bool
+	}{
+		{"status equals", models.Assertion{Type: models.AssertStatusEquals, Number: 201}, true},
+		{"status differs", models.Assertion{Type: models.AssertStatusEquals, Number: 200}, false},
+		{"status in range", models.Assertion{Type: models.AssertStatusInRange, Min: 200, Max: 299}, true},
+		{"status range bounds are inclusive", models.Assertion{Type: models.AssertStatusInRange, Min: 201, Max: 201}, true},
+		{"status out of range", models.Assertion{Type: models.AssertStatusInRange, Min: 400, Max: 499}, false},
+		{"header present any case", models.Assertion{Type: models.AssertHeaderPresent, Target: "x-request-id"}, true},
+		{"header missing", models.Assertion{Type: models.AssertHeaderPresent, Target: "ETag"}, false},
+		{"header equals", models.Assertion{Type: models.AssertHeaderEquals, Target: "content-type", Value: "application/json"}, true},
+		{"header value differs", models.Assertion{Type: models.AssertHeaderEquals, Target: "Content-Type", Value: "text/plain"}, false},
+		{"response time below", models.Assertion{Type: models.AssertResponseTimeBelow, Number: 500}, true},
+		{"response time at limit", models.Assertion{Type: models.AssertResponseTimeBelow, Number: 120}, false},
+		{"jsonpath number", models.Assertion{Type: models.AssertJSONPathEquals, Target: "$.id", Value: "42"}, true},
+		{"jsonpath number written differently", models.Assertion{Type: models.AssertJSONPathEquals, Target: "$.ratio", Value: "1.5"}, true},
+		{"jsonpath number is not a string", models.Assertion{Type: models.AssertJSONPathEquals, Target: "$.id", Value: `"42"`}, false},
+		{"jsonpath quoted string", models.Assertion{Type: models.AssertJSONPathEquals, Target: "$.owner.id", Value: `"42"`}, true},
+		{"jsonpath bare string", models.Assertion{Type: models.AssertJSONPathEquals, Target: "$.name", Value: "Ada Lovelace"}, true},
+		{"jsonpath bool", models.Assertion{Type: models.AssertJSONPathEquals, Target: "$.active", Value: "true"}, true},
+		{"jsonpath object", models.Assertion{Type: models.AssertJSONPathEquals, Target: "$.items[1]", Value: `{"n": 2}`}, true},
+		{"jsonpath wildcard", models.Assertion{Type: models.AssertJSONPathEquals, Target: "$.items[*].n", Value: "[1, 2]"}, true},
+		{"jsonpath missing key", models.Assertion{Type: models.AssertJSONPathEquals, Target: "$.missing", Value: "1"}, false},
+		{"contains substring", models.Assertion{Type: models.AssertJSONPathContains, Target: "$.name", Value: "Love"}, true},
+		{"contains array item", models.Assertion{Type: models.AssertJSONPathContains, Target: "$.tags", Value: "admin"}, true},
+		{"contains structured item", models.Assertion{Type: models.AssertJSONPathContains, Target: "$.items", Value: `{"n": 1}`}, true},
+		{"contains object key", models.Assertion{Type: models.AssertJSONPathContains, Target: "$.owner", Value: "id"}, true},
+		{"does not contain", models.Assertion{Type: models.AssertJSONPathContains, Target: "$.tags", Value: "guest"}, false},
+		{"number contains nothing", models.Assertion{Type: models.AssertJSONPathContains, Target: "$.id", Value: "4"}, false},
+		{"matches string", models.Assertion{Type: models.AssertJSONPathMatches, Target: "$.name", Value: `^Ada\s`}, true},
+		{"matches rendered number", models.Assertion{Type: models.AssertJSONPathMatches, Target: "$.id", Value: `^\d+$`}, true},
+		{"does not match", models.Assertion{Type: models.AssertJSONPathMatches, Target: "$.name", Value: `^Grace`}, false},
+		{"schema matches", models.Assertion{Type: models.AssertJSONSchema, Value: `{"type": "object", "required": ["id", "name"], "properties": {"id": {"type": "integer"}}}`}, true},
+		{"schema rejects", models.Assertion{Type: models.AssertJSONSchema, Value: `{"type": "object", "properties": {"id": {"type": "string"}}}`}, false},
+		{"unknown type", models.Assertion{Type: "status_below", Number: 300}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := Assert([]models.Assertion{tt.a}, res)
+			if len(results) != 1 {
+				t.Fatalf("got %d results", len(results))
+			}
+			if r := results[0]; r.Passed != tt.want || r.Message == "" {
+				t.Errorf("passed = %v (%q), want %v", r.Passed, r.Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssertBodyNotJSON(t *testing.T) {
+	res := &Result{StatusCode: 200, Body: []byte("<html></html>")}
+	results := Assert([]models.Assertion{
+		{Type: models.AssertStatusEquals, Number: 200},
+		{Type: models.AssertJSONPathEquals, Target: "$.id", Value: "1"},
+		{Type: models.AssertJSONSchema, Value: `{"type": "object"}`},
+	}, res)
+	for i, want := range []bool{true, false, false} {
+		if results[i].Index != i || results[i].Passed != want {
+			t.Errorf("result %d = %+v, want passed %v", i, results[i], want)
+		}
+	}
+	if msg := results[1].Message; msg != "response body is not JSON" {
+		t.Errorf("message %q", msg)
+	}
+}
+
+func TestCheckAssertion(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       models.Assertion
+		wantErr bool
+	}{
+		{"status", models.Assertion{Type: models.AssertStatusEquals, Number: 204}, false},
+		{"status too low", models.Assertion{Type: models.AssertStatusEquals, Number: 99}, true},
+		{"range", models.Assertion{Type: models.AssertStatusInRange, Min: 200, Max: 299}, false},
+		{"range reversed", models.Assertion{Type: models.AssertStatusInRange, Min: 299, Max: 200}, true},
+		{"header without name", models.Assertion{Type: models.AssertHeaderPresent}, true},
+		{"jsonpath", models.Assertion{Type: models.AssertJSONPathEquals, Target: "$.a[0].b"}, false},
+		{"bad jsonpath", models.Assertion{Type: models.AssertJSONPathContains, Target: "$.a["}, true},
+		{"bad pattern", models.Assertion{Type: models.AssertJSONPathMatches, Target: "$.a", Value: "("}, true},
+		{"response time", models.Assertion{Type: models.AssertResponseTimeBelow, Number: 0}, true},
+		{"schema", models.Assertion{Type: models.AssertJSONSchema, Value: `{"type": "string"}`}, false},
+		{"schema not JSON", models.Assertion{Type: models.AssertJSONSchema, Value: `{`}, true},
+		{"schema with remote ref", models.Assertion{Type: models.AssertJSONSchema, Value: `{"$ref": "https://example.com/schema.json"}`}, true},
+		{"unknown type", models.Assertion{Type: "nope"}, true},
+	}
+	for _, tt := range tests {
+		if err := CheckAssertion(tt.a); (err != nil) != tt.wantErr {
+			t.Errorf("%s: CheckAssertion = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
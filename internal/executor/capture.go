@@ -0,0 +1,124 @@
+package executor
+
+import (
+	"bytes"
+	"collectionsservice/internal/models"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/PaesslerAG/jsonpath"
+	"gorm.io/datatypes"
+)
+
+type CaptureResult struct {
+	Capture  models.Capture
+	Captured bool
+	Value    string
+	Message  string
+}
+
+// ParseCaptures decodes a stored capture list.
+func ParseCaptures(raw datatypes.JSON) ([]models.Capture, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var list []models.Capture
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("invalid captures: %w", err)
+	}
+	return list, nil
+}
+
+var variableName = regexp.MustCompile(`^[\w.\-]+$`)
+
+// CheckCapture reports whether c is complete and its expression compiles.
+func CheckCapture(c models.Capture) error {
+	if !variableName.MatchString(c.Variable) {
+		return fmt.Errorf("variable name %q must only hold letters, digits, '_', '.' or '-'", c.Variable)
+	}
+	if c.Expression == "" {
+		return fmt.Errorf("capture expression cannot be empty")
+	}
+	switch c.Scope {
+	case "", models.CaptureScopeRun, models.CaptureScopeCollection:
+	default:
+		return fmt.Errorf("unknown capture scope %q", c.Scope)
+	}
+
+	switch c.Source {
+	case models.CaptureJSONPath:
+		if _, err := jsonpath.New(c.Expression); err != nil {
+			return fmt.Errorf("invalid JSONPath %q: %w", c.Expression, err)
+		}
+	case models.CaptureRegex:
+		if _, err := regexp.Compile(c.Expression); err != nil {
+			return fmt.Errorf("invalid regular expression: %w", err)
+		}
+	case models.CaptureHeader, models.CaptureCookie:
+	default:
+		return fmt.Errorf("unknown capture source %q", c.Source)
+	}
+	return nil
+}
+
+// Capture extracts every capture from res and sets it in vars. A capture
+// that finds nothing leaves its variable unchanged.
+func Capture(list []models.Capture, res *Result, vars map[string]string) []CaptureResult {
+	results := make([]CaptureResult, 0, len(list))
+	for _, c := range list {
+		value, err := extract(c, res)
+		if err != nil {
+			results = append(results, CaptureResult{Capture: c, Message: err.Error()})
+			continue
+		}
+		vars[c.Variable] = value
+		results = append(results, CaptureResult{Capture: c, Captured: true, Value: value})
+	}
+	return results
+}
+
+func extract(c models.Capture, res *Result) (string, error) {
+	switch c.Source {
+	case models.CaptureHeader:
+		values, ok := res.Headers[http.CanonicalHeaderKey(c.Expression)]
+		if !ok || len(values) == 0 {
+			return "", fmt.Errorf("header %s not found", c.Expression)
+		}
+		return values[0], nil
+	case models.CaptureCookie:
+		for _, cookie := range (&http.Response{Header: res.Headers}).Cookies() {
+			if cookie.Name == c.Expression {
+				return cookie.Value, nil
+			}
+		}
+		return "", fmt.Errorf("cookie %s not set", c.Expression)
+	case models.CaptureRegex:
+		re, err := regexp.Compile(c.Expression)
+		if err != nil {
+			return "", err
+		}
+		m := re.FindSubmatch(res.Body)
+		if m == nil {
+			return "", fmt.Errorf("body does not match %s", c.Expression)
+		}
+		if len(m) > 1 {
+			return string(m[1]), nil
+		}
+		return string(m[0]), nil
+	case models.CaptureJSONPath:
+		dec := json.NewDecoder(bytes.NewReader(res.Body))
+		dec.UseNumber()
+		var body interface{}
+		if err := dec.Decode(&body); err != nil {
+			return "", fmt.Errorf("response body is not JSON")
+		}
+		got, err := jsonpath.Get(c.Expression, body)
+		if err != nil {
+			return "", fmt.Errorf("%s: %v", c.Expression, err)
+		}
+		return text(got), nil
+	}
+	return "", fmt.Errorf("unknown capture source %q", c.Source)
+}
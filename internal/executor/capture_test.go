@@ -0,0 +1,106 @@
+package executor
+
+import (
+	"collectionsservice/internal/models"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCapture(t *testing.T) {
+	res := &Result{
+		StatusCode: 200,
+		Headers: http.Header{
+			"Location":   {"/users/42", "/ignored"},
+			"Set-Cookie": {"session=s3cr3t; Path=/; HttpOnly", "theme=dark"},
+		},
+		Body: []byte(`{"id": 42, "token": "tok-1", "ratio": 1.50, "user": {"name": "Ada"}, "tags": ["a", "b"]}`),
+	}
+	tests := []struct {
+		name      string
+		c         models.Capture
+		wantValue string
+		wantOK    bool
+	}{
+		{"jsonpath string", models.Capture{Source: models.CaptureJSONPath, Expression: "$.token"}, "tok-1", true},
+		{"jsonpath number keeps its text", models.Capture{Source: models.CaptureJSONPath, Expression: "$.ratio"}, "1.50", true},
+		{"jsonpath nested", models.Capture{Source: models.CaptureJSONPath, Expression: "$.user.name"}, "Ada", true},
+		{"jsonpath array as JSON", models.Capture{Source: models.CaptureJSONPath, Expression: "$.tags"}, `["a","b"]`, true},
+		{"jsonpath missing", models.Capture{Source: models.CaptureJSONPath, Expression: "$.missing"}, "", false},
+		{"header first value", models.Capture{Source: models.CaptureHeader, Expression: "location"}, "/users/42", true},
+		{"header missing", models.Capture{Source: models.CaptureHeader, Expression: "ETag"}, "", false},
+		{"cookie", models.Capture{Source: models.CaptureCookie, Expression: "session"}, "s3cr3t", true},
+		{"second cookie", models.Capture{Source: models.CaptureCookie, Expression: "theme"}, "dark", true},
+		{"cookie not set", models.Capture{Source: models.CaptureCookie, Expression: "csrf"}, "", false},
+		{"regex group", models.Capture{Source: models.CaptureRegex, Expression: `"token": "([^"]+)"`}, "tok-1", true},
+		{"regex whole match", models.Capture{Source: models.CaptureRegex, Expression: `tok-\d`}, "tok-1", true},
+		{"regex no match", models.Capture{Source: models.CaptureRegex, Expression: `nope-\d`}, "", false},
+		{"unknown source", models.Capture{Source: "query", Expression: "id"}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.c.Variable = "v"
+			vars := map[string]string{"v": "before"}
+			results := Capture([]models.Capture{tt.c}, res, vars)
+			if len(results) != 1 {
+				t.Fatalf("got %d results", len(results))
+			}
+			r := results[0]
+			if r.Captured != tt.wantOK || r.Value != tt.wantValue {
+				t.Fatalf("captured %v %q (%s), want %v %q", r.Captured, r.Value, r.Message, tt.wantOK, tt.wantValue)
+			}
+			want := tt.wantValue
+			if !tt.wantOK {
+				want = "before"
+				if r.Message == "" {
+					t.Error("failed capture has no message")
+				}
+			}
+			if vars["v"] != want {
+				t.Errorf("variable = %q, want %q", vars["v"], want)
+			}
+		})
+	}
+}
+
+func TestCaptureIntoVariables(t *testing.T) {
+	res := &Result{Headers: http.Header{"X-Next": {"page-2"}}, Body: []byte(`{"id": "7"}`)}
+	vars := map[string]string{"id": "old", "keep": "1"}
+	Capture([]models.Capture{
+		{Source: models.CaptureJSONPath, Expression: "$.id", Variable: "id"},
+		{Source: models.CaptureHeader, Expression: "X-Next", Variable: "next"},
+		{Source: models.CaptureJSONPath, Expression: "$.gone", Variable: "keep"},
+		{Source: models.CaptureRegex, Expression: `"(\d)"`, Variable: "next"},
+	}, res, vars)
+	want := map[string]string{"id": "7", "keep": "1", "next": "7"}
+	if !reflect.DeepEqual(vars, want) {
+		t.Errorf("vars = %v, want %v", vars, want)
+	}
+
+	results := Capture([]models.Capture{{Source: models.CaptureJSONPath, Expression: "$.id", Variable: "id"}}, &Result{Body: []byte("not json")}, vars)
+	if results[0].Captured || results[0].Message != "response body is not JSON" {
+		t.Errorf("capture from a non-JSON body = %+v", results[0])
+	}
+}
+
+func TestCheckCapture(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       models.Capture
+		wantErr bool
+	}{
+		{"jsonpath", models.Capture{Source: models.CaptureJSONPath, Expression: "$.a", Variable: "auth.token"}, false},
+		{"collection scope", models.Capture{Source: models.CaptureHeader, Expression: "ETag", Variable: "etag", Scope: models.CaptureScopeCollection}, false},
+		{"unknown scope", models.Capture{Source: models.CaptureHeader, Expression: "ETag", Variable: "etag", Scope: "global"}, true},
+		{"bad variable", models.Capture{Source: models.CaptureHeader, Expression: "ETag", Variable: "{{etag}}"}, true},
+		{"no expression", models.Capture{Source: models.CaptureCookie, Variable: "session"}, true},
+		{"bad jsonpath", models.Capture{Source: models.CaptureJSONPath, Expression: "$.a[", Variable: "a"}, true},
+		{"bad regex", models.Capture{Source: models.CaptureRegex, Expression: "(", Variable: "a"}, true},
+		{"unknown source", models.Capture{Source: "query", Expression: "a", Variable: "a"}, true},
+	}
+	for _, tt := range tests {
+		if err := CheckCapture(tt.c); (err != nil) != tt.wantErr {
+			t.Errorf("%s: CheckCapture = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package executor
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+var newCnonce = func() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+type digestChallenge struct {
+	realm     string
+	nonce     string
+	opaque    string
+	algorithm string
+	qop       string
+}
+
+func parseDigestChallenge(header string) (*digestChallenge, error) {
+	rest, ok := strings.CutPrefix(header, "Digest ")
+	if !ok {
+		return nil, errors.New("digest: server did not send a Digest challenge")
+	}
+
+	c := &digestChallenge{}
+	for _, part := range splitChallenge(rest) {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "realm":
+			c.realm = value
+		case "nonce":
+			c.nonce = value
+		case "opaque":
+			c.opaque = value
+		case "algorithm":
+			c.algorithm = value
+		case "qop":
+			for _, q := range strings.Split(value, ",") {
+				if strings.TrimSpace(q) == "auth" {
+					c.qop = "auth"
+				}
+			}
+		}
+	}
+	if c.nonce == "" {
+		return nil, errors.New("digest: challenge has no nonce")
+	}
+	return c, nil
+}
+
+// splitChallenge splits on commas outside quoted strings.
+func splitChallenge(s string) []string {
+	var parts []string
+	var cur strings.Builder
+	quoted := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			cur.WriteRune(r)
+		case r == ',' && !quoted:
+			parts = append(parts, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	return append(parts, cur.String())
+}
+
+func (c *digestChallenge) authorize(method, uri, username, password string) (string, error) {
+	var newHash func() hash.Hash
+	switch strings.ToUpper(c.algorithm) {
+	case "", "MD5":
+		newHash = md5.New
+	case "SHA-256":
+		newHash = sha256.New
+	default:
+		return "", fmt.Errorf("digest: unsupported algorithm %q", c.algorithm)
+	}
+	h := func(s string) string {
+		d := newHash()
+		d.Write([]byte(s))
+		return hex.EncodeToString(d.Sum(nil))
+	}
+
+	ha1 := h(username + ":" + c.realm + ":" + password)
+	ha2 := h(method + ":" + uri)
+
+	fields := []string{
+		fmt.Sprintf(`username="%s"`, username),
+		fmt.Sprintf(`realm="%s"`, c.realm),
+		fmt.Sprintf(`nonce="%s"`, c.nonce),
+		fmt.Sprintf(`uri="%s"`, uri),
+	}
+	if c.qop == "auth" {
+		cnonce, err := newCnonce()
+		if err != nil {
+			return "", err
+		}
+		const nc = "00000001"
+		response := h(ha1 + ":" + c.nonce + ":" + nc + ":" + cnonce + ":auth:" + ha2)
+		fields = append(fields, "qop=auth", "nc="+nc, fmt.Sprintf(`cnonce="%s"`, cnonce), fmt.Sprintf(`response="%s"`, response))
+	} else {
+		fields = append(fields, fmt.Sprintf(`response="%s"`, h(ha1+":"+c.nonce+":"+ha2)))
+	}
+	if c.algorithm != "" {
+		fields = append(fields, "algorithm="+c.algorithm)
+	}
+	if c.opaque != "" {
+		fields = append(fields, fmt.Sprintf(`opaque="%s"`, c.opaque))
+	}
+	return "Digest " + strings.Join(fields, ", "), nil
+}
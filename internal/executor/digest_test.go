@@ -0,0 +1,93 @@
+package executor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDigestAuthorize(t *testing.T) {
+	const (
+		rfc7616   = `Digest realm="http-auth@example.org", qop="auth, auth-int", algorithm=%s, nonce="7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v", opaque="FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS"`
+		rfc7616CN = "f2/wE4q74E6zIJEtWaHKaf5wv/H5QzzpXusqGemxURZJ"
+	)
+	tests := []struct {
+		name      string
+		challenge string
+		cnonce    string
+		user      string
+		password  string
+		want      string
+	}{
+		{
+			name:      "RFC 2617 section 3.5",
+			challenge: `Digest realm="testrealm@host.com", qop="auth,auth-int", nonce="dcd98b7102dd2f0e8b11d0f600bfb0c093", opaque="5ccc069c403ebaf9f0171e9517f40e41"`,
+			cnonce:    "0a4f113b",
+			user:      "Mufasa", password: "Circle Of Life",
+			want: `Digest username="Mufasa", realm="testrealm@host.com", nonce="dcd98b7102dd2f0e8b11d0f600bfb0c093", uri="/dir/index.html", ` +
+				`qop=auth, nc=00000001, cnonce="0a4f113b", response="6629fae49393a05397450978507c4ef1", opaque="5ccc069c403ebaf9f0171e9517f40e41"`,
+		},
+		{
+			name:      "RFC 7616 section 3.9.1 SHA-256",
+			challenge: strings.Replace(rfc7616, "%s", "SHA-256", 1),
+			cnonce:    rfc7616CN,
+			user:      "Mufasa", password: "Circle of Life",
+			want: `Digest username="Mufasa", realm="http-auth@example.org", nonce="7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v", uri="/dir/index.html", ` +
+				`qop=auth, nc=00000001, cnonce="` + rfc7616CN + `", response="753927fa0e85d155564e2e272a28d1802ca10daf4496794697cf8db5856cb6c1", ` +
+				`algorithm=SHA-256, opaque="FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS"`,
+		},
+		{
+			name:      "RFC 7616 section 3.9.1 MD5",
+			challenge: strings.Replace(rfc7616, "%s", "MD5", 1),
+			cnonce:    rfc7616CN,
+			user:      "Mufasa", password: "Circle of Life",
+			want: `Digest username="Mufasa", realm="http-auth@example.org", nonce="7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v", uri="/dir/index.html", ` +
+				`qop=auth, nc=00000001, cnonce="` + rfc7616CN + `", response="8ca523f5e9506fed4657c9700eebdbec", ` +
+				`algorithm=MD5, opaque="FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS"`,
+		},
+		{
+			name:      "RFC 2069 without qop",
+			challenge: `Digest realm="testrealm@host.com", nonce="dcd98b7102dd2f0e8b11d0f600bfb0c093"`,
+			user:      "Mufasa", password: "CircleOfLife",
+			want: `Digest username="Mufasa", realm="testrealm@host.com", nonce="dcd98b7102dd2f0e8b11d0f600bfb0c093", uri="/dir/index.html", ` +
+				`response="1949323746fe6a43ef61f9606e7febea"`,
+		},
+	}
+	defer func(orig func() (string, error)) { newCnonce = orig }(newCnonce)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newCnonce = func() (string, error) { return tt.cnonce, nil }
+			c, err := parseDigestChallenge(tt.challenge)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := c.authorize("GET", "/dir/index.html", tt.user, tt.password)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("authorize =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDigestChallenge(t *testing.T) {
+	c, err := parseDigestChallenge(`Digest realm="a, b", qop="auth-int", nonce="n", algorithm=SHA-256`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.realm != "a, b" || c.nonce != "n" || c.algorithm != "SHA-256" || c.qop != "" {
+		t.Errorf("parsed %+v", c)
+	}
+
+	for _, header := range []string{`Basic realm="x"`, `Digest realm="x"`, ""} {
+		if _, err := parseDigestChallenge(header); err == nil {
+			t.Errorf("challenge %q accepted", header)
+		}
+	}
+
+	c, _ = parseDigestChallenge(`Digest nonce="n", algorithm=SHA-512-256`)
+	if _, err := c.authorize("GET", "/", "u", "p"); err == nil {
+		t.Error("unsupported algorithm accepted")
+	}
+}
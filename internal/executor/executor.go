@@ -0,0 +1,188 @@
+// Package executor sends stored requests over HTTP.
+package executor
+
+import (
+	"bytes"
+	"collectionsservice/internal/models"
+	"collectionsservice/internal/scripting"
+	"collectionsservice/internal/secrets"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// maxResponseBody caps how much of a response body is kept.
+const maxResponseBody = 10 << 20
+
+type Executor struct {
+	client       *http.Client
+	tokens       *tokenCache
+	scriptLimits scripting.Limits
+}
+
+func New(timeout time.Duration, scriptLimits scripting.Limits) *Executor {
+	return &Executor{
+		client:       &http.Client{Timeout: timeout},
+		tokens:       newTokenCache(),
+		scriptLimits: scriptLimits,
+	}
+}
+
+type Result struct {
+	StatusCode int
+	Headers    http.Header
+	Body       []byte
+	Duration   time.Duration
+}
+
+// Sent is a request with its variables resolved.
+type Sent struct {
+	Method  string
+	URL     string
+	Headers http.Header
+	Body    []byte
+}
+
+// Execute sends r and returns its response. Secrets on r and collectionAuth
+// must already be opened. {{name}} placeholders are resolved from vars, and r
+// uses collectionAuth when it has no auth of its own.
+func (e *Executor) Execute(ctx context.Context, r *models.Request, collectionAuth datatypes.JSON, vars map[string]string) (*Result, error) {
+	out, err := buildOutgoing(r, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	auth := resolveAuth(r.Auth, collectionAuth)
+	if auth != nil {
+		interpolateAuth(auth, vars)
+	}
+
+	start := time.Now()
+	resp, err := e.send(ctx, out, auth)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return &Result{
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Header,
+		Body:       body,
+		Duration:   time.Since(start),
+	}, nil
+}
+
+// outgoing is a request that can be built more than once, which Digest auth
+// needs for its challenge round trip.
+type outgoing struct {
+	method string
+	url    *url.URL
+	header http.Header
+	body   []byte
+}
+
+func (o *outgoing) build(ctx context.Context) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, o.method, o.url.String(), bytes.NewReader(o.body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = o.header.Clone()
+	return req, nil
+}
+
+func buildOutgoing(r *models.Request, vars map[string]string) (*outgoing, error) {
+	out := &outgoing{header: http.Header{}}
+
+	var rawURL string
+	var headers []byte
+	switch r.Kind {
+	case models.RequestKindHTTP:
+		out.method = http.MethodGet
+		if r.HTTPMethod != nil && *r.HTTPMethod != "" {
+			out.method = *r.HTTPMethod
+		}
+		rawURL = deref(r.HTTPURL)
+		headers = r.HTTPHeaders
+		if r.HTTPBody != nil && *r.HTTPBody != "" {
+			out.body = []byte(Interpolate(*r.HTTPBody, vars))
+			out.header.Set("Content-Type", "application/json")
+		}
+	case models.RequestKindGraphQL:
+		out.method = http.MethodPost
+		rawURL = deref(r.GraphQLEndpoint)
+		headers = r.GraphQLHeaders
+		payload := map[string]interface{}{"query": Interpolate(deref(r.GraphQLQuery), vars)}
+		if len(r.GraphQLVariables) > 0 {
+			payload["variables"] = json.RawMessage(Interpolate(string(r.GraphQLVariables), vars))
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode GraphQL payload: %w", err)
+		}
+		out.body = body
+		out.header.Set("Content-Type", "application/json")
+	default:
+		return nil, fmt.Errorf("cannot execute request of kind %q", r.Kind)
+	}
+
+	u, err := url.Parse(Interpolate(rawURL, vars))
+	if err != nil {
+		return nil, fmt.Errorf("invalid request url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("request url must be http or https, got %q", u.String())
+	}
+	out.url = u
+
+	if entries, ok := secrets.ParseEntries(r.HTTPQueryParams); ok && r.Kind == models.RequestKindHTTP {
+		q := u.Query()
+		for _, e := range entries {
+			q.Add(Interpolate(e.Key, vars), Interpolate(e.Value, vars))
+		}
+		u.RawQuery = q.Encode()
+	}
+	if entries, ok := secrets.ParseEntries(headers); ok {
+		for _, e := range entries {
+			out.header.Add(Interpolate(e.Key, vars), Interpolate(e.Value, vars))
+		}
+	}
+
+	return out, nil
+}
+
+var placeholder = regexp.MustCompile(`\{\{\s*([\w.\-]+)\s*\}\}`)
+
+// Interpolate replaces {{name}} placeholders with their variable values.
+// Unknown placeholders are left as they are.
+func Interpolate(s string, vars map[string]string) string {
+	if len(vars) == 0 || !strings.Contains(s, "{{") {
+		return s
+	}
+	return placeholder.ReplaceAllStringFunc(s, func(m string) string {
+		name := placeholder.FindStringSubmatch(m)[1]
+		if v, ok := vars[name]; ok {
+			return v
+		}
+		return m
+	})
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,127 @@
+package executor
+
+import (
+	"collectionsservice/internal/models"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryMargin refreshes cached tokens this long before they expire.
+const tokenExpiryMargin = 30 * time.Second
+
+type cachedToken struct {
+	accessToken string
+	expiresAt   time.Time
+}
+
+// tokenCache keeps OAuth 2.0 access tokens per grant so a collection run does
+// not fetch a token for every request.
+type tokenCache struct {
+	mu     sync.Mutex
+	tokens map[string]cachedToken
+}
+
+func newTokenCache() *tokenCache {
+	return &tokenCache{tokens: make(map[string]cachedToken)}
+}
+
+func (c *tokenCache) get(ctx context.Context, client *http.Client, a *models.AuthConfig) (string, error) {
+	key := tokenCacheKey(a)
+
+	c.mu.Lock()
+	tok, ok := c.tokens[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(tok.expiresAt) {
+		return tok.accessToken, nil
+	}
+
+	tok, err := fetchToken(ctx, client, a)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	c.tokens[key] = tok
+	c.mu.Unlock()
+	return tok.accessToken, nil
+}
+
+// tokenCacheKey hashes everything that identifies a grant, secrets included,
+// so a rotated client secret never reuses a token fetched with the old one.
+func tokenCacheKey(a *models.AuthConfig) string {
+	h := sha256.New()
+	for _, part := range []string{a.OAuth2Grant, a.TokenURL, a.ClientID, a.ClientSecret, a.Username, a.Password, strings.Join(a.Scopes, " ")} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func fetchToken(ctx context.Context, client *http.Client, a *models.AuthConfig) (cachedToken, error) {
+	form := url.Values{}
+	switch a.OAuth2Grant {
+	case models.OAuth2Password:
+		form.Set("grant_type", "password")
+		form.Set("username", a.Username)
+		form.Set("password", a.Password)
+	case models.OAuth2ClientCredentials, "":
+		form.Set("grant_type", "client_credentials")
+	default:
+		return cachedToken{}, fmt.Errorf("unsupported grant %q", a.OAuth2Grant)
+	}
+	if len(a.Scopes) > 0 {
+		form.Set("scope", strings.Join(a.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return cachedToken{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(a.ClientID), url.QueryEscape(a.ClientSecret))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return cachedToken{}, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return cachedToken{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return cachedToken{}, fmt.Errorf("token endpoint returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var parsed struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return cachedToken{}, fmt.Errorf("invalid token response: %w", err)
+	}
+	if parsed.AccessToken == "" {
+		return cachedToken{}, fmt.Errorf("token response has no access_token")
+	}
+
+	// Tokens without an expiry are reused for at most five minutes.
+	ttl := 5 * time.Minute
+	if parsed.ExpiresIn > 0 {
+		ttl = time.Duration(parsed.ExpiresIn) * time.Second
+	}
+	return cachedToken{
+		accessToken: parsed.AccessToken,
+		expiresAt:   time.Now().Add(ttl - tokenExpiryMargin),
+	}, nil
+}
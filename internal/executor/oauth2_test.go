@@ -0,0 +1,140 @@
+package executor
+
+import (
+	"collectionsservice/internal/models"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// tokenServer answers like the token endpoint of RFC 6749 sections 4.3 and
+// 4.4, for client s6BhdRkqt3 with secret 7Fjfp0ZBr1KtDRbnfVdmIw.
+func tokenServer(t *testing.T, body string, status int) (*httptest.Server, *[]*http.Request) {
+	t.Helper()
+	var got []*http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		got = append(got, r)
+		w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &got
+}
+
+const rfc6749Token = `{"access_token":"2YotnFZFEjr1zCsicMWpAA","token_type":"example","expires_in":3600,"example_parameter":"example_value"}`
+
+func TestFetchToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		auth     models.AuthConfig
+		wantForm map[string]string
+	}{
+		{
+			name:     "client credentials",
+			auth:     models.AuthConfig{OAuth2Grant: models.OAuth2ClientCredentials, ClientID: "s6BhdRkqt3", ClientSecret: "7Fjfp0ZBr1KtDRbnfVdmIw"},
+			wantForm: map[string]string{"grant_type": "client_credentials"},
+		},
+		{
+			name:     "default grant with scopes",
+			auth:     models.AuthConfig{ClientID: "s6BhdRkqt3", ClientSecret: "7Fjfp0ZBr1KtDRbnfVdmIw", Scopes: []string{"read", "write"}},
+			wantForm: map[string]string{"grant_type": "client_credentials", "scope": "read write"},
+		},
+		{
+			name:     "password",
+			auth:     models.AuthConfig{OAuth2Grant: models.OAuth2Password, ClientID: "s6BhdRkqt3", ClientSecret: "7Fjfp0ZBr1KtDRbnfVdmIw", Username: "johndoe", Password: "A3ddj3w"},
+			wantForm: map[string]string{"grant_type": "password", "username": "johndoe", "password": "A3ddj3w"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, got := tokenServer(t, rfc6749Token, http.StatusOK)
+			tt.auth.TokenURL = srv.URL + "/token"
+
+			before := time.Now()
+			tok, err := fetchToken(context.Background(), srv.Client(), &tt.auth)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tok.accessToken != "2YotnFZFEjr1zCsicMWpAA" {
+				t.Errorf("access token %q", tok.accessToken)
+			}
+			if wantExpiry := before.Add(time.Hour - tokenExpiryMargin); tok.expiresAt.Before(wantExpiry) || tok.expiresAt.After(wantExpiry.Add(time.Minute)) {
+				t.Errorf("expires at %v, want about %v", tok.expiresAt, wantExpiry)
+			}
+
+			r := (*got)[0]
+			if r.Method != http.MethodPost || r.URL.Path != "/token" {
+				t.Errorf("request %s %s", r.Method, r.URL.Path)
+			}
+			if authz := r.Header.Get("Authorization"); authz != "Basic czZCaGRSa3F0Mzo3RmpmcDBaQnIxS3REUmJuZlZkbUl3" {
+				t.Errorf("Authorization = %q", authz)
+			}
+			if len(r.PostForm) != len(tt.wantForm) {
+				t.Errorf("form %v, want %v", r.PostForm, tt.wantForm)
+			}
+			for k, v := range tt.wantForm {
+				if r.PostForm.Get(k) != v {
+					t.Errorf("form %s = %q, want %q", k, r.PostForm.Get(k), v)
+				}
+			}
+		})
+	}
+}
+
+func TestFetchTokenErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		grant  string
+	}{
+		{name: "error status", body: `{"error":"invalid_client"}`, status: http.StatusUnauthorized},
+		{name: "not JSON", body: "<html>", status: http.StatusOK},
+		{name: "no access token", body: `{"token_type":"bearer"}`, status: http.StatusOK},
+		{name: "unsupported grant", body: rfc6749Token, status: http.StatusOK, grant: "authorization_code"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := tokenServer(t, tt.body, tt.status)
+			a := &models.AuthConfig{OAuth2Grant: tt.grant, TokenURL: srv.URL, ClientID: "id"}
+			if _, err := fetchToken(context.Background(), srv.Client(), a); err == nil {
+				t.Error("fetchToken succeeded")
+			}
+		})
+	}
+}
+
+func TestTokenCache(t *testing.T) {
+	srv, got := tokenServer(t, `{"access_token":"t"}`, http.StatusOK)
+	cache := newTokenCache()
+	a := &models.AuthConfig{TokenURL: srv.URL, ClientID: "id", ClientSecret: "old"}
+
+	for i := 0; i < 3; i++ {
+		if tok, err := cache.get(context.Background(), srv.Client(), a); err != nil || tok != "t" {
+			t.Fatalf("get = %q, %v", tok, err)
+		}
+	}
+	if len(*got) != 1 {
+		t.Errorf("fetched %d tokens, want 1", len(*got))
+	}
+
+	rotated := *a
+	rotated.ClientSecret = "new"
+	if _, err := cache.get(context.Background(), srv.Client(), &rotated); err != nil {
+		t.Fatal(err)
+	}
+	if len(*got) != 2 {
+		t.Errorf("rotated secret reused the cached token")
+	}
+
+	cache.tokens[tokenCacheKey(a)] = cachedToken{accessToken: "stale", expiresAt: time.Now().Add(-time.Second)}
+	if tok, _ := cache.get(context.Background(), srv.Client(), a); tok != "t" || len(*got) != 3 {
+		t.Errorf("expired token %q was reused", tok)
+	}
+}
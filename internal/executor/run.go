@@ -0,0 +1,186 @@
+package executor
+
+import (
+	"collectionsservice/internal/models"
+	"collectionsservice/internal/scripting"
+	"collectionsservice/internal/secrets"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"gorm.io/datatypes"
+)
+
+// Outcome is the result of running one request together with its scripts
+// and assertions.
+type Outcome struct {
+	// Request is the request as it was sent, before auth was applied. It is
+	// nil when the request could not be built.
+	Request *Sent
+	// Result is nil when the request was not sent.
+	Result *Result
+	// Err is set when the request could not be sent.
+	Err error
+	// ScriptErr is set when a script threw or timed out. A failing
+	// pre-request script stops the request from being sent.
+	ScriptErr error
+
+	Assertions []AssertionResult
+	Captures   []CaptureResult
+	Tests      []scripting.TestResult
+	Console    []string
+}
+
+// Failed reports whether the request failed to send, a script failed, or an
+// assertion or test did not pass.
+func (o *Outcome) Failed() bool {
+	if o.Err != nil || o.ScriptErr != nil {
+		return true
+	}
+	for _, a := range o.Assertions {
+		if !a.Passed {
+			return true
+		}
+	}
+	for _, t := range o.Tests {
+		if !t.Passed {
+			return true
+		}
+	}
+	return false
+}
+
+// Run executes r with the pre-request and test scripts of col and r,
+// collection scripts first. r's assertions and captures are applied to the
+// response before the test scripts run. Secrets on col and r must already be opened.
+// Scripts and captures write vars, so a caller running several requests can
+// pass the same map to carry values from one request to the next.
+func (e *Executor) Run(ctx context.Context, col *models.Collection, r *models.Request, vars map[string]string) *Outcome {
+	outcome := &Outcome{}
+	sc := &scripting.Context{
+		Vars: vars,
+		Info: scripting.Info{RequestID: r.ID, RequestName: r.Name, EventName: "prerequest"},
+	}
+	defer func() {
+		outcome.Tests = sc.Tests
+		outcome.Console = sc.Console
+	}()
+
+	req := *r
+	if hasScript(col.PreRequestScript, r.PreRequestScript) {
+		sc.Request = requestView(&req)
+		for _, src := range []*string{col.PreRequestScript, r.PreRequestScript} {
+			if err := scripting.Run(ctx, deref(src), sc, e.scriptLimits); err != nil {
+				outcome.ScriptErr = fmt.Errorf("pre-request: %w", err)
+				return outcome
+			}
+		}
+		if err := applyView(&req, sc.Request); err != nil {
+			outcome.ScriptErr = fmt.Errorf("pre-request: %w", err)
+			return outcome
+		}
+		sc.Request = nil
+	}
+
+	if out, err := buildOutgoing(&req, vars); err == nil {
+		outcome.Request = &Sent{Method: out.method, URL: out.url.String(), Headers: out.header, Body: out.body}
+	}
+	result, err := e.Execute(ctx, &req, col.Auth, vars)
+	if err != nil {
+		outcome.Err = err
+		return outcome
+	}
+	outcome.Result = result
+
+	assertions, err := ParseAssertions(r.Assertions)
+	if err != nil {
+		outcome.Assertions = []AssertionResult{{Message: err.Error()}}
+	} else {
+		outcome.Assertions = Assert(assertions, result)
+	}
+	captures, err := ParseCaptures(r.Captures)
+	if err != nil {
+		outcome.Captures = []CaptureResult{{Message: err.Error()}}
+	} else {
+		outcome.Captures = Capture(captures, result, vars)
+	}
+
+	if hasScript(col.TestScript, r.TestScript) {
+		sc.Info.EventName = "test"
+		sc.Response = &scripting.Response{
+			Code:         result.StatusCode,
+			Headers:      result.Headers,
+			Body:         result.Body,
+			ResponseTime: result.Duration,
+		}
+		for _, src := range []*string{col.TestScript, r.TestScript} {
+			if err := scripting.Run(ctx, deref(src), sc, e.scriptLimits); err != nil {
+				outcome.ScriptErr = fmt.Errorf("test: %w", err)
+				return outcome
+			}
+		}
+	}
+	return outcome
+}
+
+func hasScript(scripts ...*string) bool {
+	for _, s := range scripts {
+		if deref(s) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// requestView exposes the parts of r a pre-request script may change. The
+// GraphQL query is exposed as the body.
+func requestView(r *models.Request) *scripting.Request {
+	view := &scripting.Request{}
+	var headers datatypes.JSON
+	switch r.Kind {
+	case models.RequestKindGraphQL:
+		view.Method = http.MethodPost
+		view.URL = deref(r.GraphQLEndpoint)
+		view.Body = deref(r.GraphQLQuery)
+		headers = r.GraphQLHeaders
+	default:
+		view.Method = http.MethodGet
+		if deref(r.HTTPMethod) != "" {
+			view.Method = *r.HTTPMethod
+		}
+		view.URL = deref(r.HTTPURL)
+		view.Body = deref(r.HTTPBody)
+		headers = r.HTTPHeaders
+	}
+
+	entries, _ := secrets.ParseEntries(headers)
+	for _, e := range entries {
+		view.Headers = append(view.Headers, scripting.Header{Key: e.Key, Value: e.Value})
+	}
+	return view
+}
+
+func applyView(r *models.Request, view *scripting.Request) error {
+	entries := make([]secrets.Entry, 0, len(view.Headers))
+	for _, h := range view.Headers {
+		entries = append(entries, secrets.Entry{Key: h.Key, Value: h.Value})
+	}
+	raw, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode headers: %w", err)
+	}
+
+	switch r.Kind {
+	case models.RequestKindGraphQL:
+		r.GraphQLEndpoint = &view.URL
+		r.GraphQLQuery = &view.Body
+		r.GraphQLHeaders = datatypes.JSON(raw)
+	default:
+		r.HTTPMethod = &view.Method
+		r.HTTPURL = &view.URL
+		r.HTTPBody = &view.Body
+		r.HTTPHeaders = datatypes.JSON(raw)
+	}
+	return nil
+}
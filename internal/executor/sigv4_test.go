@@ -0,0 +1,97 @@
+package executor
+
+import (
+	"collectionsservice/internal/models"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The vectors come from the AWS Signature Version 4 test suite, which signs
+// for service "service" in us-east-1 at 2015-08-30T12:36:00Z.
+func TestSignSigV4Suite(t *testing.T) {
+	creds := &models.AuthConfig{
+		AWSAccessKeyID:     "AKIDEXAMPLE",
+		AWSSecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		AWSRegion:          "us-east-1",
+		AWSService:         "service",
+	}
+	at := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+	const credential = "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "
+
+	tests := []struct {
+		name        string
+		method      string
+		url         string
+		contentType string
+		body        string
+		want        string
+	}{
+		{
+			name: "get-vanilla", method: "GET", url: "https://example.amazonaws.com/",
+			want: "SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		},
+		{
+			name: "get-vanilla-query-order-key-case", method: "GET", url: "https://example.amazonaws.com/?Param2=value2&Param1=value1",
+			want: "SignedHeaders=host;x-amz-date, Signature=b97d918cfa904a5beff61c982a1b6f458b799221646efd99d3219ec94cdf2500",
+		},
+		{
+			name: "post-vanilla", method: "POST", url: "https://example.amazonaws.com/",
+			want: "SignedHeaders=host;x-amz-date, Signature=5da7c1a2acd57cee7505fc6676e4e544621c30862966e37dddb68e92efbe5d6b",
+		},
+		{
+			name: "post-x-www-form-urlencoded", method: "POST", url: "https://example.amazonaws.com/",
+			contentType: "application/x-www-form-urlencoded", body: "Param1=value1",
+			want: "SignedHeaders=content-type;host;x-amz-date, Signature=ff11897932ad3f4e8b18135d722051e5ac45fc38421b1da7b9d196a0fe09473a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			signSigV4(req, []byte(tt.body), creds, at)
+
+			if got := req.Header.Get("X-Amz-Date"); got != "20150830T123600Z" {
+				t.Errorf("X-Amz-Date = %q", got)
+			}
+			if got := req.Header.Get("Authorization"); got != credential+tt.want {
+				t.Errorf("Authorization =\n%s\nwant\n%s", got, credential+tt.want)
+			}
+		})
+	}
+}
+
+func TestSignSigV4Headers(t *testing.T) {
+	at := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+	req, _ := http.NewRequest("PUT", "https://bucket.s3.amazonaws.com/a%20b.txt", nil)
+	signSigV4(req, []byte("hello"), &models.AuthConfig{
+		AWSAccessKeyID: "AKID", AWSSecretAccessKey: "secret", AWSSessionToken: "session",
+		AWSRegion: "eu-west-1", AWSService: "s3",
+	}, at)
+
+	if got, want := req.Header.Get("X-Amz-Content-Sha256"), sha256Hex([]byte("hello")); got != want {
+		t.Errorf("X-Amz-Content-Sha256 = %q, want %q", got, want)
+	}
+	if got := req.Header.Get("X-Amz-Security-Token"); got != "session" {
+		t.Errorf("X-Amz-Security-Token = %q", got)
+	}
+	authz := req.Header.Get("Authorization")
+	if !strings.Contains(authz, "Credential=AKID/20150830/eu-west-1/s3/aws4_request, ") ||
+		!strings.Contains(authz, "SignedHeaders=host;x-amz-content-sha256;x-amz-date;x-amz-security-token, ") {
+		t.Errorf("Authorization = %q", authz)
+	}
+}
+
+func TestCanonicalQuery(t *testing.T) {
+	req, _ := http.NewRequest("GET", "https://example.amazonaws.com/?b=2&a=z&a=y&space=a%20b&tilde=~x&plus=a%2Bb", nil)
+	want := "a=y&a=z&b=2&plus=a%2Bb&space=a%20b&tilde=~x"
+	if got := canonicalQuery(req.URL.Query()); got != want {
+		t.Errorf("canonicalQuery = %q, want %q", got, want)
+	}
+}
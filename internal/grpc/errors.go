@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"collectionsservice/internal/apperr"
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// errorStatus maps an error returned by a handler to the status sent to the
+// client. Domain errors keep their code and details, status errors pass
+// through, and missing records become NotFound. Anything else is an
+// internal error: it is logged and the client gets a generic message.
+func errorStatus(method string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := apperr.As(err); ok {
+		return e.GRPCStatus().Err()
+	}
+	if st, ok := status.FromError(err); ok {
+		return st.Err()
+	}
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return status.Error(codes.NotFound, "not found")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	log.Error().Err(err).Str("method", method).Msg("Unhandled error")
+	return status.Error(codes.Internal, "internal error")
+}
+
+func errorUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		return resp, errorStatus(info.FullMethod, err)
+	}
+}
+
+func errorStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return errorStatus(info.FullMethod, handler(srv, ss))
+	}
+}
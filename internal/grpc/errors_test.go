@@ -0,0 +1,138 @@
+package grpc
+
+import (
+	"collectionsservice/internal/apperr"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    codes.Code
+		wantMessage string
+		wantReason  string
+		wantFields  []string
+		wantType    string
+	}{
+		{name: "nil", err: nil, wantCode: codes.OK},
+		{
+			name:        "invalid fields",
+			err:         apperr.InvalidFields(apperr.Violation{Field: "name", Description: "required"}, apperr.Violation{Field: "requests[1].request_id", Description: "not found"}),
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "name: required; requests[1].request_id: not found",
+			wantReason:  apperr.ReasonInvalidArgument,
+			wantFields:  []string{"name", "requests[1].request_id"},
+		},
+		{
+			name:        "wrapped not found",
+			err:         fmt.Errorf("failed to load: %w", apperr.NotFound("collection", "c1")),
+			wantCode:    codes.NotFound,
+			wantMessage: "collection not found",
+			wantReason:  "COLLECTION_NOT_FOUND",
+			wantType:    "collection",
+		},
+		{
+			name:        "already exists",
+			err:         apperr.AlreadyExists("collection", "api", "collection %q exists", "api"),
+			wantCode:    codes.AlreadyExists,
+			wantMessage: `collection "api" exists`,
+			wantReason:  "COLLECTION_ALREADY_EXISTS",
+			wantType:    "collection",
+		},
+		{
+			name:        "failed precondition",
+			err:         apperr.FailedPrecondition("COLLECTION_PROTECTED", "collection is protected"),
+			wantCode:    codes.FailedPrecondition,
+			wantMessage: "collection is protected",
+			wantReason:  "COLLECTION_PROTECTED",
+		},
+		{
+			name:        "aborted",
+			err:         apperr.Aborted("UPSTREAM_CHANGED", "upstream changed"),
+			wantCode:    codes.Aborted,
+			wantMessage: "upstream changed",
+			wantReason:  "UPSTREAM_CHANGED",
+		},
+		{
+			name:        "permission denied",
+			err:         apperr.PermissionDenied("SHARE_TOKEN_SCOPE", "no run access"),
+			wantCode:    codes.PermissionDenied,
+			wantMessage: "no run access",
+			wantReason:  "SHARE_TOKEN_SCOPE",
+		},
+		{
+			name:        "status passes through",
+			err:         status.Error(codes.Unavailable, "failed to read changes"),
+			wantCode:    codes.Unavailable,
+			wantMessage: "failed to read changes",
+		},
+		{
+			name:        "missing record",
+			err:         fmt.Errorf("failed to fetch: %w", gorm.ErrRecordNotFound),
+			wantCode:    codes.NotFound,
+			wantMessage: "not found",
+		},
+		{
+			name:        "canceled",
+			err:         fmt.Errorf("query: %w", context.Canceled),
+			wantCode:    codes.Canceled,
+			wantMessage: "query: context canceled",
+		},
+		{
+			name:        "deadline",
+			err:         context.DeadlineExceeded,
+			wantCode:    codes.DeadlineExceeded,
+			wantMessage: "context deadline exceeded",
+		},
+		{
+			name:        "unknown errors are hidden",
+			err:         errors.New("pq: relation \"collections\" does not exist"),
+			wantCode:    codes.Internal,
+			wantMessage: "internal error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(errorStatus("/collections.CollectionService/Test", tt.err))
+			if st.Code() != tt.wantCode || st.Message() != tt.wantMessage {
+				t.Fatalf("got %s %q, want %s %q", st.Code(), st.Message(), tt.wantCode, tt.wantMessage)
+			}
+
+			var reason, resourceType string
+			var fields []string
+			for _, d := range st.Details() {
+				switch d := d.(type) {
+				case *errdetails.ErrorInfo:
+					if d.GetDomain() != apperr.Domain {
+						t.Errorf("error info domain %q, want %q", d.GetDomain(), apperr.Domain)
+					}
+					reason = d.GetReason()
+				case *errdetails.BadRequest:
+					for _, v := range d.GetFieldViolations() {
+						fields = append(fields, v.GetField())
+					}
+				case *errdetails.ResourceInfo:
+					resourceType = d.GetResourceType()
+				}
+			}
+			if reason != tt.wantReason {
+				t.Errorf("reason %q, want %q", reason, tt.wantReason)
+			}
+			if fmt.Sprint(fields) != fmt.Sprint(tt.wantFields) {
+				t.Errorf("violations on %v, want %v", fields, tt.wantFields)
+			}
+			if resourceType != tt.wantType {
+				t.Errorf("resource type %q, want %q", resourceType, tt.wantType)
+			}
+		})
+	}
+}
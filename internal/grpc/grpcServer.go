@@ -1,6 +1,9 @@
 package grpc
 
 import (
+	"collectionsservice/internal/audit"
+	"collectionsservice/internal/auth"
+	"collectionsservice/internal/idempotency"
 	pb "collectionsservice/internal/proto"
 	"log"
 	"net"
@@ -8,14 +11,37 @@ import (
 	"google.golang.org/grpc"
 )
 
-func StartGRPCServer(ser pb.CollectionServiceServer) {
+type Services struct {
+	Collections pb.CollectionServiceServer
+	Workspaces  pb.WorkspaceServiceServer
+	Auth        pb.AuthServiceServer
+}
+
+func StartGRPCServer(services Services, authn *auth.Authenticator, recorder *audit.Recorder, guard *idempotency.Guard) {
 	listener, err := net.Listen("tcp", ":50051")
 	if err != nil {
 		log.Fatal(" Failed to listen:", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	pb.RegisterCollectionServiceServer(grpcServer, ser)
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			recoverUnaryInterceptor(),
+			authn.UnaryInterceptor(),
+			recorder.UnaryInterceptor(),
+			errorUnaryInterceptor(),
+			validateUnaryInterceptor(),
+			guard.UnaryInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			recoverStreamInterceptor(),
+			authn.StreamInterceptor(),
+			errorStreamInterceptor(),
+			validateStreamInterceptor(),
+		),
+	)
+	pb.RegisterCollectionServiceServer(grpcServer, services.Collections)
+	pb.RegisterWorkspaceServiceServer(grpcServer, services.Workspaces)
+	pb.RegisterAuthServiceServer(grpcServer, services.Auth)
 
 	log.Println("gRPC Server started on port 50051")
 
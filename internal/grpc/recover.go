@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recoverPanic turns a panic in a handler into an internal error, so one bad
+// call cannot take the server down. The panic and its stack are logged; the
+// client only sees a generic message.
+func recoverPanic(method string, err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	log.Error().
+		Str("method", method).
+		Interface("panic", r).
+		Str("stack", string(debug.Stack())).
+		Msg("Recovered from panic")
+	*err = status.Error(codes.Internal, "internal error")
+}
+
+func recoverUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer recoverPanic(info.FullMethod, &err)
+		return handler(ctx, req)
+	}
+}
+
+func recoverStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer recoverPanic(info.FullMethod, &err)
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,205 @@
+// Package idempotency lets clients retry create calls safely. A call sent
+// with an idempotency key stores its response under the key; a retry with
+// the same key and request gets that response back instead of creating a
+// duplicate.
+package idempotency
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/auth"
+	"collectionsservice/internal/models"
+	pb "collectionsservice/internal/proto"
+	"collectionsservice/internal/workspace"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// MetadataKey is the gRPC metadata header that carries a key for requests
+// that do not set idempotency_key.
+const MetadataKey = "idempotency-key"
+
+const (
+	keyField     = "idempotency_key"
+	maxKeyLength = 255
+)
+
+type Store interface {
+	ReserveIdempotencyKey(ctx context.Context, key *models.IdempotencyKey) (*models.IdempotencyKey, error)
+	CompleteIdempotencyKey(ctx context.Context, key *models.IdempotencyKey) error
+	ReleaseIdempotencyKey(ctx context.Context, key *models.IdempotencyKey) error
+	PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int64, error)
+}
+
+// idempotent maps each method that accepts a key to a constructor for its
+// response, which a replay is decoded into.
+var idempotent = map[string]func() proto.Message{
+	pb.CollectionService_CreateCollection_FullMethodName: func() proto.Message {
+		return &pb.CreateCollectionResponse{}
+	},
+	pb.CollectionService_AddRequestToCollection_FullMethodName: func() proto.Message {
+		return &pb.CollectionResponse{}
+	},
+	pb.CollectionService_AddRequestToCollectionByID_FullMethodName: func() proto.Message {
+		return &pb.CollectionResponse{}
+	},
+	pb.CollectionService_BatchAddRequestsToCollection_FullMethodName: func() proto.Message {
+		return &pb.BatchRequestsResponse{}
+	},
+}
+
+// Guard replays the stored response of calls made with a known key.
+type Guard struct {
+	store Store
+	ttl   time.Duration
+	lease time.Duration
+}
+
+// NewGuard returns a Guard that remembers responses for ttl. A call holds
+// its key for at most lease before a retry may run it again.
+func NewGuard(store Store, ttl, lease time.Duration) *Guard {
+	return &Guard{store: store, ttl: ttl, lease: lease}
+}
+
+// Start purges expired keys every hour until ctx is done.
+func (g *Guard) Start(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		if n, err := g.store.PurgeIdempotencyKeys(ctx, time.Now()); err == nil && n > 0 {
+			log.Info().Int64("count", n).Msg("Purged expired idempotency keys")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// UnaryInterceptor must run after authentication, since keys are scoped to
+// the caller, and after validation, so a rejected request holds no key.
+//
+// The first call with a key holds it while it runs, for at most the lease. A
+// failed call releases the key so it can be retried; a successful one stores
+// its response, which every retry within the TTL gets back.
+func (g *Guard) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		newResponse, ok := idempotent[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+		msg := req.(proto.Message)
+		key, err := requestKey(ctx, msg)
+		if err != nil {
+			return nil, err
+		}
+		if key == "" {
+			return handler(ctx, req)
+		}
+		workspaceID, err := workspace.FromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		hash, err := requestHash(msg)
+		if err != nil {
+			return nil, err
+		}
+
+		// The lease identifies this reservation when it is completed or
+		// released, so it is kept at the precision the database stores.
+		now := time.Now().Truncate(time.Microsecond)
+		record := &models.IdempotencyKey{
+			Subject:        auth.Subject(ctx, string(auth.MethodAnonymous)),
+			WorkspaceID:    workspaceID,
+			Method:         path.Base(info.FullMethod),
+			Key:            key,
+			RequestHash:    hash,
+			LeaseExpiresAt: now.Add(g.lease),
+			CreatedAt:      now,
+			ExpiresAt:      now.Add(g.ttl),
+		}
+		held, err := g.store.ReserveIdempotencyKey(ctx, record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+		if held != nil {
+			return replay(held, hash, newResponse())
+		}
+
+		resp, err := handler(ctx, req)
+		// The outcome is stored even when the client has gone away, since
+		// that is when it retries.
+		storeCtx := context.WithoutCancel(ctx)
+		if err != nil {
+			_ = g.store.ReleaseIdempotencyKey(storeCtx, record)
+			return nil, err
+		}
+		record.Response, err = proto.Marshal(resp.(proto.Message))
+		if err != nil {
+			log.Error().Err(err).Str("method", record.Method).Msg("Failed to encode idempotent response")
+			_ = g.store.ReleaseIdempotencyKey(storeCtx, record)
+			return resp, nil
+		}
+		_ = g.store.CompleteIdempotencyKey(storeCtx, record)
+		return resp, nil
+	}
+}
+
+// replay returns the response stored under a held key.
+func replay(held *models.IdempotencyKey, hash string, resp proto.Message) (interface{}, error) {
+	if held.RequestHash != hash {
+		return nil, apperr.FailedPrecondition("IDEMPOTENCY_KEY_REUSED", "idempotency key %q was already used for a different request", held.Key)
+	}
+	if held.Response == nil {
+		return nil, apperr.Aborted("IDEMPOTENCY_KEY_IN_USE", "a request with idempotency key %q is still running; retry later", held.Key)
+	}
+	if err := proto.Unmarshal(held.Response, resp); err != nil {
+		return nil, fmt.Errorf("failed to decode idempotent response: %w", err)
+	}
+	return resp, nil
+}
+
+// requestKey returns the key of a request, from its idempotency_key field
+// or the metadata header. Both may be sent if they agree.
+func requestKey(ctx context.Context, msg proto.Message) (string, error) {
+	var key string
+	if fd := msg.ProtoReflect().Descriptor().Fields().ByName(keyField); fd != nil {
+		key = msg.ProtoReflect().Get(fd).String()
+	}
+	if values := metadata.ValueFromIncomingContext(ctx, MetadataKey); len(values) > 0 {
+		switch {
+		case key == "":
+			key = values[0]
+		case key != values[0]:
+			return "", apperr.Invalid(keyField, "%s and the %s header differ", keyField, MetadataKey)
+		}
+	}
+	if len(key) > maxKeyLength {
+		return "", apperr.Invalid(keyField, "must be at most %d bytes", maxKeyLength)
+	}
+	return key, nil
+}
+
+// requestHash fingerprints a request without its key, so the same request
+// sent with the key in the field or in the header hashes alike.
+func requestHash(msg proto.Message) (string, error) {
+	clone := proto.Clone(msg)
+	if fd := clone.ProtoReflect().Descriptor().Fields().ByName(keyField); fd != nil {
+		clone.ProtoReflect().Clear(fd)
+	}
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(clone)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,213 @@
+package idempotency
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/auth"
+	"collectionsservice/internal/models"
+	pb "collectionsservice/internal/proto"
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// memoryStore keeps keys the way the repository does: an expired key, or an
+// unfinished one whose lease ran out, is free again.
+type memoryStore struct {
+	keys map[string]*models.IdempotencyKey
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{keys: make(map[string]*models.IdempotencyKey)}
+}
+
+func storeKey(k *models.IdempotencyKey) string {
+	return k.Subject + "/" + k.WorkspaceID + "/" + k.Method + "/" + k.Key
+}
+
+func (s *memoryStore) ReserveIdempotencyKey(_ context.Context, key *models.IdempotencyKey) (*models.IdempotencyKey, error) {
+	now := time.Now()
+	if held, ok := s.keys[storeKey(key)]; ok {
+		if held.ExpiresAt.After(now) && (held.Response != nil || held.LeaseExpiresAt.After(now)) {
+			copied := *held
+			return &copied, nil
+		}
+	}
+	copied := *key
+	s.keys[storeKey(key)] = &copied
+	return nil, nil
+}
+
+func (s *memoryStore) CompleteIdempotencyKey(_ context.Context, key *models.IdempotencyKey) error {
+	if held, ok := s.keys[storeKey(key)]; ok && held.Response == nil && held.LeaseExpiresAt.Equal(key.LeaseExpiresAt) {
+		held.Response = key.Response
+	}
+	return nil
+}
+
+func (s *memoryStore) ReleaseIdempotencyKey(_ context.Context, key *models.IdempotencyKey) error {
+	if held, ok := s.keys[storeKey(key)]; ok && held.Response == nil && held.LeaseExpiresAt.Equal(key.LeaseExpiresAt) {
+		delete(s.keys, storeKey(key))
+	}
+	return nil
+}
+
+func (s *memoryStore) PurgeIdempotencyKeys(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+var createInfo = &grpc.UnaryServerInfo{FullMethod: pb.CollectionService_CreateCollection_FullMethodName}
+
+func TestGuard(t *testing.T) {
+	type call struct {
+		req *pb.CreateCollectionRequest
+		// header is sent as the idempotency-key metadata.
+		header string
+		// fail makes the handler return an error.
+		fail bool
+
+		wantRun    bool
+		wantID     string
+		wantReason string
+	}
+	failed := errors.New("handler failed")
+
+	tests := []struct {
+		name  string
+		lease time.Duration
+		// hold is a call that reserved its key and is still running.
+		hold  *pb.CreateCollectionRequest
+		calls []call
+	}{
+		{
+			name: "no key runs every call",
+			calls: []call{
+				{req: &pb.CreateCollectionRequest{Name: "a"}, wantRun: true, wantID: "1"},
+				{req: &pb.CreateCollectionRequest{Name: "a"}, wantRun: true, wantID: "2"},
+			},
+		},
+		{
+			name: "retry replays the response",
+			calls: []call{
+				{req: &pb.CreateCollectionRequest{Name: "a", IdempotencyKey: "k"}, wantRun: true, wantID: "1"},
+				{req: &pb.CreateCollectionRequest{Name: "a", IdempotencyKey: "k"}, wantID: "1"},
+			},
+		},
+		{
+			name: "key in field and header hash alike",
+			calls: []call{
+				{req: &pb.CreateCollectionRequest{Name: "a", IdempotencyKey: "k"}, wantRun: true, wantID: "1"},
+				{req: &pb.CreateCollectionRequest{Name: "a"}, header: "k", wantID: "1"},
+			},
+		},
+		{
+			name: "key reused for another request",
+			calls: []call{
+				{req: &pb.CreateCollectionRequest{Name: "a", IdempotencyKey: "k"}, wantRun: true, wantID: "1"},
+				{req: &pb.CreateCollectionRequest{Name: "b", IdempotencyKey: "k"}, wantReason: "IDEMPOTENCY_KEY_REUSED"},
+			},
+		},
+		{
+			name: "field and header differ",
+			calls: []call{
+				{req: &pb.CreateCollectionRequest{Name: "a", IdempotencyKey: "k"}, header: "other", wantReason: apperr.ReasonInvalidArgument},
+			},
+		},
+		{
+			name: "failed call frees the key",
+			calls: []call{
+				{req: &pb.CreateCollectionRequest{Name: "a", IdempotencyKey: "k"}, fail: true, wantRun: true},
+				{req: &pb.CreateCollectionRequest{Name: "a", IdempotencyKey: "k"}, wantRun: true, wantID: "2"},
+				{req: &pb.CreateCollectionRequest{Name: "a", IdempotencyKey: "k"}, wantID: "2"},
+			},
+		},
+		{
+			name:  "running call holds the key",
+			lease: time.Minute,
+			hold:  &pb.CreateCollectionRequest{Name: "a", IdempotencyKey: "k"},
+			calls: []call{
+				{req: &pb.CreateCollectionRequest{Name: "a", IdempotencyKey: "k"}, wantReason: "IDEMPOTENCY_KEY_IN_USE"},
+			},
+		},
+		{
+			name:  "expired lease frees the key",
+			lease: -time.Second,
+			hold:  &pb.CreateCollectionRequest{Name: "a", IdempotencyKey: "k"},
+			calls: []call{
+				{req: &pb.CreateCollectionRequest{Name: "a", IdempotencyKey: "k"}, wantRun: true, wantID: "1"},
+				{req: &pb.CreateCollectionRequest{Name: "a", IdempotencyKey: "k"}, wantID: "1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lease := tt.lease
+			if lease == 0 {
+				lease = time.Minute
+			}
+			g := NewGuard(newMemoryStore(), time.Hour, lease)
+			interceptor := g.UnaryInterceptor()
+
+			if tt.hold != nil {
+				// A call that took the key and has not finished.
+				hash, err := requestHash(tt.hold)
+				if err != nil {
+					t.Fatal(err)
+				}
+				now := time.Now()
+				_, _ = g.store.ReserveIdempotencyKey(context.Background(), &models.IdempotencyKey{
+					Subject:        string(auth.MethodAnonymous),
+					WorkspaceID:    models.DefaultWorkspaceID,
+					Method:         "CreateCollection",
+					Key:            tt.hold.GetIdempotencyKey(),
+					RequestHash:    hash,
+					LeaseExpiresAt: now.Add(lease),
+					ExpiresAt:      now.Add(time.Hour),
+				})
+			}
+
+			runs := 0
+			for i, c := range tt.calls {
+				ctx := context.Background()
+				if c.header != "" {
+					ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(MetadataKey, c.header))
+				}
+				ran := false
+				resp, err := interceptor(ctx, c.req, createInfo, func(context.Context, interface{}) (interface{}, error) {
+					ran = true
+					runs++
+					if c.fail {
+						return nil, failed
+					}
+					return &pb.CreateCollectionResponse{Id: strconv.Itoa(runs)}, nil
+				})
+
+				if ran != c.wantRun {
+					t.Errorf("call %d: handler ran %v, want %v", i, ran, c.wantRun)
+				}
+				switch {
+				case c.wantReason != "":
+					e, ok := apperr.As(err)
+					if !ok || e.Reason != c.wantReason {
+						t.Errorf("call %d: error %v, want reason %s", i, err, c.wantReason)
+					}
+				case c.fail:
+					if !errors.Is(err, failed) {
+						t.Errorf("call %d: error %v, want %v", i, err, failed)
+					}
+				case err != nil:
+					t.Errorf("call %d: unexpected error %v", i, err)
+				default:
+					if got := resp.(*pb.CreateCollectionResponse).GetId(); got != c.wantID {
+						t.Errorf("call %d: got response %q, want %q", i, got, c.wantID)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package merge
+
+import (
+	"collectionsservice/internal/models"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// ForkChanges lists what a fork changed relative to its upstream collection.
+// Changes to the collection's own fields are reported as one collection
+// change. Requests are matched through ForkedFromRequestID, so renames in the fork are
+// reported as modifications. Upstream requests that existed when the fork was
+// taken and are gone from the fork are reported as removals; requests added
+// upstream after the fork are left alone.
+func ForkChanges(fork, upstream *models.Collection) ([]models.ProposalChange, error) {
+	var base []string
+	if len(fork.ForkBaseRequestIDs) > 0 {
+		if err := json.Unmarshal(fork.ForkBaseRequestIDs, &base); err != nil {
+			return nil, fmt.Errorf("invalid fork base for collection %s: %w", fork.ID, err)
+		}
+	}
+
+	upstreamByID := make(map[string]models.Request, len(upstream.Requests))
+	for _, r := range upstream.Requests {
+		upstreamByID[r.ID] = r
+	}
+
+	var changes []models.ProposalChange
+	if diffs := collectionDiffs(fork, upstream); len(diffs) > 0 {
+		changes = append(changes, models.ProposalChange{
+			Type:                models.ProposalChangeCollection,
+			Name:                upstream.Name,
+			UpstreamFingerprint: CollectionFingerprint(upstream),
+			Diffs:               diffs,
+		})
+	}
+
+	kept := make(map[string]bool)
+	for i := range fork.Requests {
+		r := fork.Requests[i]
+		origin, ok := "", false
+		if r.ForkedFromRequestID != nil {
+			origin = *r.ForkedFromRequestID
+			_, ok = upstreamByID[origin]
+		}
+		if !ok {
+			changes = append(changes, models.ProposalChange{
+				Type:          models.ProposalChangeAdded,
+				Name:          r.Name,
+				ForkRequestID: r.ID,
+				Request:       &r,
+			})
+			continue
+		}
+		kept[origin] = true
+
+		up := upstreamByID[origin]
+		diffs := Diff(r, up)
+		if len(diffs) == 0 {
+			continue
+		}
+		change := models.ProposalChange{
+			Type:                models.ProposalChangeModified,
+			Name:                r.Name,
+			ForkRequestID:       r.ID,
+			UpstreamRequestID:   up.ID,
+			UpstreamFingerprint: Fingerprint(up),
+			Request:             &r,
+		}
+		for _, d := range diffs {
+			change.Diffs = append(change.Diffs, models.FieldChange{Field: d.Field, Proposed: d.Source, Current: d.Target})
+		}
+		changes = append(changes, change)
+	}
+
+	for _, id := range base {
+		up, ok := upstreamByID[id]
+		if !ok || kept[id] {
+			continue
+		}
+		changes = append(changes, models.ProposalChange{
+			Type:                models.ProposalChangeRemoved,
+			Name:                up.Name,
+			UpstreamRequestID:   up.ID,
+			UpstreamFingerprint: Fingerprint(up),
+		})
+	}
+
+	return changes, nil
+}
+
+// Fingerprint identifies the content of a request so callers can tell
+// whether it changed since a snapshot was taken.
+func Fingerprint(r models.Request) string {
+	fields := Fields(r)
+	values := make([]string, 0, len(fieldNames))
+	for _, name := range fieldNames {
+		values = append(values, name+"="+fields[name])
+	}
+	b, _ := json.Marshal(values)
+	return fingerprint(string(b))
+}
+
+// CollectionFingerprint identifies the collection-level fields of c, the
+// ones a collection change proposes.
+func CollectionFingerprint(c *models.Collection) string {
+	fields := collectionFields(c)
+	values := make([]string, 0, len(collectionFieldNames))
+	for _, name := range collectionFieldNames {
+		values = append(values, name+"="+fields[name])
+	}
+	b, _ := json.Marshal(values)
+	return fingerprint(string(b))
+}
+
+var collectionFieldNames = []string{
+	"description",
+	"variables",
+	"auth",
+	"pre_request_script",
+	"test_script",
+}
+
+func collectionFields(c *models.Collection) map[string]string {
+	return map[string]string{
+		"description":        deref(c.Description),
+		"variables":          normalizeJSON(c.Variables),
+		"auth":               normalizeJSON(c.Auth),
+		"pre_request_script": deref(c.PreRequestScript),
+		"test_script":        deref(c.TestScript),
+	}
+}
+
+func collectionDiffs(fork, upstream *models.Collection) []models.FieldChange {
+	f, u := collectionFields(fork), collectionFields(upstream)
+	var diffs []models.FieldChange
+	for _, name := range collectionFieldNames {
+		if f[name] != u[name] {
+			diffs = append(diffs, models.FieldChange{Field: name, Proposed: f[name], Current: u[name]})
+		}
+	}
+	return diffs
+}
+
+func fingerprint(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,70 @@
+package merge
+
+import (
+	"collectionsservice/internal/models"
+	"testing"
+
+	"gorm.io/datatypes"
+)
+
+func TestForkChangesCollectionFields(t *testing.T) {
+	script := "pm.test('ok', () => {})"
+	upstream := &models.Collection{
+		ID:        "up",
+		Name:      "api",
+		Variables: datatypes.JSON(`[{"key":"host","value":"a"}]`),
+		Auth:      datatypes.JSON(`{"type":"bearer","token":"t"}`),
+	}
+
+	tests := []struct {
+		name   string
+		fork   models.Collection
+		fields []string
+	}{
+		{name: "unchanged", fork: *upstream},
+		{
+			name: "key order only",
+			fork: models.Collection{
+				Variables: datatypes.JSON(`[{"value":"a","key":"host"}]`),
+				Auth:      datatypes.JSON(`{"token":"t","type":"bearer"}`),
+			},
+		},
+		{
+			name: "variables, auth and scripts",
+			fork: models.Collection{
+				Variables:  datatypes.JSON(`[{"key":"host","value":"b"}]`),
+				TestScript: &script,
+			},
+			fields: []string{"variables", "auth", "test_script"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := ForkChanges(&tt.fork, upstream)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tt.fields) == 0 {
+				if len(changes) != 0 {
+					t.Fatalf("got %d changes, want none", len(changes))
+				}
+				return
+			}
+			if len(changes) != 1 || changes[0].Type != models.ProposalChangeCollection {
+				t.Fatalf("got %+v, want one collection change", changes)
+			}
+			c := changes[0]
+			if c.UpstreamFingerprint != CollectionFingerprint(upstream) {
+				t.Error("fingerprint does not match upstream")
+			}
+			if len(c.Diffs) != len(tt.fields) {
+				t.Fatalf("got diffs %+v, want fields %v", c.Diffs, tt.fields)
+			}
+			for i, d := range c.Diffs {
+				if d.Field != tt.fields[i] {
+					t.Errorf("diff %d is %s, want %s", i, d.Field, tt.fields[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package merge
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/models"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type MatchBy int
+
+const (
+	MatchByName MatchBy = iota
+	MatchByMethodURL
+)
+
+type Resolution int
+
+const (
+	ResolutionUnspecified Resolution = iota
+	ResolutionOurs
+	ResolutionTheirs
+	ResolutionKeepBoth
+)
+
+type FieldDiff struct {
+	Field  string
+	Source string
+	Target string
+}
+
+type Conflict struct {
+	Key    string
+	Source models.Request
+	Target models.Request
+	Diffs  []FieldDiff
+}
+
+type Plan struct {
+	Added     []models.Request
+	Skipped   []models.Request
+	Conflicts []Conflict
+}
+
+// Changes holds the writes needed to apply a plan to the target collection.
+type Changes struct {
+	Creates []models.Request
+	Updates []models.Request
+}
+
+// BuildPlan compares every request of source against target. Requests are
+// matched by key; when a collection holds several requests with the same key
+// only the first one takes part in the match.
+func BuildPlan(source, target *models.Collection, matchBy MatchBy) *Plan {
+	targetByKey := make(map[string]models.Request)
+	for _, r := range target.Requests {
+		k := Key(r, matchBy)
+		if _, ok := targetByKey[k]; !ok {
+			targetByKey[k] = r
+		}
+	}
+
+	plan := &Plan{}
+	seen := make(map[string]bool)
+	for _, r := range source.Requests {
+		k := Key(r, matchBy)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+
+		t, ok := targetByKey[k]
+		if !ok {
+			plan.Added = append(plan.Added, r)
+			continue
+		}
+
+		diffs := Diff(r, t)
+		if len(diffs) == 0 {
+			plan.Skipped = append(plan.Skipped, r)
+			continue
+		}
+		plan.Conflicts = append(plan.Conflicts, Conflict{Key: k, Source: r, Target: t, Diffs: diffs})
+	}
+
+	return plan
+}
+
+// Token fingerprints the plan so an apply can detect that either collection
+// changed after the preview was taken.
+func (p *Plan) Token() string {
+	h := sha256.New()
+	for _, r := range p.Added {
+		fmt.Fprintf(h, "add|%s\n", r.ID)
+	}
+	for _, r := range p.Skipped {
+		fmt.Fprintf(h, "skip|%s\n", r.ID)
+	}
+	for _, c := range p.Conflicts {
+		fmt.Fprintf(h, "conflict|%s|%s|%s\n", c.Key, c.Source.ID, c.Target.ID)
+		for _, d := range c.Diffs {
+			fmt.Fprintf(h, "%s|%s|%s\n", d.Field, d.Source, d.Target)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Resolve turns the plan into concrete writes against targetID. Every
+// conflict needs a resolution, either per key or through fallback.
+func (p *Plan) Resolve(targetID string, resolutions map[string]Resolution, fallback Resolution) (*Changes, error) {
+	changes := &Changes{}
+	for _, r := range p.Added {
+		changes.Creates = append(changes.Creates, copyInto(r, targetID, r.Name))
+	}
+
+	known := make(map[string]bool, len(p.Conflicts))
+	for _, c := range p.Conflicts {
+		known[c.Key] = true
+	}
+	for k := range resolutions {
+		if !known[k] {
+			return nil, apperr.Invalid("resolutions", "no merge conflict with key %q", k)
+		}
+	}
+
+	var unresolved []string
+	for _, c := range p.Conflicts {
+		res, ok := resolutions[c.Key]
+		if !ok || res == ResolutionUnspecified {
+			res = fallback
+		}
+
+		switch res {
+		case ResolutionOurs:
+		case ResolutionTheirs:
+			updated := c.Source
+			updated.ID = c.Target.ID
+			updated.CollectionID = targetID
+			changes.Updates = append(changes.Updates, updated)
+		case ResolutionKeepBoth:
+			name := c.Source.Name
+			if name == c.Target.Name {
+				name += " (merged)"
+			}
+			changes.Creates = append(changes.Creates, copyInto(c.Source, targetID, name))
+		default:
+			unresolved = append(unresolved, c.Key)
+		}
+	}
+
+	if len(unresolved) > 0 {
+		return nil, apperr.Invalid("resolutions", "unresolved merge conflicts: %s", strings.Join(unresolved, ", "))
+	}
+	return changes, nil
+}
+
+func Key(r models.Request, matchBy MatchBy) string {
+	if matchBy == MatchByMethodURL {
+		switch r.Kind {
+		case models.RequestKindHTTP:
+			return strings.ToUpper(deref(r.HTTPMethod)) + " " + deref(r.HTTPURL)
+		case models.RequestKindGraphQL:
+			return "GRAPHQL " + deref(r.GraphQLEndpoint) + "#" + r.Name
+		}
+	}
+	return r.Name
+}
+
+// Diff lists every field whose value differs between source and target.
+// JSON columns are compared semantically so key order does not matter.
+func Diff(source, target models.Request) []FieldDiff {
+	s, t := Fields(source), Fields(target)
+	var diffs []FieldDiff
+	for _, name := range fieldNames {
+		if s[name] != t[name] {
+			diffs = append(diffs, FieldDiff{Field: name, Source: s[name], Target: t[name]})
+		}
+	}
+	return diffs
+}
+
+var fieldNames = []string{
+	"kind",
+	"name",
+	"http_method",
+	"http_url",
+	"http_headers",
+	"http_query_params",
+	"http_body",
+	"graphql_endpoint",
+	"graphql_query",
+	"graphql_variables",
+	"graphql_headers",
+	"auth",
+	"pre_request_script",
+	"test_script",
+	"assertions",
+	"captures",
+}
+
+func Fields(r models.Request) map[string]string {
+	return map[string]string{
+		"kind":               string(r.Kind),
+		"name":               r.Name,
+		"http_method":        deref(r.HTTPMethod),
+		"http_url":           deref(r.HTTPURL),
+		"http_headers":       normalizeJSON(r.HTTPHeaders),
+		"http_query_params":  normalizeJSON(r.HTTPQueryParams),
+		"http_body":          deref(r.HTTPBody),
+		"graphql_endpoint":   deref(r.GraphQLEndpoint),
+		"graphql_query":      deref(r.GraphQLQuery),
+		"graphql_variables":  normalizeJSON(r.GraphQLVariables),
+		"graphql_headers":    normalizeJSON(r.GraphQLHeaders),
+		"auth":               normalizeJSON(r.Auth),
+		"pre_request_script": deref(r.PreRequestScript),
+		"test_script":        deref(r.TestScript),
+		"assertions":         normalizeJSON(r.Assertions),
+		"captures":           normalizeJSON(r.Captures),
+	}
+}
+
+func copyInto(r models.Request, collectionID, name string) models.Request {
+	r.ID = uuid.New().String()
+	r.CollectionID = collectionID
+	r.Name = name
+	return r
+}
+
+func normalizeJSON(raw []byte) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return string(raw)
+	}
+	if v == nil {
+		return ""
+	}
+	if list, ok := v.([]interface{}); ok && len(list) == 0 {
+		return ""
+	}
+	out, _ := json.Marshal(v)
+	return string(out)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,215 @@
+package merge
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/models"
+	"reflect"
+	"testing"
+
+	"gorm.io/datatypes"
+)
+
+func str(s string) *string { return &s }
+
+func httpRequest(id, name, method, url string) models.Request {
+	return models.Request{ID: id, Kind: models.RequestKindHTTP, Name: name, HTTPMethod: str(method), HTTPURL: str(url)}
+}
+
+func ids(reqs []models.Request) []string {
+	var out []string
+	for _, r := range reqs {
+		out = append(out, r.ID)
+	}
+	return out
+}
+
+func TestBuildPlan(t *testing.T) {
+	target := &models.Collection{ID: "target", Requests: []models.Request{
+		httpRequest("t-list", "list users", "GET", "/users"),
+		httpRequest("t-get", "get user", "GET", "/users/:id"),
+		httpRequest("t-get-dup", "get user", "GET", "/users/:id/old"),
+		func() models.Request {
+			r := httpRequest("t-create", "create user", "POST", "/users")
+			r.HTTPHeaders = datatypes.JSON(`[{"key":"A","value":"1"}]`)
+			r.Assertions = datatypes.JSON(`[]`)
+			return r
+		}(),
+	}}
+
+	tests := []struct {
+		name          string
+		source        []models.Request
+		matchBy       MatchBy
+		wantAdded     []string
+		wantSkipped   []string
+		wantConflicts map[string][]string
+	}{
+		{
+			name:      "new request",
+			source:    []models.Request{httpRequest("s1", "delete user", "DELETE", "/users/:id")},
+			wantAdded: []string{"s1"},
+		},
+		{
+			name:        "identical request",
+			source:      []models.Request{httpRequest("s1", "list users", "GET", "/users")},
+			wantSkipped: []string{"s1"},
+		},
+		{
+			name: "JSON compared semantically",
+			source: []models.Request{func() models.Request {
+				r := httpRequest("s1", "create user", "POST", "/users")
+				r.HTTPHeaders = datatypes.JSON(`[{"value":"1","key":"A"}]`)
+				return r
+			}()},
+			wantSkipped: []string{"s1"},
+		},
+		{
+			name:          "changed URL",
+			source:        []models.Request{httpRequest("s1", "list users", "GET", "/v2/users")},
+			wantConflicts: map[string][]string{"list users": {"http_url"}},
+		},
+		{
+			name:          "first duplicate key takes part",
+			source:        []models.Request{httpRequest("s1", "get user", "GET", "/users/:id/old"), httpRequest("s2", "get user", "GET", "/users/:id")},
+			wantConflicts: map[string][]string{"get user": {"http_url"}},
+		},
+		{
+			name:          "match by method and URL",
+			source:        []models.Request{httpRequest("s1", "fetch users", "get", "/users"), httpRequest("s2", "list users", "PUT", "/users")},
+			matchBy:       MatchByMethodURL,
+			wantAdded:     []string{"s2"},
+			wantConflicts: map[string][]string{"GET /users": {"name", "http_method"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := BuildPlan(&models.Collection{ID: "source", Requests: tt.source}, target, tt.matchBy)
+			if got := ids(plan.Added); !reflect.DeepEqual(got, tt.wantAdded) {
+				t.Errorf("added %v, want %v", got, tt.wantAdded)
+			}
+			if got := ids(plan.Skipped); !reflect.DeepEqual(got, tt.wantSkipped) {
+				t.Errorf("skipped %v, want %v", got, tt.wantSkipped)
+			}
+			if len(plan.Conflicts) != len(tt.wantConflicts) {
+				t.Fatalf("conflicts %+v, want %v", plan.Conflicts, tt.wantConflicts)
+			}
+			for _, c := range plan.Conflicts {
+				var fields []string
+				for _, d := range c.Diffs {
+					fields = append(fields, d.Field)
+				}
+				want, ok := tt.wantConflicts[c.Key]
+				if !ok {
+					t.Errorf("unexpected conflict %q", c.Key)
+					continue
+				}
+				if !reflect.DeepEqual(fields, want) {
+					t.Errorf("conflict %q differs in %v, want %v", c.Key, fields, want)
+				}
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	source := &models.Collection{ID: "source", Requests: []models.Request{
+		httpRequest("s-new", "delete user", "DELETE", "/users/:id"),
+		httpRequest("s-list", "list users", "GET", "/v2/users"),
+		httpRequest("s-get", "get user", "GET", "/v2/users/:id"),
+	}}
+	target := &models.Collection{ID: "target", Requests: []models.Request{
+		httpRequest("t-list", "list users", "GET", "/users"),
+		httpRequest("t-get", "get user", "GET", "/users/:id"),
+	}}
+	plan := BuildPlan(source, target, MatchByName)
+
+	t.Run("fallback theirs", func(t *testing.T) {
+		changes, err := plan.Resolve("target", nil, ResolutionTheirs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(changes.Creates) != 1 || changes.Creates[0].Name != "delete user" {
+			t.Fatalf("creates %+v", changes.Creates)
+		}
+		if c := changes.Creates[0]; c.ID == "s-new" || c.ID == "" || c.CollectionID != "target" {
+			t.Errorf("added request not copied into the target: %+v", c)
+		}
+		if got := ids(changes.Updates); !reflect.DeepEqual(got, []string{"t-list", "t-get"}) {
+			t.Fatalf("updated %v", got)
+		}
+		if u := changes.Updates[0]; deref(u.HTTPURL) != "/v2/users" || u.CollectionID != "target" {
+			t.Errorf("update does not carry the source: %+v", u)
+		}
+	})
+
+	t.Run("per key overrides fallback", func(t *testing.T) {
+		changes, err := plan.Resolve("target", map[string]Resolution{
+			"list users": ResolutionOurs,
+			"get user":   ResolutionKeepBoth,
+		}, ResolutionTheirs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(changes.Updates) != 0 {
+			t.Errorf("updates %v, want none", ids(changes.Updates))
+		}
+		var names []string
+		for _, c := range changes.Creates {
+			names = append(names, c.Name)
+		}
+		if want := []string{"delete user", "get user (merged)"}; !reflect.DeepEqual(names, want) {
+			t.Errorf("created %v, want %v", names, want)
+		}
+	})
+
+	t.Run("unspecified uses fallback", func(t *testing.T) {
+		changes, err := plan.Resolve("target", map[string]Resolution{"list users": ResolutionUnspecified}, ResolutionOurs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(changes.Updates) != 0 || len(changes.Creates) != 1 {
+			t.Errorf("changes %+v, want only the added request", changes)
+		}
+	})
+
+	for _, tt := range []struct {
+		name        string
+		resolutions map[string]Resolution
+	}{
+		{"unresolved conflict", map[string]Resolution{"list users": ResolutionOurs}},
+		{"unknown key", map[string]Resolution{"list users": ResolutionOurs, "get user": ResolutionOurs, "nope": ResolutionTheirs}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := plan.Resolve("target", tt.resolutions, ResolutionUnspecified)
+			if e, ok := apperr.As(err); !ok || e.Kind != apperr.KindInvalidArgument {
+				t.Errorf("error %v, want invalid argument", err)
+			}
+		})
+	}
+}
+
+func TestResolveKeepBothRenamesOnlyClashes(t *testing.T) {
+	// Matched by method and URL, the names differ, so keep-both needs no rename.
+	source := &models.Collection{Requests: []models.Request{httpRequest("s1", "users v2", "GET", "/users")}}
+	target := &models.Collection{Requests: []models.Request{httpRequest("t1", "users", "GET", "/users")}}
+	changes, err := BuildPlan(source, target, MatchByMethodURL).Resolve("target", nil, ResolutionKeepBoth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes.Creates) != 1 || changes.Creates[0].Name != "users v2" {
+		t.Errorf("creates %+v", changes.Creates)
+	}
+}
+
+func TestPlanToken(t *testing.T) {
+	source := &models.Collection{Requests: []models.Request{httpRequest("s1", "users", "GET", "/v2/users")}}
+	target := &models.Collection{Requests: []models.Request{httpRequest("t1", "users", "GET", "/users")}}
+	token := BuildPlan(source, target, MatchByName).Token()
+	if again := BuildPlan(source, target, MatchByName).Token(); again != token {
+		t.Error("token is not stable")
+	}
+	target.Requests[0].HTTPURL = str("/v1/users")
+	if BuildPlan(source, target, MatchByName).Token() == token {
+		t.Error("token ignores a changed target")
+	}
+}
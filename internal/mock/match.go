@@ -0,0 +1,251 @@
+package mock
+
+import (
+	"collectionsservice/internal/models"
+	"collectionsservice/internal/secrets"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+type incoming struct {
+	method string
+	path   string
+	query  url.Values
+	header http.Header
+	body   []byte
+}
+
+// operation returns the GraphQL operation name of the incoming request, from
+// operationName or else the first named operation in the query.
+func (in *incoming) operation() string {
+	if in.method == http.MethodGet {
+		if name := in.query.Get("operationName"); name != "" {
+			return name
+		}
+		return operationName(in.query.Get("query"))
+	}
+	var payload struct {
+		OperationName string `json:"operationName"`
+		Query         string `json:"query"`
+	}
+	if err := json.Unmarshal(in.body, &payload); err != nil {
+		return ""
+	}
+	if payload.OperationName != "" {
+		return payload.OperationName
+	}
+	return operationName(payload.Query)
+}
+
+// match picks the example that fits in best. Every path segment, query
+// parameter, header and body an example specifies must match; among the
+// examples that fit, the one matching the most literal segments and
+// conditions wins, and the oldest wins a tie. A non-empty name picks the
+// example with that name among those whose request matches the method and
+// path, ignoring the example's own conditions.
+func match(requests []models.Request, examples []*models.Example, in *incoming, name string) *models.Example {
+	byID := make(map[string]*models.Request, len(requests))
+	for i := range requests {
+		byID[requests[i].ID] = &requests[i]
+	}
+
+	var best *models.Example
+	bestScore := -1
+	for _, e := range examples {
+		r, ok := byID[e.RequestID]
+		if !ok || (name != "" && e.Name != name) {
+			continue
+		}
+		if name != "" {
+			if matchRoute(r, e, in) {
+				return e
+			}
+			continue
+		}
+		if score, ok := matchExample(r, e, in); ok && score > bestScore {
+			best, bestScore = e, score
+		}
+	}
+	return best
+}
+
+// matchRoute reports whether in has the method of r and the path of r or e.
+func matchRoute(r *models.Request, e *models.Example, in *incoming) bool {
+	template, ok := route(r, in)
+	if !ok {
+		return false
+	}
+	if _, ok := matchPath(template, in.path); ok {
+		return true
+	}
+	if e.RequestPath != nil {
+		_, ok = matchPath(requestPath(*e.RequestPath), in.path)
+	}
+	return ok
+}
+
+// route returns the path template of r if in has its method.
+func route(r *models.Request, in *incoming) (string, bool) {
+	if r.Kind == models.RequestKindGraphQL {
+		if in.method != http.MethodPost && in.method != http.MethodGet {
+			return "", false
+		}
+		return requestPath(deref(r.GraphQLEndpoint)), true
+	}
+	method := http.MethodGet
+	if deref(r.HTTPMethod) != "" {
+		method = *r.HTTPMethod
+	}
+	if !strings.EqualFold(method, in.method) {
+		return "", false
+	}
+	return requestPath(deref(r.HTTPURL)), true
+}
+
+func matchExample(r *models.Request, e *models.Example, in *incoming) (int, bool) {
+	template, ok := route(r, in)
+	if !ok {
+		return 0, false
+	}
+	score := 0
+	if r.Kind == models.RequestKindGraphQL {
+		if want := operationName(deref(r.GraphQLQuery)); want != "" {
+			got := in.operation()
+			if got != "" && got != want {
+				return 0, false
+			}
+			if got == want {
+				score++
+			}
+		}
+	}
+	if e.RequestPath != nil {
+		template = requestPath(*e.RequestPath)
+	}
+
+	literals, ok := matchPath(template, in.path)
+	if !ok {
+		return 0, false
+	}
+	score += literals
+
+	query, _ := secrets.ParseEntries(e.RequestQuery)
+	for _, q := range query {
+		if !hasValue(in.query[q.Key], q.Value) {
+			return 0, false
+		}
+		score++
+	}
+	headers, _ := secrets.ParseEntries(e.RequestHeaders)
+	for _, h := range headers {
+		if !hasValue(in.header.Values(h.Key), h.Value) {
+			return 0, false
+		}
+		score++
+	}
+	if e.RequestBody != nil {
+		if !bodyEqual(*e.RequestBody, in.body) {
+			return 0, false
+		}
+		score++
+	}
+	return score, true
+}
+
+// requestPath reduces a stored URL to its path: the scheme and host, or a
+// leading {{variable}} standing in for them, and the query are dropped.
+func requestPath(raw string) string {
+	raw, _, _ = strings.Cut(raw, "#")
+	raw, _, _ = strings.Cut(raw, "?")
+	if strings.HasPrefix(raw, "{{") {
+		if end := strings.Index(raw, "}}"); end >= 0 {
+			raw = raw[end+2:]
+		}
+	}
+	if _, rest, ok := strings.Cut(raw, "://"); ok {
+		raw = rest
+	}
+	if !strings.HasPrefix(raw, "/") {
+		if i := strings.Index(raw, "/"); i >= 0 {
+			raw = raw[i:]
+		} else {
+			raw = "/"
+		}
+	}
+	return raw
+}
+
+// matchPath matches path against template, where a segment written :name or
+// holding a {{variable}} matches any value. It returns the number of
+// literal segments matched.
+func matchPath(template, path string) (int, bool) {
+	want := segments(template)
+	got := segments(path)
+	if len(want) != len(got) {
+		return 0, false
+	}
+	literals := 0
+	for i, seg := range want {
+		if strings.HasPrefix(seg, ":") || strings.Contains(seg, "{{") {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(seg); err == nil {
+			seg = unescaped
+		}
+		if seg != got[i] {
+			return 0, false
+		}
+		literals++
+	}
+	return literals, true
+}
+
+func segments(path string) []string {
+	var out []string
+	for _, seg := range strings.Split(path, "/") {
+		if seg != "" {
+			out = append(out, seg)
+		}
+	}
+	return out
+}
+
+func hasValue(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
+
+// bodyEqual compares bodies as JSON when both parse, and as trimmed text
+// otherwise.
+func bodyEqual(want string, got []byte) bool {
+	var a, b interface{}
+	if json.Unmarshal([]byte(want), &a) == nil && json.Unmarshal(got, &b) == nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return strings.TrimSpace(want) == strings.TrimSpace(string(got))
+}
+
+var operationPattern = regexp.MustCompile(`\b(?:query|mutation|subscription)\s+([_A-Za-z][_0-9A-Za-z]*)`)
+
+func operationName(query string) string {
+	m := operationPattern.FindStringSubmatch(query)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,123 @@
+package mock
+
+import (
+	"collectionsservice/internal/models"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"gorm.io/datatypes"
+)
+
+func TestRequestPath(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"https://api.test/users/:id?expand=1", "/users/:id"},
+		{"{{baseUrl}}/users/{{userId}}", "/users/{{userId}}"},
+		{"api.test/users#top", "/users"},
+		{"https://api.test", "/"},
+		{"/users", "/users"},
+	}
+	for _, tt := range tests {
+		if got := requestPath(tt.raw); got != tt.want {
+			t.Errorf("requestPath(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		name         string
+		template     string
+		path         string
+		wantLiterals int
+		wantOK       bool
+	}{
+		{"literal", "/users/me", "/users/me", 2, true},
+		{"colon parameter", "/users/:id", "/users/42", 1, true},
+		{"variable parameter", "/users/{{userId}}/posts", "/users/42/posts", 2, true},
+		{"parameter inside a segment", "/files/{{name}}.json", "/files/a.json", 1, true},
+		{"only parameters", "/:a/:b", "/x/y", 0, true},
+		{"escaped literal", "/files/a%20b", "/files/a b", 2, true},
+		{"trailing slash", "/users/", "/users", 1, true},
+		{"different literal", "/users/:id", "/teams/42", 0, false},
+		{"missing parameter", "/users/:id", "/users", 0, false},
+		{"extra segment", "/users/:id", "/users/42/posts", 0, false},
+	}
+	for _, tt := range tests {
+		literals, ok := matchPath(tt.template, tt.path)
+		if ok != tt.wantOK || literals != tt.wantLiterals {
+			t.Errorf("%s: matchPath(%q, %q) = %d, %v; want %d, %v", tt.name, tt.template, tt.path, literals, ok, tt.wantLiterals, tt.wantOK)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	str := func(s string) *string { return &s }
+	requests := []models.Request{
+		{ID: "get-user", Kind: models.RequestKindHTTP, HTTPMethod: str("GET"), HTTPURL: str("{{baseUrl}}/users/:id")},
+		{ID: "get-me", Kind: models.RequestKindHTTP, HTTPMethod: str("GET"), HTTPURL: str("{{baseUrl}}/users/me")},
+		{ID: "create-user", Kind: models.RequestKindHTTP, HTTPMethod: str("POST"), HTTPURL: str("{{baseUrl}}/users")},
+		{ID: "list", Kind: models.RequestKindHTTP, HTTPURL: str("https://api.test/items")},
+		{ID: "gql-user", Kind: models.RequestKindGraphQL, GraphQLEndpoint: str("{{baseUrl}}/graphql"), GraphQLQuery: str("query GetUser { user { id } }")},
+		{ID: "gql-posts", Kind: models.RequestKindGraphQL, GraphQLEndpoint: str("{{baseUrl}}/graphql"), GraphQLQuery: str("query ListPosts { posts { id } }")},
+	}
+	examples := []*models.Example{
+		{ID: "user", RequestID: "get-user", Name: "default"},
+		{ID: "user-admin", RequestID: "get-user", Name: "admin", RequestQuery: datatypes.JSON(`[{"key":"role","value":"admin"}]`)},
+		{ID: "user-42", RequestID: "get-user", Name: "forty-two", RequestPath: str("/users/42")},
+		{ID: "user-v2", RequestID: "get-user", Name: "v2", RequestHeaders: datatypes.JSON(`[{"key":"Accept","value":"application/vnd.v2+json"}]`)},
+		{ID: "me", RequestID: "get-me", Name: "default"},
+		{ID: "created", RequestID: "create-user", Name: "created"},
+		{ID: "created-bob", RequestID: "create-user", Name: "bob", RequestBody: str(`{"name": "bob"}`)},
+		{ID: "list-first", RequestID: "list", Name: "first"},
+		{ID: "list-second", RequestID: "list", Name: "second"},
+		{ID: "gql-user", RequestID: "gql-user", Name: "user"},
+		{ID: "gql-posts", RequestID: "gql-posts", Name: "posts"},
+		{ID: "orphan", RequestID: "deleted", Name: "default"},
+	}
+
+	tests := []struct {
+		name   string
+		in     incoming
+		pick   string
+		wantID string
+	}{
+		{name: "path parameter", in: incoming{method: "GET", path: "/users/7"}, wantID: "user"},
+		{name: "literal segment beats parameter", in: incoming{method: "GET", path: "/users/me"}, wantID: "me"},
+		{name: "example path beats request template", in: incoming{method: "GET", path: "/users/42"}, wantID: "user-42"},
+		{name: "matching query wins", in: incoming{method: "GET", path: "/users/7", query: url.Values{"role": {"admin"}}}, wantID: "user-admin"},
+		{name: "other query value", in: incoming{method: "GET", path: "/users/7", query: url.Values{"role": {"guest"}}}, wantID: "user"},
+		{name: "matching header wins", in: incoming{method: "GET", path: "/users/7", header: http.Header{"Accept": {"application/vnd.v2+json"}}}, wantID: "user-v2"},
+		{name: "method mismatch", in: incoming{method: "DELETE", path: "/users/7"}},
+		{name: "default GET method, oldest wins a tie", in: incoming{method: "GET", path: "/items"}, wantID: "list-first"},
+		{name: "body compared as JSON", in: incoming{method: "POST", path: "/users", body: []byte(`{"name":"bob"}`)}, wantID: "created-bob"},
+		{name: "other body", in: incoming{method: "POST", path: "/users", body: []byte(`{"name":"eve"}`)}, wantID: "created"},
+		{name: "unknown path", in: incoming{method: "GET", path: "/nothing"}},
+		{name: "graphql operation in body", in: incoming{method: "POST", path: "/graphql", body: []byte(`{"query":"query ListPosts { posts { id } }"}`)}, wantID: "gql-posts"},
+		{name: "graphql operationName", in: incoming{method: "POST", path: "/graphql", body: []byte(`{"operationName":"GetUser","query":"query GetUser { user { id } }"}`)}, wantID: "gql-user"},
+		{name: "graphql GET", in: incoming{method: "GET", path: "/graphql", query: url.Values{"query": {"query ListPosts { posts { id } }"}}}, wantID: "gql-posts"},
+		{name: "graphql unknown operation", in: incoming{method: "POST", path: "/graphql", body: []byte(`{"query":"query Other { x }"}`)}},
+		{name: "picked by name", in: incoming{method: "GET", path: "/users/7"}, pick: "forty-two", wantID: "user-42"},
+		{name: "pick ignores conditions", in: incoming{method: "GET", path: "/users/7"}, pick: "admin", wantID: "user-admin"},
+		{name: "pick needs the route", in: incoming{method: "POST", path: "/users/7"}, pick: "admin"},
+		{name: "pick unknown name", in: incoming{method: "GET", path: "/users/7"}, pick: "missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := tt.in
+			if in.header == nil {
+				in.header = http.Header{}
+			}
+			got := match(requests, examples, &in, tt.pick)
+			switch {
+			case got == nil && tt.wantID != "":
+				t.Errorf("no match, want %s", tt.wantID)
+			case got != nil && got.ID != tt.wantID:
+				t.Errorf("matched %s, want %q", got.ID, tt.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Package mock serves the saved examples of collections over HTTP, so clients
+// can be built against a collection before its backend exists.
+package mock
+
+import (
+	"collectionsservice/internal/models"
+	"collectionsservice/internal/secrets"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// ExampleHeader picks an example by name, overriding matching.
+const ExampleHeader = "X-Mock-Example"
+
+// maxRequestBody caps how much of an incoming body is read for matching.
+const maxRequestBody = 1 << 20
+
+// Store is the part of the collection repository the mock server reads.
+type Store interface {
+	GetByIDWithRequests(ctx context.Context, id string) (*models.Collection, error)
+	ListExamples(ctx context.Context, collectionID, requestID string) ([]*models.Example, error)
+}
+
+// Server answers /{collection_id}/{path} with the best matching example of
+// that collection.
+type Server struct {
+	store Store
+}
+
+func NewServer(store Store) *Server {
+	return &Server{store: store}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	collectionID, path, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	path = "/" + path
+	if collectionID == "" {
+		writeError(w, http.StatusNotFound, "request path must start with a collection ID")
+		return
+	}
+
+	col, err := s.store.GetByIDWithRequests(r.Context(), collectionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("collection %s not found", collectionID))
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("collection_id", collectionID).Msg("Failed to load collection for mock")
+		writeError(w, http.StatusInternalServerError, "failed to load collection")
+		return
+	}
+	examples, err := s.store.ListExamples(r.Context(), collectionID, "")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load examples")
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBody))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read request body")
+		return
+	}
+	in := &incoming{
+		method: r.Method,
+		path:   path,
+		query:  r.URL.Query(),
+		header: r.Header,
+		body:   body,
+	}
+
+	example := match(col.Requests, examples, in, r.Header.Get(ExampleHeader))
+	if example == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no example matches %s %s", r.Method, path))
+		return
+	}
+
+	log.Debug().Str("collection_id", collectionID).Str("example_id", example.ID).
+		Str("method", r.Method).Str("path", path).Msg("Serving mock example")
+	entries, _ := secrets.ParseEntries(example.Headers)
+	for _, h := range entries {
+		switch http.CanonicalHeaderKey(h.Key) {
+		case "Content-Length", "Transfer-Encoding", "Content-Encoding", "Connection":
+			continue
+		}
+		w.Header().Add(h.Key, h.Value)
+	}
+	w.WriteHeader(example.StatusCode)
+	_, _ = io.WriteString(w, example.Body)
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
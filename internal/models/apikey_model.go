@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type APIKey struct {
+	ID         string `gorm:"type:uuid;primaryKey"`
+	Name       string `gorm:"not null"`
+	Subject    string `gorm:"not null;index"`
+	Prefix     string `gorm:"type:text;not null"`
+	Hash       string `gorm:"type:text;not null;uniqueIndex"`
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
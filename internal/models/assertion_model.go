@@ -0,0 +1,29 @@
+package models
+
+type AssertionType string
+
+const (
+	AssertStatusEquals      AssertionType = "status_equals"
+	AssertStatusInRange     AssertionType = "status_in_range"
+	AssertHeaderPresent     AssertionType = "header_present"
+	AssertHeaderEquals      AssertionType = "header_equals"
+	AssertJSONPathEquals    AssertionType = "jsonpath_equals"
+	AssertJSONPathContains  AssertionType = "jsonpath_contains"
+	AssertJSONPathMatches   AssertionType = "jsonpath_matches"
+	AssertResponseTimeBelow AssertionType = "response_time_below"
+	AssertJSONSchema        AssertionType = "json_schema"
+)
+
+// Assertion is a check on a response, stored as a jsonb list on requests.
+// Target is the header name or JSONPath expression; Value is the expected
+// value, regular expression or JSON Schema. Number is the expected status or
+// the response time limit in milliseconds, and Min and Max bound a status
+// range.
+type Assertion struct {
+	Type   AssertionType `json:"type"`
+	Target string        `json:"target,omitempty"`
+	Value  string        `json:"value,omitempty"`
+	Number int64         `json:"number,omitempty"`
+	Min    int64         `json:"min,omitempty"`
+	Max    int64         `json:"max,omitempty"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type AuditOutcome string
+
+const (
+	AuditSucceeded AuditOutcome = "succeeded"
+	// AuditDenied means authentication or authorization failed.
+	AuditDenied AuditOutcome = "denied"
+	AuditFailed AuditOutcome = "failed"
+)
+
+// AuditEvent records one call to a mutating RPC. Payload and snapshots are
+// stored with secret values redacted.
+type AuditEvent struct {
+	ID          string `gorm:"type:uuid;primaryKey"`
+	Method      string `gorm:"type:text;not null;index"`
+	Subject     string `gorm:"type:text;index"`
+	AuthMethod  string `gorm:"type:text"`
+	APIKeyID    string `gorm:"type:text"`
+	WorkspaceID string `gorm:"type:uuid;index:idx_audit_events_workspace_created,priority:1"`
+	// CollectionID is the collection the call changed, when there is one.
+	CollectionID *string `gorm:"type:uuid;index"`
+	PeerAddress  string  `gorm:"type:text"`
+	ForwardedFor string  `gorm:"type:text"`
+
+	Payload datatypes.JSON `gorm:"type:jsonb"`
+	Outcome AuditOutcome   `gorm:"type:text;not null;index"`
+	Code    string         `gorm:"type:text"`
+	Error   *string        `gorm:"type:text"`
+	Before  datatypes.JSON `gorm:"type:jsonb"`
+	After   datatypes.JSON `gorm:"type:jsonb"`
+
+	DurationMs int64
+	CreatedAt  time.Time `gorm:"not null;index:idx_audit_events_workspace_created,priority:2"`
+}
+
+// AuditFilter narrows an audit listing. Zero fields do not filter.
+type AuditFilter struct {
+	WorkspaceID  string
+	Subject      string
+	Method       string
+	CollectionID string
+	Outcome      AuditOutcome
+	Since        time.Time
+	Until        time.Time
+	// Before continues a listing after this event.
+	Before *AuditEvent
+	Limit  int
+}
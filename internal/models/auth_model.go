@@ -0,0 +1,56 @@
+package models
+
+type AuthType string
+
+const (
+	AuthInherit  AuthType = "inherit"
+	AuthNone     AuthType = "none"
+	AuthBasic    AuthType = "basic"
+	AuthBearer   AuthType = "bearer"
+	AuthAPIKey   AuthType = "apikey"
+	AuthOAuth2   AuthType = "oauth2"
+	AuthAWSSigV4 AuthType = "awsv4"
+	AuthDigest   AuthType = "digest"
+)
+
+const (
+	APIKeyInHeader = "header"
+	APIKeyInQuery  = "query"
+
+	OAuth2ClientCredentials = "client_credentials"
+	OAuth2Password          = "password"
+)
+
+// AuthConfig is stored as jsonb on requests and collections. A request with
+// no auth, or with AuthInherit, uses its collection's auth. Password, Token,
+// APIKeyValue, ClientSecret, AWSSecretAccessKey and AWSSessionToken are
+// always sealed at rest.
+type AuthConfig struct {
+	Type AuthType `json:"type"`
+
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+
+	Token string `json:"token,omitempty"`
+
+	APIKeyName  string `json:"api_key_name,omitempty"`
+	APIKeyValue string `json:"api_key_value,omitempty"`
+	APIKeyIn    string `json:"api_key_in,omitempty"`
+
+	OAuth2Grant  string   `json:"oauth2_grant,omitempty"`
+	TokenURL     string   `json:"token_url,omitempty"`
+	ClientID     string   `json:"client_id,omitempty"`
+	ClientSecret string   `json:"client_secret,omitempty"`
+	Scopes       []string `json:"scopes,omitempty"`
+
+	AWSAccessKeyID     string `json:"aws_access_key_id,omitempty"`
+	AWSSecretAccessKey string `json:"aws_secret_access_key,omitempty"`
+	AWSSessionToken    string `json:"aws_session_token,omitempty"`
+	AWSRegion          string `json:"aws_region,omitempty"`
+	AWSService         string `json:"aws_service,omitempty"`
+}
+
+// SecretFields returns pointers to the fields of a that hold credentials.
+func (a *AuthConfig) SecretFields() []*string {
+	return []*string{&a.Password, &a.Token, &a.APIKeyValue, &a.ClientSecret, &a.AWSSecretAccessKey, &a.AWSSessionToken}
+}
@@ -0,0 +1,30 @@
+package models
+
+type CaptureSource string
+
+const (
+	CaptureJSONPath CaptureSource = "jsonpath"
+	CaptureHeader   CaptureSource = "header"
+	CaptureRegex    CaptureSource = "regex"
+	CaptureCookie   CaptureSource = "cookie"
+)
+
+type CaptureScope string
+
+const (
+	// CaptureScopeRun keeps the value for the rest of the run only.
+	CaptureScopeRun CaptureScope = "run"
+	// CaptureScopeCollection also saves the value as a collection variable.
+	CaptureScopeCollection CaptureScope = "collection"
+)
+
+// Capture copies part of a response into a variable, stored as a jsonb list
+// on requests. Expression is a JSONPath, a header or cookie name, or a
+// regular expression matched against the body whose first group, or whole
+// match when it has none, is taken.
+type Capture struct {
+	Source     CaptureSource `json:"source"`
+	Expression string        `json:"expression"`
+	Variable   string        `json:"variable"`
+	Scope      CaptureScope  `json:"scope,omitempty"`
+}
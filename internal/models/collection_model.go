@@ -12,10 +12,20 @@ const (
 )
 
 type Collection struct {
-	ID          string    `gorm:"type:uuid;primaryKey"`
-	Name        string    `gorm:"not null"`
-	Description *string   `gorm:"type:text"`
-	Requests    []Request `gorm:"foreignKey:CollectionID;constraint:OnDelete:CASCADE"`
+	ID          string         `gorm:"type:uuid;primaryKey"`
+	WorkspaceID string         `gorm:"type:uuid;index;uniqueIndex:idx_collections_workspace_name"`
+	Name        string         `gorm:"not null;uniqueIndex:idx_collections_workspace_name"`
+	Description *string        `gorm:"type:text"`
+	Requests    []Request      `gorm:"foreignKey:CollectionID;constraint:OnDelete:CASCADE"`
+	Variables   datatypes.JSON `gorm:"type:jsonb"`
+	Auth        datatypes.JSON `gorm:"type:jsonb"`
+
+	PreRequestScript *string `gorm:"type:text"`
+	TestScript       *string `gorm:"type:text"`
+
+	ForkedFromID       *string        `gorm:"type:uuid;index"`
+	ForkBaseRequestIDs datatypes.JSON `gorm:"type:jsonb"`
+	Protected          bool           `gorm:"not null;default:false"`
 }
 
 type Request struct {
@@ -34,6 +44,16 @@ type Request struct {
 	GraphQLQuery     *string        `gorm:"type:text"`
 	GraphQLVariables datatypes.JSON `gorm:"type:jsonb"`
 	GraphQLHeaders   datatypes.JSON `gorm:"type:jsonb"`
+
+	Auth datatypes.JSON `gorm:"type:jsonb"`
+
+	PreRequestScript *string `gorm:"type:text"`
+	TestScript       *string `gorm:"type:text"`
+
+	Assertions datatypes.JSON `gorm:"type:jsonb"`
+	Captures   datatypes.JSON `gorm:"type:jsonb"`
+
+	ForkedFromRequestID *string `gorm:"type:uuid"`
 }
 
 type UpdateRequestInCollectionParams struct {
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Example is a saved response for a request. The Request* fields optionally
+// describe the request variant that produced it: a concrete path, and the
+// query parameters and headers it was sent with.
+type Example struct {
+	ID         string         `gorm:"type:uuid;primaryKey"`
+	RequestID  string         `gorm:"type:uuid;not null;index"`
+	Name       string         `gorm:"not null"`
+	StatusCode int            `gorm:"not null"`
+	Headers    datatypes.JSON `gorm:"type:jsonb"`
+	Body       string         `gorm:"type:text"`
+
+	RequestPath    *string        `gorm:"type:text"`
+	RequestQuery   datatypes.JSON `gorm:"type:jsonb"`
+	RequestHeaders datatypes.JSON `gorm:"type:jsonb"`
+	RequestBody    *string        `gorm:"type:text"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Request Request `gorm:"foreignKey:RequestID;constraint:OnDelete:CASCADE"`
+}
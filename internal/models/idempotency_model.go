@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// IdempotencyKey remembers the result of a create call made with a client
+// supplied key, so a retry gets the original result instead of a duplicate.
+// Keys are scoped to the caller, the workspace and the method.
+type IdempotencyKey struct {
+	Subject     string `gorm:"type:text;primaryKey"`
+	WorkspaceID string `gorm:"type:uuid;primaryKey"`
+	Method      string `gorm:"type:text;primaryKey"`
+	Key         string `gorm:"type:text;primaryKey"`
+	// RequestHash is a SHA-256 of the request without its key, to reject a
+	// key reused for a different request.
+	RequestHash string `gorm:"type:text;not null"`
+	// Response is the marshalled response, or nil while the first call is
+	// still running.
+	Response []byte
+	// LeaseExpiresAt is when a call that has not stored its response loses
+	// the key, so a call that died while holding it does not block retries
+	// until the key expires.
+	LeaseExpiresAt time.Time `gorm:"not null;default:now()"`
+	CreatedAt      time.Time
+	ExpiresAt      time.Time `gorm:"not null;index"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+type Role string
+
+const (
+	RoleOwner  Role = "owner"
+	RoleEditor Role = "editor"
+	RoleRunner Role = "runner"
+	RoleViewer Role = "viewer"
+)
+
+type WorkspaceMember struct {
+	WorkspaceID string `gorm:"type:uuid;primaryKey"`
+	Subject     string `gorm:"type:text;primaryKey"`
+	Role        Role   `gorm:"type:text;not null"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// CollectionMember overrides the subject's workspace role for one collection.
+type CollectionMember struct {
+	CollectionID string `gorm:"type:uuid;primaryKey"`
+	Subject      string `gorm:"type:text;primaryKey"`
+	Role         Role   `gorm:"type:text;not null"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
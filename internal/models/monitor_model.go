@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Monitor runs a collection on a cron schedule. Variables override the
+// collection's variables for every scheduled run.
+type Monitor struct {
+	ID           string         `gorm:"type:uuid;primaryKey"`
+	CollectionID string         `gorm:"type:uuid;not null;index"`
+	Name         string         `gorm:"not null"`
+	Cron         string         `gorm:"type:text;not null"`
+	Timezone     string         `gorm:"type:text;not null;default:'UTC'"`
+	Enabled      bool           `gorm:"not null;default:true"`
+	Variables    datatypes.JSON `gorm:"type:jsonb"`
+	// NotifyURL receives a JSON POST when a run fails after passing, or
+	// passes after failing.
+	NotifyURL *string `gorm:"type:text"`
+	CreatedBy string  `gorm:"type:text"`
+
+	// NextRunAt is nil while the monitor is disabled.
+	NextRunAt           *time.Time `gorm:"index"`
+	LastRunAt           *time.Time
+	LastStatus          RunStatus `gorm:"type:text"`
+	LastCollectionRunID *string   `gorm:"type:uuid"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Collection Collection `gorm:"foreignKey:CollectionID;constraint:OnDelete:CASCADE"`
+}
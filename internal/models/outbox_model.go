@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Domain events recorded in the outbox.
+const (
+	EventCollectionCreated = "collection.created"
+	EventCollectionUpdated = "collection.updated"
+	EventCollectionDeleted = "collection.deleted"
+	EventRequestAdded      = "request.added"
+	EventRequestUpdated    = "request.updated"
+	EventRequestRemoved    = "request.removed"
+)
+
+// OutboxEvent is a change to a collection, written in the same transaction
+// as the change itself. The relay publishes events in Seq order and marks
+// them published, giving each the next Position. Transactions may commit out
+// of Seq order, but Positions are committed in order, so watchers follow
+// Position.
+type OutboxEvent struct {
+	Seq          int64          `gorm:"primaryKey;autoIncrement"`
+	ID           string         `gorm:"type:uuid;not null;uniqueIndex"`
+	Event        string         `gorm:"type:text;not null"`
+	WorkspaceID  string         `gorm:"type:uuid;not null"`
+	CollectionID string         `gorm:"type:uuid;not null"`
+	Actor        string         `gorm:"type:text"`
+	Data         datatypes.JSON `gorm:"type:jsonb"`
+	CreatedAt    time.Time
+	PublishedAt  *time.Time `gorm:"index"`
+	Position     *int64     `gorm:"uniqueIndex"`
+	Attempts     int        `gorm:"not null;default:0"`
+	LastError    *string    `gorm:"type:text"`
+}
+
+// ChangeFilter selects published events after a position. Empty IDs do not
+// filter.
+type ChangeFilter struct {
+	After        int64
+	WorkspaceID  string
+	CollectionID string
+	Limit        int
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type ProposalStatus string
+
+const (
+	ProposalStatusOpen     ProposalStatus = "OPEN"
+	ProposalStatusApproved ProposalStatus = "APPROVED"
+	ProposalStatusRejected ProposalStatus = "REJECTED"
+)
+
+type ProposalChangeType string
+
+const (
+	ProposalChangeAdded      ProposalChangeType = "ADDED"
+	ProposalChangeModified   ProposalChangeType = "MODIFIED"
+	ProposalChangeRemoved    ProposalChangeType = "REMOVED"
+	ProposalChangeCollection ProposalChangeType = "COLLECTION"
+)
+
+type ChangeProposal struct {
+	ID                   string         `gorm:"type:uuid;primaryKey"`
+	ForkCollectionID     string         `gorm:"type:uuid;not null;index"`
+	UpstreamCollectionID string         `gorm:"type:uuid;not null;index"`
+	Title                string         `gorm:"not null"`
+	Description          *string        `gorm:"type:text"`
+	Author               string         `gorm:"not null"`
+	Status               ProposalStatus `gorm:"type:text;not null;index"`
+	Changes              datatypes.JSON `gorm:"type:jsonb"`
+	ReviewedBy           *string        `gorm:"type:text"`
+	ReviewedAt           *time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	Comments             []ProposalComment `gorm:"foreignKey:ProposalID;constraint:OnDelete:CASCADE"`
+}
+
+type ProposalComment struct {
+	ID         string `gorm:"type:uuid;primaryKey"`
+	ProposalID string `gorm:"type:uuid;not null;index"`
+	Author     string `gorm:"not null"`
+	Body       string `gorm:"type:text;not null"`
+	CreatedAt  time.Time
+}
+
+// ProposalChange is one entry of the change list snapshotted into
+// ChangeProposal.Changes when the proposal is opened.
+type ProposalChange struct {
+	Type                ProposalChangeType `json:"type"`
+	Name                string             `json:"name"`
+	ForkRequestID       string             `json:"fork_request_id,omitempty"`
+	UpstreamRequestID   string             `json:"upstream_request_id,omitempty"`
+	UpstreamFingerprint string             `json:"upstream_fingerprint,omitempty"`
+	Diffs               []FieldChange      `json:"diffs,omitempty"`
+	Request             *Request           `json:"request,omitempty"`
+}
+
+type FieldChange struct {
+	Field    string `json:"field"`
+	Proposed string `json:"proposed"`
+	Current  string `json:"current"`
+}
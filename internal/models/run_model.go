@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type RunStatus string
+
+const (
+	// RunStatusPassed means the response arrived and every script,
+	// assertion and test passed.
+	RunStatusPassed RunStatus = "passed"
+	// RunStatusFailed means the response arrived but a script, assertion or
+	// test failed.
+	RunStatusFailed RunStatus = "failed"
+	// RunStatusError means the request was not sent or got no response.
+	RunStatusError RunStatus = "error"
+)
+
+// RequestRun records one execution of a request, from ExecuteRequest or as
+// part of a collection run. Secret values are redacted before it is stored.
+type RequestRun struct {
+	ID              string    `gorm:"type:uuid;primaryKey"`
+	CollectionID    string    `gorm:"type:uuid;not null;index:idx_request_runs_collection_started,priority:1"`
+	RequestID       string    `gorm:"type:uuid;not null;index:idx_request_runs_request_started,priority:1"`
+	CollectionRunID *string   `gorm:"type:uuid;index"`
+	RequestName     string    `gorm:"type:text"`
+	TriggeredBy     string    `gorm:"type:text"`
+	Status          RunStatus `gorm:"type:text;not null;index"`
+
+	Method         string         `gorm:"type:text"`
+	URL            string         `gorm:"type:text"`
+	RequestHeaders datatypes.JSON `gorm:"type:jsonb"`
+	RequestBody    string         `gorm:"type:text"`
+
+	StatusCode      int            `gorm:"index"`
+	ResponseHeaders datatypes.JSON `gorm:"type:jsonb"`
+	ResponseBody    string         `gorm:"type:text"`
+	Truncated       bool           `gorm:"not null;default:false"`
+	DurationMs      int64
+
+	Error       *string        `gorm:"type:text"`
+	ScriptError *string        `gorm:"type:text"`
+	Assertions  datatypes.JSON `gorm:"type:jsonb"`
+	Tests       datatypes.JSON `gorm:"type:jsonb"`
+
+	StartedAt time.Time `gorm:"not null;index:idx_request_runs_collection_started,priority:2;index:idx_request_runs_request_started,priority:2"`
+
+	Collection Collection `gorm:"foreignKey:CollectionID;constraint:OnDelete:CASCADE"`
+}
+
+// RunFilter narrows a run history listing. Zero fields do not filter.
+type RunFilter struct {
+	CollectionID    string
+	RequestID       string
+	CollectionRunID string
+	Status          RunStatus
+	StatusCodeMin   int
+	StatusCodeMax   int
+	Since           time.Time
+	Until           time.Time
+	Limit           int
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+type ShareAccess string
+
+const (
+	ShareAccessRead ShareAccess = "read"
+	ShareAccessRun  ShareAccess = "run"
+)
+
+// CollectionShare grants whoever holds its token access to one collection
+// without a workspace membership.
+type CollectionShare struct {
+	ID           string      `gorm:"type:uuid;primaryKey"`
+	CollectionID string      `gorm:"type:uuid;not null;index"`
+	Access       ShareAccess `gorm:"type:text;not null"`
+	Prefix       string      `gorm:"type:text;not null"`
+	Hash         string      `gorm:"type:text;not null;uniqueIndex"`
+	CreatedBy    string      `gorm:"type:text"`
+	ExpiresAt    time.Time   `gorm:"not null"`
+	RevokedAt    *time.Time
+	CreatedAt    time.Time
+
+	Collection Collection `gorm:"foreignKey:CollectionID;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Webhook subscribes a URL to events in a workspace, or in one of its
+// collections when CollectionID is set.
+type Webhook struct {
+	ID           string  `gorm:"type:uuid;primaryKey"`
+	WorkspaceID  string  `gorm:"type:uuid;not null;index"`
+	CollectionID *string `gorm:"type:uuid;index"`
+	URL          string  `gorm:"type:text;not null"`
+	// Secret signs every payload. It is always stored sealed.
+	Secret string `gorm:"type:text;not null"`
+	// Events lists the subscribed event names; empty means every event.
+	Events    datatypes.JSON `gorm:"type:jsonb"`
+	Enabled   bool           `gorm:"not null;default:true"`
+	CreatedBy string         `gorm:"type:text"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryFailed means every attempt failed and no more are scheduled.
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event payload to send to one webhook.
+type WebhookDelivery struct {
+	ID             string         `gorm:"type:uuid;primaryKey"`
+	WebhookID      string         `gorm:"type:uuid;not null;index"`
+	EventID        string         `gorm:"type:uuid;not null"`
+	Event          string         `gorm:"type:text;not null"`
+	Payload        []byte         `gorm:"type:bytea;not null"`
+	Status         DeliveryStatus `gorm:"type:text;not null;index"`
+	AttemptCount   int            `gorm:"not null;default:0"`
+	NextAttemptAt  *time.Time     `gorm:"index"`
+	LastStatusCode int
+	LastError      *string `gorm:"type:text"`
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	Webhook  Webhook          `gorm:"foreignKey:WebhookID;constraint:OnDelete:CASCADE"`
+	Attempts []WebhookAttempt `gorm:"foreignKey:DeliveryID;constraint:OnDelete:CASCADE"`
+}
+
+// WebhookAttempt logs one try at sending a delivery.
+type WebhookAttempt struct {
+	ID           string `gorm:"type:uuid;primaryKey"`
+	DeliveryID   string `gorm:"type:uuid;not null;index"`
+	Number       int    `gorm:"not null"`
+	StatusCode   int
+	Error        *string `gorm:"type:text"`
+	ResponseBody string  `gorm:"type:text"`
+	DurationMs   int64
+	CreatedAt    time.Time
+}
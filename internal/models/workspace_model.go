@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// DefaultWorkspaceID owns every collection created before workspaces existed
+// and is used for callers that do not name a workspace.
+const DefaultWorkspaceID = "00000000-0000-0000-0000-000000000001"
+
+type Workspace struct {
+	ID          string       `gorm:"type:uuid;primaryKey"`
+	Name        string       `gorm:"not null"`
+	Description *string      `gorm:"type:text"`
+	Collections []Collection `gorm:"foreignKey:WorkspaceID;constraint:OnDelete:CASCADE"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
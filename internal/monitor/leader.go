@@ -0,0 +1,66 @@
+package monitor
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/rs/zerolog/log"
+)
+
+// LockKey is the advisory lock key held by the scheduling replica.
+const LockKey int64 = 0x6d6f6e69746f72 // "monitor"
+
+// Leader holds a session-level Postgres advisory lock on a dedicated
+// connection. The lock is released when the connection closes, so a replica
+// that dies hands leadership over without any cleanup.
+type Leader struct {
+	db   *sql.DB
+	key  int64
+	conn *sql.Conn
+}
+
+func NewLeader(db *sql.DB, key int64) *Leader {
+	return &Leader{db: db, key: key}
+}
+
+// Acquire reports whether this replica holds the lock, trying to take it if
+// it does not.
+func (l *Leader) Acquire(ctx context.Context) bool {
+	if l.conn != nil {
+		if err := l.conn.PingContext(ctx); err == nil {
+			return true
+		}
+		log.Warn().Msg("Lost monitor scheduler leadership")
+		l.conn.Close()
+		l.conn = nil
+	}
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to get a connection for the monitor lock")
+		return false
+	}
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", l.key).Scan(&locked); err != nil || !locked {
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to try the monitor lock")
+		}
+		conn.Close()
+		return false
+	}
+	l.conn = conn
+	log.Info().Msg("Acquired monitor scheduler leadership")
+	return true
+}
+
+// Release gives up the lock if it is held.
+func (l *Leader) Release() {
+	if l.conn == nil {
+		return
+	}
+	if _, err := l.conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", l.key); err != nil {
+		log.Warn().Err(err).Msg("Failed to release the monitor lock")
+	}
+	l.conn.Close()
+	l.conn = nil
+}
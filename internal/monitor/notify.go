@@ -0,0 +1,101 @@
+package monitor
+
+import (
+	"bytes"
+	"collectionsservice/internal/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Event string
+
+const (
+	EventFailed    Event = "monitor.failed"
+	EventRecovered Event = "monitor.recovered"
+)
+
+// Notification tells a monitor's owners that its runs started failing or
+// passed again.
+type Notification struct {
+	Event   Event
+	Monitor *models.Monitor
+	Summary *Summary
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// Notifiers sends each notification through every notifier in turn.
+type Notifiers []Notifier
+
+func (ns Notifiers) Notify(ctx context.Context, n Notification) error {
+	var errs []error
+	for _, notifier := range ns {
+		if err := notifier.Notify(ctx, n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WebhookNotifier posts notifications as JSON to the monitor's NotifyURL.
+// The text field makes the payload readable by Slack and Teams incoming
+// webhooks.
+type WebhookNotifier struct {
+	client *http.Client
+}
+
+func NewWebhookNotifier(timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{client: &http.Client{Timeout: timeout}}
+}
+
+func (w *WebhookNotifier) Notify(ctx context.Context, n Notification) error {
+	if n.Monitor.NotifyURL == nil || *n.Monitor.NotifyURL == "" {
+		return nil
+	}
+
+	text := fmt.Sprintf("Monitor %q passed again", n.Monitor.Name)
+	switch {
+	case n.Event == EventFailed && n.Summary.Error != "":
+		text = fmt.Sprintf("Monitor %q failed: %s", n.Monitor.Name, n.Summary.Error)
+	case n.Event == EventFailed:
+		text = fmt.Sprintf("Monitor %q failed: %d of %d requests failed, %d tests and %d assertions failed",
+			n.Monitor.Name, n.Summary.RequestsFailed, n.Summary.Requests, n.Summary.TestsFailed, n.Summary.AssertionsFailed)
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"text":              text,
+		"event":             n.Event,
+		"monitor_id":        n.Monitor.ID,
+		"monitor_name":      n.Monitor.Name,
+		"collection_id":     n.Monitor.CollectionID,
+		"collection_run_id": n.Summary.CollectionRunID,
+		"error":             n.Summary.Error,
+		"requests":          n.Summary.Requests,
+		"requests_failed":   n.Summary.RequestsFailed,
+		"tests_failed":      n.Summary.TestsFailed,
+		"assertions_failed": n.Summary.AssertionsFailed,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *n.Monitor.NotifyURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notification endpoint returned %s", resp.Status)
+	}
+	return nil
+}
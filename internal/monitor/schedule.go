@@ -0,0 +1,63 @@
+// Package monitor runs collections on cron schedules. One replica at a time
+// schedules runs, elected through a Postgres advisory lock.
+package monitor
+
+import (
+	"fmt"
+	"time"
+	// Timezones must resolve on hosts without a zoneinfo database.
+	_ "time/tzdata"
+
+	"github.com/robfig/cron/v3"
+)
+
+// minInterval is the shortest time allowed between two scheduled runs.
+const minInterval = time.Minute
+
+var parser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// Next returns the first time after after that expr fires in timezone tz.
+func Next(expr, tz string, after time.Time) (time.Time, error) {
+	sched, loc, err := parse(expr, tz)
+	if err != nil {
+		return time.Time{}, err
+	}
+	next := sched.Next(after.In(loc))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("cron expression %q never fires", expr)
+	}
+	return next, nil
+}
+
+// Check reports whether expr is a five-field cron expression or descriptor
+// such as @hourly, tz a known timezone, and the schedule no more frequent
+// than once a minute.
+func Check(expr, tz string) error {
+	sched, loc, err := parse(expr, tz)
+	if err != nil {
+		return err
+	}
+	first := sched.Next(time.Now().In(loc))
+	if first.IsZero() {
+		return fmt.Errorf("cron expression %q never fires", expr)
+	}
+	if sched.Next(first).Sub(first) < minInterval {
+		return fmt.Errorf("cron expression %q fires more than once a minute", expr)
+	}
+	return nil
+}
+
+func parse(expr, tz string) (cron.Schedule, *time.Location, error) {
+	if tz == "" {
+		tz = "UTC"
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unknown timezone %q", tz)
+	}
+	sched, err := parser.Parse(expr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+	return sched, loc, nil
+}
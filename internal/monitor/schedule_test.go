@@ -0,0 +1,77 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		expr, tz string
+		wantErr  string
+	}{
+		{expr: "*/5 * * * *"},
+		{expr: "0 9 * * 1-5", tz: "Europe/Berlin"},
+		{expr: "@hourly", tz: "America/New_York"},
+		{expr: "@every 1m"},
+		{expr: "* * * * *"},
+		{expr: "@every 30s", wantErr: "more than once a minute"},
+		{expr: "0 0 30 2 *", wantErr: "never fires"},
+		{expr: "* * * * * *", wantErr: "invalid cron expression"},
+		{expr: "61 * * * *", wantErr: "invalid cron expression"},
+		{expr: "", wantErr: "invalid cron expression"},
+		{expr: "@hourly", tz: "Mars/Olympus", wantErr: "unknown timezone"},
+	}
+	for _, tt := range tests {
+		err := Check(tt.expr, tt.tz)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("Check(%q, %q) = %v, want nil", tt.expr, tt.tz, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("Check(%q, %q) = %v, want %q", tt.expr, tt.tz, err, tt.wantErr)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	at := func(s string) time.Time {
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	tests := []struct {
+		expr, tz string
+		after    string
+		want     string
+		wantErr  bool
+	}{
+		{expr: "*/15 * * * *", after: "2026-01-10T10:07:30Z", want: "2026-01-10T10:15:00Z"},
+		{expr: "*/15 * * * *", after: "2026-01-10T10:15:00Z", want: "2026-01-10T10:30:00Z"},
+		{expr: "@daily", after: "2026-12-31T23:59:00Z", want: "2027-01-01T00:00:00Z"},
+		// 09:00 in Berlin is 08:00 UTC in winter and 07:00 UTC once
+		// daylight saving starts on 29 March 2026.
+		{expr: "0 9 * * *", tz: "Europe/Berlin", after: "2026-03-27T12:00:00Z", want: "2026-03-28T08:00:00Z"},
+		{expr: "0 9 * * *", tz: "Europe/Berlin", after: "2026-03-28T12:00:00Z", want: "2026-03-29T07:00:00Z"},
+		{expr: "0 0 30 2 *", after: "2026-01-01T00:00:00Z", wantErr: true},
+		{expr: "bogus", after: "2026-01-01T00:00:00Z", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Next(tt.expr, tt.tz, at(tt.after))
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Next(%q, %q, %s) = %v, want an error", tt.expr, tt.tz, tt.after, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Next(%q, %q, %s): %v", tt.expr, tt.tz, tt.after, err)
+			continue
+		}
+		if want := at(tt.want); !got.Equal(want) {
+			t.Errorf("Next(%q, %q, %s) = %v, want %v", tt.expr, tt.tz, tt.after, got.UTC(), want)
+		}
+	}
+}
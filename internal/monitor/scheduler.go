@@ -0,0 +1,157 @@
+package monitor
+
+import (
+	"collectionsservice/internal/models"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// dueBatch caps how many due monitors one tick picks up.
+	dueBatch = 50
+	// maxConcurrentRuns caps how many monitors run at once.
+	maxConcurrentRuns = 4
+)
+
+// Summary is the outcome of one scheduled collection run.
+type Summary struct {
+	// Error is set when the collection could not be run at all.
+	Error            string
+	CollectionRunID  string
+	Requests         int
+	RequestsFailed   int
+	TestsFailed      int
+	AssertionsFailed int
+}
+
+// Failed reports whether the run, a request, a test or an assertion failed.
+func (s *Summary) Failed() bool {
+	return s.Error != "" || s.RequestsFailed > 0 || s.TestsFailed > 0 || s.AssertionsFailed > 0
+}
+
+type Store interface {
+	DueMonitors(ctx context.Context, now time.Time, limit int) ([]*models.Monitor, error)
+	ClaimMonitor(ctx context.Context, id string, due, next time.Time) (bool, error)
+	FinishMonitorRun(ctx context.Context, id string, status models.RunStatus, collectionRunID string, at time.Time) error
+}
+
+// Runner runs a monitor's collection and records it in run history.
+type Runner interface {
+	RunMonitor(ctx context.Context, m *models.Monitor) (*Summary, error)
+}
+
+type Scheduler struct {
+	store    Store
+	runner   Runner
+	notifier Notifier
+	leader   *Leader
+	interval time.Duration
+
+	slots chan struct{}
+	wg    sync.WaitGroup
+}
+
+func NewScheduler(store Store, runner Runner, notifier Notifier, leader *Leader, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		store:    store,
+		runner:   runner,
+		notifier: notifier,
+		leader:   leader,
+		interval: interval,
+		slots:    make(chan struct{}, maxConcurrentRuns),
+	}
+}
+
+// Start checks for due monitors every interval until ctx is done, then waits
+// for started runs and releases leadership. Only the replica holding the
+// advisory lock starts runs; missed runs are not caught up, a monitor simply
+// runs once and moves on to its next scheduled time.
+func (s *Scheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	defer s.leader.Release()
+	defer s.wg.Wait()
+
+	for {
+		s.tick(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context) {
+	if !s.leader.Acquire(ctx) {
+		return
+	}
+
+	now := time.Now()
+	due, err := s.store.DueMonitors(ctx, now, dueBatch)
+	if err != nil {
+		return
+	}
+	for _, m := range due {
+		next, err := Next(m.Cron, m.Timezone, now)
+		if err != nil {
+			log.Error().Err(err).Str("monitor_id", m.ID).Msg("Monitor has an invalid schedule")
+			continue
+		}
+		claimed, err := s.store.ClaimMonitor(ctx, m.ID, *m.NextRunAt, next)
+		if err != nil || !claimed {
+			continue
+		}
+
+		select {
+		case s.slots <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		s.wg.Add(1)
+		go func(m *models.Monitor) {
+			defer s.wg.Done()
+			defer func() { <-s.slots }()
+			s.run(ctx, m)
+		}(m)
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, m *models.Monitor) {
+	started := time.Now()
+	summary, err := s.runner.RunMonitor(ctx, m)
+	if err != nil {
+		log.Error().Err(err).Str("monitor_id", m.ID).Msg("Monitor run failed")
+		summary = &Summary{Error: err.Error()}
+	}
+
+	status := models.RunStatusPassed
+	if summary.Failed() {
+		status = models.RunStatusFailed
+	}
+	log.Info().Str("monitor_id", m.ID).Str("status", string(status)).
+		Dur("took", time.Since(started)).Msg("Monitor run finished")
+
+	// The result is saved even when shutdown cancelled the run.
+	saveCtx := context.WithoutCancel(ctx)
+	if err := s.store.FinishMonitorRun(saveCtx, m.ID, status, summary.CollectionRunID, started); err != nil {
+		return
+	}
+
+	var event Event
+	switch {
+	case status == models.RunStatusFailed && m.LastStatus != models.RunStatusFailed:
+		event = EventFailed
+	case status == models.RunStatusPassed && m.LastStatus == models.RunStatusFailed:
+		event = EventRecovered
+	default:
+		return
+	}
+	log.Warn().Str("monitor_id", m.ID).Str("event", string(event)).Msg("Monitor status changed")
+	if err := s.notifier.Notify(saveCtx, Notification{Event: event, Monitor: m, Summary: summary}); err != nil {
+		log.Warn().Err(err).Str("monitor_id", m.ID).Msg("Failed to send monitor notification")
+	}
+}
@@ -0,0 +1,115 @@
+// Package outbox relays the domain events the repository records alongside
+// each change to pluggable sinks. Events are delivered at least once, in
+// the order they were recorded.
+package outbox
+
+import (
+	"collectionsservice/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// batchSize caps how many events one relay pass publishes.
+const batchSize = 100
+
+// Event is what sinks receive. ID is stable across redeliveries, so
+// consumers can drop duplicates.
+type Event struct {
+	ID           string          `json:"id"`
+	Seq          int64           `json:"seq"`
+	Type         string          `json:"event"`
+	WorkspaceID  string          `json:"workspace_id"`
+	CollectionID string          `json:"collection_id"`
+	Actor        string          `json:"actor,omitempty"`
+	Data         json.RawMessage `json:"data,omitempty"`
+	OccurredAt   time.Time       `json:"occurred_at"`
+}
+
+// Sink publishes events to one destination. Publish must only return nil
+// once the destination has accepted the event.
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, e Event) error
+}
+
+type Store interface {
+	RelayOutbox(ctx context.Context, limit int, publish func(*models.OutboxEvent) error) (int, error)
+	PurgeOutbox(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Relay publishes every outbox event to all of its sinks. An event that a
+// sink rejects is retried, with every sink, until all accept it; later
+// events wait behind it.
+type Relay struct {
+	store     Store
+	sinks     []Sink
+	interval  time.Duration
+	retention time.Duration
+}
+
+// NewRelay returns a Relay that checks for events every interval and keeps
+// published events for retention.
+func NewRelay(store Store, sinks []Sink, interval, retention time.Duration) *Relay {
+	return &Relay{store: store, sinks: sinks, interval: interval, retention: retention}
+}
+
+// Start relays events until ctx is done.
+func (r *Relay) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	var purged time.Time
+	for {
+		// A full batch means there may be more waiting.
+		for r.tick(ctx) == batchSize {
+			if ctx.Err() != nil {
+				return
+			}
+		}
+		if time.Since(purged) > time.Hour {
+			if _, err := r.store.PurgeOutbox(ctx, time.Now().Add(-r.retention)); err == nil {
+				purged = time.Now()
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relay) tick(ctx context.Context) int {
+	n, err := r.store.RelayOutbox(ctx, batchSize, func(e *models.OutboxEvent) error {
+		return r.publish(ctx, e)
+	})
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+func (r *Relay) publish(ctx context.Context, row *models.OutboxEvent) error {
+	e := Event{
+		ID:           row.ID,
+		Seq:          row.Seq,
+		Type:         row.Event,
+		WorkspaceID:  row.WorkspaceID,
+		CollectionID: row.CollectionID,
+		Actor:        row.Actor,
+		Data:         json.RawMessage(row.Data),
+		OccurredAt:   row.CreatedAt,
+	}
+	for _, sink := range r.sinks {
+		if err := sink.Publish(ctx, e); err != nil {
+			log.Warn().Err(err).Str("sink", sink.Name()).Str("event_id", e.ID).Int64("seq", e.Seq).
+				Int("attempts", row.Attempts+1).Msg("Failed to publish outbox event")
+			return fmt.Errorf("%s: %w", sink.Name(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package outbox
+
+import (
+	"collectionsservice/internal/models"
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fakeStore relays its events the way the repository does: in sequence
+// order, stopping at the first one publish rejects.
+type fakeStore struct {
+	events []*models.OutboxEvent
+}
+
+func (s *fakeStore) RelayOutbox(_ context.Context, limit int, publish func(*models.OutboxEvent) error) (int, error) {
+	published := 0
+	for _, e := range s.events {
+		if published == limit {
+			break
+		}
+		if e.PublishedAt != nil {
+			continue
+		}
+		if err := publish(e); err != nil {
+			msg := err.Error()
+			e.Attempts++
+			e.LastError = &msg
+			return published, nil
+		}
+		now := time.Now()
+		e.PublishedAt = &now
+		published++
+	}
+	return published, nil
+}
+
+func (s *fakeStore) PurgeOutbox(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+// recordingSink logs every event it is offered and rejects the ones listed
+// in fail until they have been offered that many times.
+type recordingSink struct {
+	name string
+	fail map[int64]int
+	seen []int64
+}
+
+func (s *recordingSink) Name() string { return s.name }
+
+func (s *recordingSink) Publish(_ context.Context, e Event) error {
+	s.seen = append(s.seen, e.Seq)
+	if s.fail[e.Seq] > 0 {
+		s.fail[e.Seq]--
+		return errors.New("unavailable")
+	}
+	return nil
+}
+
+func TestRelay(t *testing.T) {
+	tests := []struct {
+		name       string
+		firstFail  map[int64]int
+		secondFail map[int64]int
+
+		wantTicks   []int
+		wantFirst   []int64
+		wantSecond  []int64
+		wantErrorAt map[int64]string
+	}{
+		{
+			name:       "every sink accepts",
+			wantTicks:  []int{3, 0},
+			wantFirst:  []int64{1, 2, 3},
+			wantSecond: []int64{1, 2, 3},
+		},
+		{
+			name:        "failure holds back later events",
+			firstFail:   map[int64]int{2: 2},
+			wantTicks:   []int{1, 0, 2},
+			wantFirst:   []int64{1, 2, 2, 2, 3},
+			wantSecond:  []int64{1, 2, 3},
+			wantErrorAt: map[int64]string{2: "first: unavailable"},
+		},
+		{
+			name:        "event is redelivered to sinks that accepted it",
+			secondFail:  map[int64]int{1: 1},
+			wantTicks:   []int{0, 3},
+			wantFirst:   []int64{1, 1, 2, 3},
+			wantSecond:  []int64{1, 1, 2, 3},
+			wantErrorAt: map[int64]string{1: "second: unavailable"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeStore{}
+			for seq := int64(1); seq <= 3; seq++ {
+				store.events = append(store.events, &models.OutboxEvent{Seq: seq, ID: "e" + strconv.FormatInt(seq, 10), Event: "request.added"})
+			}
+			first := &recordingSink{name: "first", fail: tt.firstFail}
+			second := &recordingSink{name: "second", fail: tt.secondFail}
+			relay := NewRelay(store, []Sink{first, second}, time.Minute, time.Hour)
+
+			var ticks []int
+			for range tt.wantTicks {
+				ticks = append(ticks, relay.tick(context.Background()))
+			}
+
+			if !reflect.DeepEqual(ticks, tt.wantTicks) {
+				t.Errorf("published per tick %v, want %v", ticks, tt.wantTicks)
+			}
+			if !reflect.DeepEqual(first.seen, tt.wantFirst) {
+				t.Errorf("first sink saw %v, want %v", first.seen, tt.wantFirst)
+			}
+			if !reflect.DeepEqual(second.seen, tt.wantSecond) {
+				t.Errorf("second sink saw %v, want %v", second.seen, tt.wantSecond)
+			}
+			for _, e := range store.events {
+				if e.PublishedAt == nil {
+					t.Errorf("event %d not published", e.Seq)
+				}
+				want, failed := tt.wantErrorAt[e.Seq]
+				switch {
+				case !failed && e.LastError != nil:
+					t.Errorf("event %d has error %q", e.Seq, *e.LastError)
+				case failed && (e.LastError == nil || *e.LastError != want):
+					t.Errorf("event %d error %v, want %q", e.Seq, e.LastError, want)
+				}
+			}
+		})
+	}
+}
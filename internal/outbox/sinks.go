@@ -0,0 +1,109 @@
+package outbox
+
+import (
+	"collectionsservice/internal/webhook"
+	"context"
+	"encoding/json"
+
+	"github.com/rs/zerolog/log"
+)
+
+// LogSink writes every event to the service log.
+type LogSink struct{}
+
+func (LogSink) Name() string { return "log" }
+
+func (LogSink) Publish(ctx context.Context, e Event) error {
+	log.Info().Str("event_id", e.ID).Int64("seq", e.Seq).Str("event", e.Type).
+		Str("collection_id", e.CollectionID).Str("actor", e.Actor).RawJSON("data", e.Data).Msg("Domain event")
+	return nil
+}
+
+// WebhookSink queues deliveries of webhook events to the subscribed
+// webhooks. Events webhooks cannot subscribe to are skipped.
+type WebhookSink struct {
+	publisher *webhook.Publisher
+}
+
+func NewWebhookSink(publisher *webhook.Publisher) *WebhookSink {
+	return &WebhookSink{publisher: publisher}
+}
+
+func (s *WebhookSink) Name() string { return "webhook" }
+
+func (s *WebhookSink) Publish(ctx context.Context, e Event) error {
+	event := webhook.Event(e.Type)
+	if !webhook.Known(event) {
+		return nil
+	}
+	var data map[string]interface{}
+	if len(e.Data) > 0 {
+		if err := json.Unmarshal(e.Data, &data); err != nil {
+			return err
+		}
+	}
+	return s.publisher.Publish(ctx, webhook.Message{
+		Event:        event,
+		WorkspaceID:  e.WorkspaceID,
+		CollectionID: e.CollectionID,
+		Actor:        e.Actor,
+		Data:         data,
+		ID:           e.ID,
+		OccurredAt:   e.OccurredAt,
+	})
+}
+
+// NATSPublisher sends a message and returns once the server has stored it,
+// as JetStream publishing does. Core NATS publishing does not wait, so it
+// gives no delivery guarantee.
+type NATSPublisher interface {
+	Publish(ctx context.Context, subject string, data []byte) error
+}
+
+// NATSSink publishes each event as JSON to "<prefix>.<event>", for example
+// "collections.request.added".
+type NATSSink struct {
+	publisher NATSPublisher
+	prefix    string
+}
+
+func NewNATSSink(publisher NATSPublisher, prefix string) *NATSSink {
+	return &NATSSink{publisher: publisher, prefix: prefix}
+}
+
+func (s *NATSSink) Name() string { return "nats" }
+
+func (s *NATSSink) Publish(ctx context.Context, e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return s.publisher.Publish(ctx, s.prefix+"."+e.Type, body)
+}
+
+// KafkaProducer writes a message and returns once the brokers have
+// acknowledged it.
+type KafkaProducer interface {
+	Produce(ctx context.Context, topic string, key, value []byte) error
+}
+
+// KafkaSink publishes each event as JSON to one topic, keyed by collection
+// so a collection's events stay in order within a partition.
+type KafkaSink struct {
+	producer KafkaProducer
+	topic    string
+}
+
+func NewKafkaSink(producer KafkaProducer, topic string) *KafkaSink {
+	return &KafkaSink{producer: producer, topic: topic}
+}
+
+func (s *KafkaSink) Name() string { return "kafka" }
+
+func (s *KafkaSink) Publish(ctx context.Context, e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return s.producer.Produce(ctx, s.topic, []byte(e.CollectionID), body)
+}
@@ -19,13 +19,58 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CollectionService_CreateCollection_FullMethodName            = "/collections.CollectionService/CreateCollection"
-	CollectionService_AddRequestToCollection_FullMethodName      = "/collections.CollectionService/AddRequestToCollection"
-	CollectionService_ListCollectionsAndRequests_FullMethodName  = "/collections.CollectionService/ListCollectionsAndRequests"
-	CollectionService_UpdateCollection_FullMethodName            = "/collections.CollectionService/UpdateCollection"
-	CollectionService_UpdateRequestInCollection_FullMethodName   = "/collections.CollectionService/UpdateRequestInCollection"
-	CollectionService_DeleteRequestFromCollection_FullMethodName = "/collections.CollectionService/DeleteRequestFromCollection"
-	CollectionService_DeleteCollection_FullMethodName            = "/collections.CollectionService/DeleteCollection"
+	CollectionService_CreateCollection_FullMethodName                  = "/collections.CollectionService/CreateCollection"
+	CollectionService_AddRequestToCollection_FullMethodName            = "/collections.CollectionService/AddRequestToCollection"
+	CollectionService_AddRequestToCollectionByID_FullMethodName        = "/collections.CollectionService/AddRequestToCollectionByID"
+	CollectionService_ListCollectionsAndRequests_FullMethodName        = "/collections.CollectionService/ListCollectionsAndRequests"
+	CollectionService_UpdateCollection_FullMethodName                  = "/collections.CollectionService/UpdateCollection"
+	CollectionService_UpdateRequestInCollection_FullMethodName         = "/collections.CollectionService/UpdateRequestInCollection"
+	CollectionService_DeleteRequestFromCollection_FullMethodName       = "/collections.CollectionService/DeleteRequestFromCollection"
+	CollectionService_DeleteCollection_FullMethodName                  = "/collections.CollectionService/DeleteCollection"
+	CollectionService_BatchAddRequestsToCollection_FullMethodName      = "/collections.CollectionService/BatchAddRequestsToCollection"
+	CollectionService_BatchUpdateRequestsInCollection_FullMethodName   = "/collections.CollectionService/BatchUpdateRequestsInCollection"
+	CollectionService_BatchDeleteRequestsFromCollection_FullMethodName = "/collections.CollectionService/BatchDeleteRequestsFromCollection"
+	CollectionService_PreviewMergeCollections_FullMethodName           = "/collections.CollectionService/PreviewMergeCollections"
+	CollectionService_MergeCollections_FullMethodName                  = "/collections.CollectionService/MergeCollections"
+	CollectionService_ForkCollection_FullMethodName                    = "/collections.CollectionService/ForkCollection"
+	CollectionService_SetCollectionProtected_FullMethodName            = "/collections.CollectionService/SetCollectionProtected"
+	CollectionService_OpenChangeProposal_FullMethodName                = "/collections.CollectionService/OpenChangeProposal"
+	CollectionService_GetChangeProposal_FullMethodName                 = "/collections.CollectionService/GetChangeProposal"
+	CollectionService_ListChangeProposals_FullMethodName               = "/collections.CollectionService/ListChangeProposals"
+	CollectionService_CommentOnChangeProposal_FullMethodName           = "/collections.CollectionService/CommentOnChangeProposal"
+	CollectionService_ReviewChangeProposal_FullMethodName              = "/collections.CollectionService/ReviewChangeProposal"
+	CollectionService_GrantCollectionRole_FullMethodName               = "/collections.CollectionService/GrantCollectionRole"
+	CollectionService_RevokeCollectionRole_FullMethodName              = "/collections.CollectionService/RevokeCollectionRole"
+	CollectionService_ListCollectionMembers_FullMethodName             = "/collections.CollectionService/ListCollectionMembers"
+	CollectionService_ShareCollection_FullMethodName                   = "/collections.CollectionService/ShareCollection"
+	CollectionService_ListCollectionShares_FullMethodName              = "/collections.CollectionService/ListCollectionShares"
+	CollectionService_RevokeCollectionShare_FullMethodName             = "/collections.CollectionService/RevokeCollectionShare"
+	CollectionService_GetSharedCollection_FullMethodName               = "/collections.CollectionService/GetSharedCollection"
+	CollectionService_ReencryptSecrets_FullMethodName                  = "/collections.CollectionService/ReencryptSecrets"
+	CollectionService_ExecuteRequest_FullMethodName                    = "/collections.CollectionService/ExecuteRequest"
+	CollectionService_RunCollection_FullMethodName                     = "/collections.CollectionService/RunCollection"
+	CollectionService_CreateExample_FullMethodName                     = "/collections.CollectionService/CreateExample"
+	CollectionService_GetExample_FullMethodName                        = "/collections.CollectionService/GetExample"
+	CollectionService_ListExamples_FullMethodName                      = "/collections.CollectionService/ListExamples"
+	CollectionService_UpdateExample_FullMethodName                     = "/collections.CollectionService/UpdateExample"
+	CollectionService_DeleteExample_FullMethodName                     = "/collections.CollectionService/DeleteExample"
+	CollectionService_ListRunHistory_FullMethodName                    = "/collections.CollectionService/ListRunHistory"
+	CollectionService_GetRun_FullMethodName                            = "/collections.CollectionService/GetRun"
+	CollectionService_CreateMonitor_FullMethodName                     = "/collections.CollectionService/CreateMonitor"
+	CollectionService_GetMonitor_FullMethodName                        = "/collections.CollectionService/GetMonitor"
+	CollectionService_ListMonitors_FullMethodName                      = "/collections.CollectionService/ListMonitors"
+	CollectionService_UpdateMonitor_FullMethodName                     = "/collections.CollectionService/UpdateMonitor"
+	CollectionService_SetMonitorEnabled_FullMethodName                 = "/collections.CollectionService/SetMonitorEnabled"
+	CollectionService_DeleteMonitor_FullMethodName                     = "/collections.CollectionService/DeleteMonitor"
+	CollectionService_CreateWebhook_FullMethodName                     = "/collections.CollectionService/CreateWebhook"
+	CollectionService_ListWebhooks_FullMethodName                      = "/collections.CollectionService/ListWebhooks"
+	CollectionService_UpdateWebhook_FullMethodName                     = "/collections.CollectionService/UpdateWebhook"
+	CollectionService_SetWebhookEnabled_FullMethodName                 = "/collections.CollectionService/SetWebhookEnabled"
+	CollectionService_DeleteWebhook_FullMethodName                     = "/collections.CollectionService/DeleteWebhook"
+	CollectionService_ListWebhookDeliveries_FullMethodName             = "/collections.CollectionService/ListWebhookDeliveries"
+	CollectionService_WatchCollections_FullMethodName                  = "/collections.CollectionService/WatchCollections"
+	CollectionService_ListAuditEvents_FullMethodName                   = "/collections.CollectionService/ListAuditEvents"
+	CollectionService_ExportAuditEvents_FullMethodName                 = "/collections.CollectionService/ExportAuditEvents"
 )
 
 // CollectionServiceClient is the client API for CollectionService service.
@@ -33,12 +78,68 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CollectionServiceClient interface {
 	CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CreateCollectionResponse, error)
+	// Deprecated: Do not use.
+	// Deprecated: collections are addressed by name, which a rename can
+	// redirect. Use AddRequestToCollectionByID.
 	AddRequestToCollection(ctx context.Context, in *AddRequestToCollectionRequest, opts ...grpc.CallOption) (*CollectionResponse, error)
+	AddRequestToCollectionByID(ctx context.Context, in *AddRequestToCollectionByIDRequest, opts ...grpc.CallOption) (*CollectionResponse, error)
 	ListCollectionsAndRequests(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error)
 	UpdateCollection(ctx context.Context, in *UpdateCollectionRequest, opts ...grpc.CallOption) (*CollectionResponse, error)
 	UpdateRequestInCollection(ctx context.Context, in *UpdateRequestInCollectionRequest, opts ...grpc.CallOption) (*UpdateRequestInCollectionResponse, error)
 	DeleteRequestFromCollection(ctx context.Context, in *DeleteRequestFromCollectionRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
 	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	BatchAddRequestsToCollection(ctx context.Context, in *BatchAddRequestsToCollectionRequest, opts ...grpc.CallOption) (*BatchRequestsResponse, error)
+	BatchUpdateRequestsInCollection(ctx context.Context, in *BatchUpdateRequestsInCollectionRequest, opts ...grpc.CallOption) (*BatchRequestsResponse, error)
+	BatchDeleteRequestsFromCollection(ctx context.Context, in *BatchDeleteRequestsFromCollectionRequest, opts ...grpc.CallOption) (*BatchRequestsResponse, error)
+	PreviewMergeCollections(ctx context.Context, in *PreviewMergeCollectionsRequest, opts ...grpc.CallOption) (*PreviewMergeCollectionsResponse, error)
+	MergeCollections(ctx context.Context, in *MergeCollectionsRequest, opts ...grpc.CallOption) (*MergeCollectionsResponse, error)
+	ForkCollection(ctx context.Context, in *ForkCollectionRequest, opts ...grpc.CallOption) (*CollectionResponse, error)
+	SetCollectionProtected(ctx context.Context, in *SetCollectionProtectedRequest, opts ...grpc.CallOption) (*CollectionResponse, error)
+	OpenChangeProposal(ctx context.Context, in *OpenChangeProposalRequest, opts ...grpc.CallOption) (*ChangeProposalResponse, error)
+	GetChangeProposal(ctx context.Context, in *GetChangeProposalRequest, opts ...grpc.CallOption) (*ChangeProposalResponse, error)
+	ListChangeProposals(ctx context.Context, in *ListChangeProposalsRequest, opts ...grpc.CallOption) (*ListChangeProposalsResponse, error)
+	CommentOnChangeProposal(ctx context.Context, in *CommentOnChangeProposalRequest, opts ...grpc.CallOption) (*ChangeProposalResponse, error)
+	ReviewChangeProposal(ctx context.Context, in *ReviewChangeProposalRequest, opts ...grpc.CallOption) (*ChangeProposalResponse, error)
+	// A collection role overrides the caller's workspace role on that collection.
+	GrantCollectionRole(ctx context.Context, in *GrantCollectionRoleRequest, opts ...grpc.CallOption) (*Member, error)
+	RevokeCollectionRole(ctx context.Context, in *RevokeCollectionRoleRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	ListCollectionMembers(ctx context.Context, in *ListCollectionMembersRequest, opts ...grpc.CallOption) (*ListMembersResponse, error)
+	ShareCollection(ctx context.Context, in *ShareCollectionRequest, opts ...grpc.CallOption) (*ShareCollectionResponse, error)
+	ListCollectionShares(ctx context.Context, in *ListCollectionSharesRequest, opts ...grpc.CallOption) (*ListCollectionSharesResponse, error)
+	RevokeCollectionShare(ctx context.Context, in *RevokeCollectionShareRequest, opts ...grpc.CallOption) (*ShareInfo, error)
+	// Resolves a share token. It needs no credentials: the token is the grant.
+	GetSharedCollection(ctx context.Context, in *GetSharedCollectionRequest, opts ...grpc.CallOption) (*SharedCollectionResponse, error)
+	// Re-seals every stored secret with the primary key after a key rotation.
+	// Admin only.
+	ReencryptSecrets(ctx context.Context, in *ReencryptSecretsRequest, opts ...grpc.CallOption) (*ReencryptSecretsResponse, error)
+	ExecuteRequest(ctx context.Context, in *ExecuteRequestRequest, opts ...grpc.CallOption) (*ExecuteRequestResponse, error)
+	RunCollection(ctx context.Context, in *RunCollectionRequest, opts ...grpc.CallOption) (*RunCollectionResponse, error)
+	CreateExample(ctx context.Context, in *CreateExampleRequest, opts ...grpc.CallOption) (*ExampleResponse, error)
+	GetExample(ctx context.Context, in *GetExampleRequest, opts ...grpc.CallOption) (*ExampleResponse, error)
+	ListExamples(ctx context.Context, in *ListExamplesRequest, opts ...grpc.CallOption) (*ListExamplesResponse, error)
+	UpdateExample(ctx context.Context, in *UpdateExampleRequest, opts ...grpc.CallOption) (*ExampleResponse, error)
+	DeleteExample(ctx context.Context, in *DeleteExampleRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	ListRunHistory(ctx context.Context, in *ListRunHistoryRequest, opts ...grpc.CallOption) (*ListRunHistoryResponse, error)
+	GetRun(ctx context.Context, in *GetRunRequest, opts ...grpc.CallOption) (*RunRecord, error)
+	CreateMonitor(ctx context.Context, in *CreateMonitorRequest, opts ...grpc.CallOption) (*MonitorResponse, error)
+	GetMonitor(ctx context.Context, in *GetMonitorRequest, opts ...grpc.CallOption) (*MonitorResponse, error)
+	ListMonitors(ctx context.Context, in *ListMonitorsRequest, opts ...grpc.CallOption) (*ListMonitorsResponse, error)
+	UpdateMonitor(ctx context.Context, in *UpdateMonitorRequest, opts ...grpc.CallOption) (*MonitorResponse, error)
+	SetMonitorEnabled(ctx context.Context, in *SetMonitorEnabledRequest, opts ...grpc.CallOption) (*MonitorResponse, error)
+	DeleteMonitor(ctx context.Context, in *DeleteMonitorRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*WebhookResponse, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*WebhookResponse, error)
+	SetWebhookEnabled(ctx context.Context, in *SetWebhookEnabledRequest, opts ...grpc.CallOption) (*WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	// Streams changes to collections and their requests as they are made.
+	WatchCollections(ctx context.Context, in *WatchCollectionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CollectionChange], error)
+	// Reads the audit log of collection and request changes. Owner only.
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	// Streams every matching audit event, newest first, as JSON lines or CSV.
+	// Owner only.
+	ExportAuditEvents(ctx context.Context, in *ExportAuditEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuditExportChunk], error)
 }
 
 type collectionServiceClient struct {
@@ -59,6 +160,7 @@ func (c *collectionServiceClient) CreateCollection(ctx context.Context, in *Crea
 	return out, nil
 }
 
+// Deprecated: Do not use.
 func (c *collectionServiceClient) AddRequestToCollection(ctx context.Context, in *AddRequestToCollectionRequest, opts ...grpc.CallOption) (*CollectionResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CollectionResponse)
@@ -69,279 +171,2475 @@ func (c *collectionServiceClient) AddRequestToCollection(ctx context.Context, in
 	return out, nil
 }
 
+func (c *collectionServiceClient) AddRequestToCollectionByID(ctx context.Context, in *AddRequestToCollectionByIDRequest, opts ...grpc.CallOption) (*CollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionResponse)
+	err := c.cc.Invoke(ctx, CollectionService_AddRequestToCollectionByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *collectionServiceClient) ListCollectionsAndRequests(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ListCollectionsResponse)
-	err := c.cc.Invoke(ctx, CollectionService_ListCollectionsAndRequests_FullMethodName, in, out, cOpts...)
+	out := new(ListCollectionsResponse)
+	err := c.cc.Invoke(ctx, CollectionService_ListCollectionsAndRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) UpdateCollection(ctx context.Context, in *UpdateCollectionRequest, opts ...grpc.CallOption) (*CollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionResponse)
+	err := c.cc.Invoke(ctx, CollectionService_UpdateCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) UpdateRequestInCollection(ctx context.Context, in *UpdateRequestInCollectionRequest, opts ...grpc.CallOption) (*UpdateRequestInCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateRequestInCollectionResponse)
+	err := c.cc.Invoke(ctx, CollectionService_UpdateRequestInCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) DeleteRequestFromCollection(ctx context.Context, in *DeleteRequestFromCollectionRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, CollectionService_DeleteRequestFromCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, CollectionService_DeleteCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) BatchAddRequestsToCollection(ctx context.Context, in *BatchAddRequestsToCollectionRequest, opts ...grpc.CallOption) (*BatchRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchRequestsResponse)
+	err := c.cc.Invoke(ctx, CollectionService_BatchAddRequestsToCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) BatchUpdateRequestsInCollection(ctx context.Context, in *BatchUpdateRequestsInCollectionRequest, opts ...grpc.CallOption) (*BatchRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchRequestsResponse)
+	err := c.cc.Invoke(ctx, CollectionService_BatchUpdateRequestsInCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) BatchDeleteRequestsFromCollection(ctx context.Context, in *BatchDeleteRequestsFromCollectionRequest, opts ...grpc.CallOption) (*BatchRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchRequestsResponse)
+	err := c.cc.Invoke(ctx, CollectionService_BatchDeleteRequestsFromCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) PreviewMergeCollections(ctx context.Context, in *PreviewMergeCollectionsRequest, opts ...grpc.CallOption) (*PreviewMergeCollectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewMergeCollectionsResponse)
+	err := c.cc.Invoke(ctx, CollectionService_PreviewMergeCollections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) MergeCollections(ctx context.Context, in *MergeCollectionsRequest, opts ...grpc.CallOption) (*MergeCollectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeCollectionsResponse)
+	err := c.cc.Invoke(ctx, CollectionService_MergeCollections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) ForkCollection(ctx context.Context, in *ForkCollectionRequest, opts ...grpc.CallOption) (*CollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionResponse)
+	err := c.cc.Invoke(ctx, CollectionService_ForkCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) SetCollectionProtected(ctx context.Context, in *SetCollectionProtectedRequest, opts ...grpc.CallOption) (*CollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionResponse)
+	err := c.cc.Invoke(ctx, CollectionService_SetCollectionProtected_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) OpenChangeProposal(ctx context.Context, in *OpenChangeProposalRequest, opts ...grpc.CallOption) (*ChangeProposalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeProposalResponse)
+	err := c.cc.Invoke(ctx, CollectionService_OpenChangeProposal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) GetChangeProposal(ctx context.Context, in *GetChangeProposalRequest, opts ...grpc.CallOption) (*ChangeProposalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeProposalResponse)
+	err := c.cc.Invoke(ctx, CollectionService_GetChangeProposal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) ListChangeProposals(ctx context.Context, in *ListChangeProposalsRequest, opts ...grpc.CallOption) (*ListChangeProposalsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListChangeProposalsResponse)
+	err := c.cc.Invoke(ctx, CollectionService_ListChangeProposals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) CommentOnChangeProposal(ctx context.Context, in *CommentOnChangeProposalRequest, opts ...grpc.CallOption) (*ChangeProposalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeProposalResponse)
+	err := c.cc.Invoke(ctx, CollectionService_CommentOnChangeProposal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) ReviewChangeProposal(ctx context.Context, in *ReviewChangeProposalRequest, opts ...grpc.CallOption) (*ChangeProposalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeProposalResponse)
+	err := c.cc.Invoke(ctx, CollectionService_ReviewChangeProposal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) GrantCollectionRole(ctx context.Context, in *GrantCollectionRoleRequest, opts ...grpc.CallOption) (*Member, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Member)
+	err := c.cc.Invoke(ctx, CollectionService_GrantCollectionRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) RevokeCollectionRole(ctx context.Context, in *RevokeCollectionRoleRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, CollectionService_RevokeCollectionRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) ListCollectionMembers(ctx context.Context, in *ListCollectionMembersRequest, opts ...grpc.CallOption) (*ListMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMembersResponse)
+	err := c.cc.Invoke(ctx, CollectionService_ListCollectionMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) ShareCollection(ctx context.Context, in *ShareCollectionRequest, opts ...grpc.CallOption) (*ShareCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareCollectionResponse)
+	err := c.cc.Invoke(ctx, CollectionService_ShareCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) ListCollectionShares(ctx context.Context, in *ListCollectionSharesRequest, opts ...grpc.CallOption) (*ListCollectionSharesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectionSharesResponse)
+	err := c.cc.Invoke(ctx, CollectionService_ListCollectionShares_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) RevokeCollectionShare(ctx context.Context, in *RevokeCollectionShareRequest, opts ...grpc.CallOption) (*ShareInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareInfo)
+	err := c.cc.Invoke(ctx, CollectionService_RevokeCollectionShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) GetSharedCollection(ctx context.Context, in *GetSharedCollectionRequest, opts ...grpc.CallOption) (*SharedCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SharedCollectionResponse)
+	err := c.cc.Invoke(ctx, CollectionService_GetSharedCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) ReencryptSecrets(ctx context.Context, in *ReencryptSecretsRequest, opts ...grpc.CallOption) (*ReencryptSecretsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReencryptSecretsResponse)
+	err := c.cc.Invoke(ctx, CollectionService_ReencryptSecrets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) ExecuteRequest(ctx context.Context, in *ExecuteRequestRequest, opts ...grpc.CallOption) (*ExecuteRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExecuteRequestResponse)
+	err := c.cc.Invoke(ctx, CollectionService_ExecuteRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) RunCollection(ctx context.Context, in *RunCollectionRequest, opts ...grpc.CallOption) (*RunCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunCollectionResponse)
+	err := c.cc.Invoke(ctx, CollectionService_RunCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) CreateExample(ctx context.Context, in *CreateExampleRequest, opts ...grpc.CallOption) (*ExampleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExampleResponse)
+	err := c.cc.Invoke(ctx, CollectionService_CreateExample_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) GetExample(ctx context.Context, in *GetExampleRequest, opts ...grpc.CallOption) (*ExampleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExampleResponse)
+	err := c.cc.Invoke(ctx, CollectionService_GetExample_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) ListExamples(ctx context.Context, in *ListExamplesRequest, opts ...grpc.CallOption) (*ListExamplesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExamplesResponse)
+	err := c.cc.Invoke(ctx, CollectionService_ListExamples_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) UpdateExample(ctx context.Context, in *UpdateExampleRequest, opts ...grpc.CallOption) (*ExampleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExampleResponse)
+	err := c.cc.Invoke(ctx, CollectionService_UpdateExample_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) DeleteExample(ctx context.Context, in *DeleteExampleRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, CollectionService_DeleteExample_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) ListRunHistory(ctx context.Context, in *ListRunHistoryRequest, opts ...grpc.CallOption) (*ListRunHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRunHistoryResponse)
+	err := c.cc.Invoke(ctx, CollectionService_ListRunHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) GetRun(ctx context.Context, in *GetRunRequest, opts ...grpc.CallOption) (*RunRecord, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunRecord)
+	err := c.cc.Invoke(ctx, CollectionService_GetRun_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) CreateMonitor(ctx context.Context, in *CreateMonitorRequest, opts ...grpc.CallOption) (*MonitorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MonitorResponse)
+	err := c.cc.Invoke(ctx, CollectionService_CreateMonitor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) GetMonitor(ctx context.Context, in *GetMonitorRequest, opts ...grpc.CallOption) (*MonitorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MonitorResponse)
+	err := c.cc.Invoke(ctx, CollectionService_GetMonitor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) ListMonitors(ctx context.Context, in *ListMonitorsRequest, opts ...grpc.CallOption) (*ListMonitorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMonitorsResponse)
+	err := c.cc.Invoke(ctx, CollectionService_ListMonitors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) UpdateMonitor(ctx context.Context, in *UpdateMonitorRequest, opts ...grpc.CallOption) (*MonitorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MonitorResponse)
+	err := c.cc.Invoke(ctx, CollectionService_UpdateMonitor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) SetMonitorEnabled(ctx context.Context, in *SetMonitorEnabledRequest, opts ...grpc.CallOption) (*MonitorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MonitorResponse)
+	err := c.cc.Invoke(ctx, CollectionService_SetMonitorEnabled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) DeleteMonitor(ctx context.Context, in *DeleteMonitorRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, CollectionService_DeleteMonitor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*WebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookResponse)
+	err := c.cc.Invoke(ctx, CollectionService_CreateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, CollectionService_ListWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*WebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookResponse)
+	err := c.cc.Invoke(ctx, CollectionService_UpdateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) SetWebhookEnabled(ctx context.Context, in *SetWebhookEnabledRequest, opts ...grpc.CallOption) (*WebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookResponse)
+	err := c.cc.Invoke(ctx, CollectionService_SetWebhookEnabled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, CollectionService_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, CollectionService_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) WatchCollections(ctx context.Context, in *WatchCollectionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CollectionChange], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CollectionService_ServiceDesc.Streams[0], CollectionService_WatchCollections_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchCollectionsRequest, CollectionChange]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CollectionService_WatchCollectionsClient = grpc.ServerStreamingClient[CollectionChange]
+
+func (c *collectionServiceClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, CollectionService_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionServiceClient) ExportAuditEvents(ctx context.Context, in *ExportAuditEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuditExportChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CollectionService_ServiceDesc.Streams[1], CollectionService_ExportAuditEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportAuditEventsRequest, AuditExportChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CollectionService_ExportAuditEventsClient = grpc.ServerStreamingClient[AuditExportChunk]
+
+// CollectionServiceServer is the server API for CollectionService service.
+// All implementations must embed UnimplementedCollectionServiceServer
+// for forward compatibility.
+type CollectionServiceServer interface {
+	CreateCollection(context.Context, *CreateCollectionRequest) (*CreateCollectionResponse, error)
+	// Deprecated: Do not use.
+	// Deprecated: collections are addressed by name, which a rename can
+	// redirect. Use AddRequestToCollectionByID.
+	AddRequestToCollection(context.Context, *AddRequestToCollectionRequest) (*CollectionResponse, error)
+	AddRequestToCollectionByID(context.Context, *AddRequestToCollectionByIDRequest) (*CollectionResponse, error)
+	ListCollectionsAndRequests(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error)
+	UpdateCollection(context.Context, *UpdateCollectionRequest) (*CollectionResponse, error)
+	UpdateRequestInCollection(context.Context, *UpdateRequestInCollectionRequest) (*UpdateRequestInCollectionResponse, error)
+	DeleteRequestFromCollection(context.Context, *DeleteRequestFromCollectionRequest) (*DeleteResponse, error)
+	DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteResponse, error)
+	BatchAddRequestsToCollection(context.Context, *BatchAddRequestsToCollectionRequest) (*BatchRequestsResponse, error)
+	BatchUpdateRequestsInCollection(context.Context, *BatchUpdateRequestsInCollectionRequest) (*BatchRequestsResponse, error)
+	BatchDeleteRequestsFromCollection(context.Context, *BatchDeleteRequestsFromCollectionRequest) (*BatchRequestsResponse, error)
+	PreviewMergeCollections(context.Context, *PreviewMergeCollectionsRequest) (*PreviewMergeCollectionsResponse, error)
+	MergeCollections(context.Context, *MergeCollectionsRequest) (*MergeCollectionsResponse, error)
+	ForkCollection(context.Context, *ForkCollectionRequest) (*CollectionResponse, error)
+	SetCollectionProtected(context.Context, *SetCollectionProtectedRequest) (*CollectionResponse, error)
+	OpenChangeProposal(context.Context, *OpenChangeProposalRequest) (*ChangeProposalResponse, error)
+	GetChangeProposal(context.Context, *GetChangeProposalRequest) (*ChangeProposalResponse, error)
+	ListChangeProposals(context.Context, *ListChangeProposalsRequest) (*ListChangeProposalsResponse, error)
+	CommentOnChangeProposal(context.Context, *CommentOnChangeProposalRequest) (*ChangeProposalResponse, error)
+	ReviewChangeProposal(context.Context, *ReviewChangeProposalRequest) (*ChangeProposalResponse, error)
+	// A collection role overrides the caller's workspace role on that collection.
+	GrantCollectionRole(context.Context, *GrantCollectionRoleRequest) (*Member, error)
+	RevokeCollectionRole(context.Context, *RevokeCollectionRoleRequest) (*DeleteResponse, error)
+	ListCollectionMembers(context.Context, *ListCollectionMembersRequest) (*ListMembersResponse, error)
+	ShareCollection(context.Context, *ShareCollectionRequest) (*ShareCollectionResponse, error)
+	ListCollectionShares(context.Context, *ListCollectionSharesRequest) (*ListCollectionSharesResponse, error)
+	RevokeCollectionShare(context.Context, *RevokeCollectionShareRequest) (*ShareInfo, error)
+	// Resolves a share token. It needs no credentials: the token is the grant.
+	GetSharedCollection(context.Context, *GetSharedCollectionRequest) (*SharedCollectionResponse, error)
+	// Re-seals every stored secret with the primary key after a key rotation.
+	// Admin only.
+	ReencryptSecrets(context.Context, *ReencryptSecretsRequest) (*ReencryptSecretsResponse, error)
+	ExecuteRequest(context.Context, *ExecuteRequestRequest) (*ExecuteRequestResponse, error)
+	RunCollection(context.Context, *RunCollectionRequest) (*RunCollectionResponse, error)
+	CreateExample(context.Context, *CreateExampleRequest) (*ExampleResponse, error)
+	GetExample(context.Context, *GetExampleRequest) (*ExampleResponse, error)
+	ListExamples(context.Context, *ListExamplesRequest) (*ListExamplesResponse, error)
+	UpdateExample(context.Context, *UpdateExampleRequest) (*ExampleResponse, error)
+	DeleteExample(context.Context, *DeleteExampleRequest) (*DeleteResponse, error)
+	ListRunHistory(context.Context, *ListRunHistoryRequest) (*ListRunHistoryResponse, error)
+	GetRun(context.Context, *GetRunRequest) (*RunRecord, error)
+	CreateMonitor(context.Context, *CreateMonitorRequest) (*MonitorResponse, error)
+	GetMonitor(context.Context, *GetMonitorRequest) (*MonitorResponse, error)
+	ListMonitors(context.Context, *ListMonitorsRequest) (*ListMonitorsResponse, error)
+	UpdateMonitor(context.Context, *UpdateMonitorRequest) (*MonitorResponse, error)
+	SetMonitorEnabled(context.Context, *SetMonitorEnabledRequest) (*MonitorResponse, error)
+	DeleteMonitor(context.Context, *DeleteMonitorRequest) (*DeleteResponse, error)
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*WebhookResponse, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	UpdateWebhook(context.Context, *UpdateWebhookRequest) (*WebhookResponse, error)
+	SetWebhookEnabled(context.Context, *SetWebhookEnabledRequest) (*WebhookResponse, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteResponse, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	// Streams changes to collections and their requests as they are made.
+	WatchCollections(*WatchCollectionsRequest, grpc.ServerStreamingServer[CollectionChange]) error
+	// Reads the audit log of collection and request changes. Owner only.
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	// Streams every matching audit event, newest first, as JSON lines or CSV.
+	// Owner only.
+	ExportAuditEvents(*ExportAuditEventsRequest, grpc.ServerStreamingServer[AuditExportChunk]) error
+	mustEmbedUnimplementedCollectionServiceServer()
+}
+
+// UnimplementedCollectionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCollectionServiceServer struct{}
+
+func (UnimplementedCollectionServiceServer) CreateCollection(context.Context, *CreateCollectionRequest) (*CreateCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedCollectionServiceServer) AddRequestToCollection(context.Context, *AddRequestToCollectionRequest) (*CollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRequestToCollection not implemented")
+}
+func (UnimplementedCollectionServiceServer) AddRequestToCollectionByID(context.Context, *AddRequestToCollectionByIDRequest) (*CollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRequestToCollectionByID not implemented")
+}
+func (UnimplementedCollectionServiceServer) ListCollectionsAndRequests(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollectionsAndRequests not implemented")
+}
+func (UnimplementedCollectionServiceServer) UpdateCollection(context.Context, *UpdateCollectionRequest) (*CollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCollection not implemented")
+}
+func (UnimplementedCollectionServiceServer) UpdateRequestInCollection(context.Context, *UpdateRequestInCollectionRequest) (*UpdateRequestInCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRequestInCollection not implemented")
+}
+func (UnimplementedCollectionServiceServer) DeleteRequestFromCollection(context.Context, *DeleteRequestFromCollectionRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRequestFromCollection not implemented")
+}
+func (UnimplementedCollectionServiceServer) DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCollection not implemented")
+}
+func (UnimplementedCollectionServiceServer) BatchAddRequestsToCollection(context.Context, *BatchAddRequestsToCollectionRequest) (*BatchRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchAddRequestsToCollection not implemented")
+}
+func (UnimplementedCollectionServiceServer) BatchUpdateRequestsInCollection(context.Context, *BatchUpdateRequestsInCollectionRequest) (*BatchRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateRequestsInCollection not implemented")
+}
+func (UnimplementedCollectionServiceServer) BatchDeleteRequestsFromCollection(context.Context, *BatchDeleteRequestsFromCollectionRequest) (*BatchRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteRequestsFromCollection not implemented")
+}
+func (UnimplementedCollectionServiceServer) PreviewMergeCollections(context.Context, *PreviewMergeCollectionsRequest) (*PreviewMergeCollectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewMergeCollections not implemented")
+}
+func (UnimplementedCollectionServiceServer) MergeCollections(context.Context, *MergeCollectionsRequest) (*MergeCollectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeCollections not implemented")
+}
+func (UnimplementedCollectionServiceServer) ForkCollection(context.Context, *ForkCollectionRequest) (*CollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForkCollection not implemented")
+}
+func (UnimplementedCollectionServiceServer) SetCollectionProtected(context.Context, *SetCollectionProtectedRequest) (*CollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCollectionProtected not implemented")
+}
+func (UnimplementedCollectionServiceServer) OpenChangeProposal(context.Context, *OpenChangeProposalRequest) (*ChangeProposalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenChangeProposal not implemented")
+}
+func (UnimplementedCollectionServiceServer) GetChangeProposal(context.Context, *GetChangeProposalRequest) (*ChangeProposalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChangeProposal not implemented")
+}
+func (UnimplementedCollectionServiceServer) ListChangeProposals(context.Context, *ListChangeProposalsRequest) (*ListChangeProposalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChangeProposals not implemented")
+}
+func (UnimplementedCollectionServiceServer) CommentOnChangeProposal(context.Context, *CommentOnChangeProposalRequest) (*ChangeProposalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommentOnChangeProposal not implemented")
+}
+func (UnimplementedCollectionServiceServer) ReviewChangeProposal(context.Context, *ReviewChangeProposalRequest) (*ChangeProposalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewChangeProposal not implemented")
+}
+func (UnimplementedCollectionServiceServer) GrantCollectionRole(context.Context, *GrantCollectionRoleRequest) (*Member, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantCollectionRole not implemented")
+}
+func (UnimplementedCollectionServiceServer) RevokeCollectionRole(context.Context, *RevokeCollectionRoleRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeCollectionRole not implemented")
+}
+func (UnimplementedCollectionServiceServer) ListCollectionMembers(context.Context, *ListCollectionMembersRequest) (*ListMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollectionMembers not implemented")
+}
+func (UnimplementedCollectionServiceServer) ShareCollection(context.Context, *ShareCollectionRequest) (*ShareCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareCollection not implemented")
+}
+func (UnimplementedCollectionServiceServer) ListCollectionShares(context.Context, *ListCollectionSharesRequest) (*ListCollectionSharesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollectionShares not implemented")
+}
+func (UnimplementedCollectionServiceServer) RevokeCollectionShare(context.Context, *RevokeCollectionShareRequest) (*ShareInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeCollectionShare not implemented")
+}
+func (UnimplementedCollectionServiceServer) GetSharedCollection(context.Context, *GetSharedCollectionRequest) (*SharedCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSharedCollection not implemented")
+}
+func (UnimplementedCollectionServiceServer) ReencryptSecrets(context.Context, *ReencryptSecretsRequest) (*ReencryptSecretsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReencryptSecrets not implemented")
+}
+func (UnimplementedCollectionServiceServer) ExecuteRequest(context.Context, *ExecuteRequestRequest) (*ExecuteRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteRequest not implemented")
+}
+func (UnimplementedCollectionServiceServer) RunCollection(context.Context, *RunCollectionRequest) (*RunCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunCollection not implemented")
+}
+func (UnimplementedCollectionServiceServer) CreateExample(context.Context, *CreateExampleRequest) (*ExampleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateExample not implemented")
+}
+func (UnimplementedCollectionServiceServer) GetExample(context.Context, *GetExampleRequest) (*ExampleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExample not implemented")
+}
+func (UnimplementedCollectionServiceServer) ListExamples(context.Context, *ListExamplesRequest) (*ListExamplesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExamples not implemented")
+}
+func (UnimplementedCollectionServiceServer) UpdateExample(context.Context, *UpdateExampleRequest) (*ExampleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateExample not implemented")
+}
+func (UnimplementedCollectionServiceServer) DeleteExample(context.Context, *DeleteExampleRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteExample not implemented")
+}
+func (UnimplementedCollectionServiceServer) ListRunHistory(context.Context, *ListRunHistoryRequest) (*ListRunHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRunHistory not implemented")
+}
+func (UnimplementedCollectionServiceServer) GetRun(context.Context, *GetRunRequest) (*RunRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRun not implemented")
+}
+func (UnimplementedCollectionServiceServer) CreateMonitor(context.Context, *CreateMonitorRequest) (*MonitorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMonitor not implemented")
+}
+func (UnimplementedCollectionServiceServer) GetMonitor(context.Context, *GetMonitorRequest) (*MonitorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMonitor not implemented")
+}
+func (UnimplementedCollectionServiceServer) ListMonitors(context.Context, *ListMonitorsRequest) (*ListMonitorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMonitors not implemented")
+}
+func (UnimplementedCollectionServiceServer) UpdateMonitor(context.Context, *UpdateMonitorRequest) (*MonitorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMonitor not implemented")
+}
+func (UnimplementedCollectionServiceServer) SetMonitorEnabled(context.Context, *SetMonitorEnabledRequest) (*MonitorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMonitorEnabled not implemented")
+}
+func (UnimplementedCollectionServiceServer) DeleteMonitor(context.Context, *DeleteMonitorRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMonitor not implemented")
+}
+func (UnimplementedCollectionServiceServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*WebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedCollectionServiceServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedCollectionServiceServer) UpdateWebhook(context.Context, *UpdateWebhookRequest) (*WebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWebhook not implemented")
+}
+func (UnimplementedCollectionServiceServer) SetWebhookEnabled(context.Context, *SetWebhookEnabledRequest) (*WebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWebhookEnabled not implemented")
+}
+func (UnimplementedCollectionServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedCollectionServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedCollectionServiceServer) WatchCollections(*WatchCollectionsRequest, grpc.ServerStreamingServer[CollectionChange]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCollections not implemented")
+}
+func (UnimplementedCollectionServiceServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedCollectionServiceServer) ExportAuditEvents(*ExportAuditEventsRequest, grpc.ServerStreamingServer[AuditExportChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAuditEvents not implemented")
+}
+func (UnimplementedCollectionServiceServer) mustEmbedUnimplementedCollectionServiceServer() {}
+func (UnimplementedCollectionServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeCollectionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CollectionServiceServer will
+// result in compilation errors.
+type UnsafeCollectionServiceServer interface {
+	mustEmbedUnimplementedCollectionServiceServer()
+}
+
+func RegisterCollectionServiceServer(s grpc.ServiceRegistrar, srv CollectionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCollectionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CollectionService_ServiceDesc, srv)
+}
+
+func _CollectionService_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).CreateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_CreateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).CreateCollection(ctx, req.(*CreateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_AddRequestToCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRequestToCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).AddRequestToCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_AddRequestToCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).AddRequestToCollection(ctx, req.(*AddRequestToCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_AddRequestToCollectionByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRequestToCollectionByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).AddRequestToCollectionByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_AddRequestToCollectionByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).AddRequestToCollectionByID(ctx, req.(*AddRequestToCollectionByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_ListCollectionsAndRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).ListCollectionsAndRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_ListCollectionsAndRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).ListCollectionsAndRequests(ctx, req.(*ListCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_UpdateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).UpdateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_UpdateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).UpdateCollection(ctx, req.(*UpdateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_UpdateRequestInCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequestInCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).UpdateRequestInCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_UpdateRequestInCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).UpdateRequestInCollection(ctx, req.(*UpdateRequestInCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_DeleteRequestFromCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequestFromCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).DeleteRequestFromCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_DeleteRequestFromCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).DeleteRequestFromCollection(ctx, req.(*DeleteRequestFromCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).DeleteCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_DeleteCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).DeleteCollection(ctx, req.(*DeleteCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_BatchAddRequestsToCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchAddRequestsToCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).BatchAddRequestsToCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_BatchAddRequestsToCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).BatchAddRequestsToCollection(ctx, req.(*BatchAddRequestsToCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_BatchUpdateRequestsInCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateRequestsInCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).BatchUpdateRequestsInCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_BatchUpdateRequestsInCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).BatchUpdateRequestsInCollection(ctx, req.(*BatchUpdateRequestsInCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_BatchDeleteRequestsFromCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteRequestsFromCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).BatchDeleteRequestsFromCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_BatchDeleteRequestsFromCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).BatchDeleteRequestsFromCollection(ctx, req.(*BatchDeleteRequestsFromCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_PreviewMergeCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewMergeCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).PreviewMergeCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_PreviewMergeCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).PreviewMergeCollections(ctx, req.(*PreviewMergeCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_MergeCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).MergeCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_MergeCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).MergeCollections(ctx, req.(*MergeCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_ForkCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForkCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).ForkCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_ForkCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).ForkCollection(ctx, req.(*ForkCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_SetCollectionProtected_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCollectionProtectedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).SetCollectionProtected(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_SetCollectionProtected_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).SetCollectionProtected(ctx, req.(*SetCollectionProtectedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_OpenChangeProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenChangeProposalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).OpenChangeProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_OpenChangeProposal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).OpenChangeProposal(ctx, req.(*OpenChangeProposalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_GetChangeProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChangeProposalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).GetChangeProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_GetChangeProposal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).GetChangeProposal(ctx, req.(*GetChangeProposalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_ListChangeProposals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChangeProposalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).ListChangeProposals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_ListChangeProposals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).ListChangeProposals(ctx, req.(*ListChangeProposalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_CommentOnChangeProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommentOnChangeProposalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).CommentOnChangeProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_CommentOnChangeProposal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).CommentOnChangeProposal(ctx, req.(*CommentOnChangeProposalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_ReviewChangeProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewChangeProposalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).ReviewChangeProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_ReviewChangeProposal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).ReviewChangeProposal(ctx, req.(*ReviewChangeProposalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_GrantCollectionRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantCollectionRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).GrantCollectionRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_GrantCollectionRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).GrantCollectionRole(ctx, req.(*GrantCollectionRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_RevokeCollectionRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCollectionRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).RevokeCollectionRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_RevokeCollectionRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).RevokeCollectionRole(ctx, req.(*RevokeCollectionRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_ListCollectionMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).ListCollectionMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_ListCollectionMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).ListCollectionMembers(ctx, req.(*ListCollectionMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_ShareCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).ShareCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_ShareCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).ShareCollection(ctx, req.(*ShareCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_ListCollectionShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionSharesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).ListCollectionShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_ListCollectionShares_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).ListCollectionShares(ctx, req.(*ListCollectionSharesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_RevokeCollectionShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCollectionShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).RevokeCollectionShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_RevokeCollectionShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).RevokeCollectionShare(ctx, req.(*RevokeCollectionShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_GetSharedCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSharedCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).GetSharedCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_GetSharedCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).GetSharedCollection(ctx, req.(*GetSharedCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_ReencryptSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReencryptSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).ReencryptSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_ReencryptSecrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).ReencryptSecrets(ctx, req.(*ReencryptSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_ExecuteRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).ExecuteRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_ExecuteRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).ExecuteRequest(ctx, req.(*ExecuteRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_RunCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).RunCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_RunCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).RunCollection(ctx, req.(*RunCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_CreateExample_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateExampleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).CreateExample(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_CreateExample_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).CreateExample(ctx, req.(*CreateExampleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_GetExample_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExampleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).GetExample(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_GetExample_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).GetExample(ctx, req.(*GetExampleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_ListExamples_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExamplesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).ListExamples(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_ListExamples_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).ListExamples(ctx, req.(*ListExamplesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_UpdateExample_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateExampleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).UpdateExample(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_UpdateExample_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).UpdateExample(ctx, req.(*UpdateExampleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_DeleteExample_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteExampleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).DeleteExample(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_DeleteExample_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).DeleteExample(ctx, req.(*DeleteExampleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_ListRunHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRunHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).ListRunHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_ListRunHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).ListRunHistory(ctx, req.(*ListRunHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_GetRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).GetRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_GetRun_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).GetRun(ctx, req.(*GetRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_CreateMonitor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMonitorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).CreateMonitor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_CreateMonitor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).CreateMonitor(ctx, req.(*CreateMonitorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_GetMonitor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMonitorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).GetMonitor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_GetMonitor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).GetMonitor(ctx, req.(*GetMonitorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_ListMonitors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMonitorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).ListMonitors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_ListMonitors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).ListMonitors(ctx, req.(*ListMonitorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_UpdateMonitor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMonitorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).UpdateMonitor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_UpdateMonitor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).UpdateMonitor(ctx, req.(*UpdateMonitorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_SetMonitorEnabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMonitorEnabledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).SetMonitorEnabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_SetMonitorEnabled_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).SetMonitorEnabled(ctx, req.(*SetMonitorEnabledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_DeleteMonitor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMonitorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).DeleteMonitor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_DeleteMonitor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).DeleteMonitor(ctx, req.(*DeleteMonitorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_UpdateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).UpdateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_UpdateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).UpdateWebhook(ctx, req.(*UpdateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_SetWebhookEnabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWebhookEnabledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).SetWebhookEnabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_SetWebhookEnabled_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).SetWebhookEnabled(ctx, req.(*SetWebhookEnabledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_WatchCollections_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCollectionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CollectionServiceServer).WatchCollections(m, &grpc.GenericServerStream[WatchCollectionsRequest, CollectionChange]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CollectionService_WatchCollectionsServer = grpc.ServerStreamingServer[CollectionChange]
+
+func _CollectionService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollectionService_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionServiceServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollectionService_ExportAuditEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportAuditEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CollectionServiceServer).ExportAuditEvents(m, &grpc.GenericServerStream[ExportAuditEventsRequest, AuditExportChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CollectionService_ExportAuditEventsServer = grpc.ServerStreamingServer[AuditExportChunk]
+
+// CollectionService_ServiceDesc is the grpc.ServiceDesc for CollectionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CollectionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "collections.CollectionService",
+	HandlerType: (*CollectionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateCollection",
+			Handler:    _CollectionService_CreateCollection_Handler,
+		},
+		{
+			MethodName: "AddRequestToCollection",
+			Handler:    _CollectionService_AddRequestToCollection_Handler,
+		},
+		{
+			MethodName: "AddRequestToCollectionByID",
+			Handler:    _CollectionService_AddRequestToCollectionByID_Handler,
+		},
+		{
+			MethodName: "ListCollectionsAndRequests",
+			Handler:    _CollectionService_ListCollectionsAndRequests_Handler,
+		},
+		{
+			MethodName: "UpdateCollection",
+			Handler:    _CollectionService_UpdateCollection_Handler,
+		},
+		{
+			MethodName: "UpdateRequestInCollection",
+			Handler:    _CollectionService_UpdateRequestInCollection_Handler,
+		},
+		{
+			MethodName: "DeleteRequestFromCollection",
+			Handler:    _CollectionService_DeleteRequestFromCollection_Handler,
+		},
+		{
+			MethodName: "DeleteCollection",
+			Handler:    _CollectionService_DeleteCollection_Handler,
+		},
+		{
+			MethodName: "BatchAddRequestsToCollection",
+			Handler:    _CollectionService_BatchAddRequestsToCollection_Handler,
+		},
+		{
+			MethodName: "BatchUpdateRequestsInCollection",
+			Handler:    _CollectionService_BatchUpdateRequestsInCollection_Handler,
+		},
+		{
+			MethodName: "BatchDeleteRequestsFromCollection",
+			Handler:    _CollectionService_BatchDeleteRequestsFromCollection_Handler,
+		},
+		{
+			MethodName: "PreviewMergeCollections",
+			Handler:    _CollectionService_PreviewMergeCollections_Handler,
+		},
+		{
+			MethodName: "MergeCollections",
+			Handler:    _CollectionService_MergeCollections_Handler,
+		},
+		{
+			MethodName: "ForkCollection",
+			Handler:    _CollectionService_ForkCollection_Handler,
+		},
+		{
+			MethodName: "SetCollectionProtected",
+			Handler:    _CollectionService_SetCollectionProtected_Handler,
+		},
+		{
+			MethodName: "OpenChangeProposal",
+			Handler:    _CollectionService_OpenChangeProposal_Handler,
+		},
+		{
+			MethodName: "GetChangeProposal",
+			Handler:    _CollectionService_GetChangeProposal_Handler,
+		},
+		{
+			MethodName: "ListChangeProposals",
+			Handler:    _CollectionService_ListChangeProposals_Handler,
+		},
+		{
+			MethodName: "CommentOnChangeProposal",
+			Handler:    _CollectionService_CommentOnChangeProposal_Handler,
+		},
+		{
+			MethodName: "ReviewChangeProposal",
+			Handler:    _CollectionService_ReviewChangeProposal_Handler,
+		},
+		{
+			MethodName: "GrantCollectionRole",
+			Handler:    _CollectionService_GrantCollectionRole_Handler,
+		},
+		{
+			MethodName: "RevokeCollectionRole",
+			Handler:    _CollectionService_RevokeCollectionRole_Handler,
+		},
+		{
+			MethodName: "ListCollectionMembers",
+			Handler:    _CollectionService_ListCollectionMembers_Handler,
+		},
+		{
+			MethodName: "ShareCollection",
+			Handler:    _CollectionService_ShareCollection_Handler,
+		},
+		{
+			MethodName: "ListCollectionShares",
+			Handler:    _CollectionService_ListCollectionShares_Handler,
+		},
+		{
+			MethodName: "RevokeCollectionShare",
+			Handler:    _CollectionService_RevokeCollectionShare_Handler,
+		},
+		{
+			MethodName: "GetSharedCollection",
+			Handler:    _CollectionService_GetSharedCollection_Handler,
+		},
+		{
+			MethodName: "ReencryptSecrets",
+			Handler:    _CollectionService_ReencryptSecrets_Handler,
+		},
+		{
+			MethodName: "ExecuteRequest",
+			Handler:    _CollectionService_ExecuteRequest_Handler,
+		},
+		{
+			MethodName: "RunCollection",
+			Handler:    _CollectionService_RunCollection_Handler,
+		},
+		{
+			MethodName: "CreateExample",
+			Handler:    _CollectionService_CreateExample_Handler,
+		},
+		{
+			MethodName: "GetExample",
+			Handler:    _CollectionService_GetExample_Handler,
+		},
+		{
+			MethodName: "ListExamples",
+			Handler:    _CollectionService_ListExamples_Handler,
+		},
+		{
+			MethodName: "UpdateExample",
+			Handler:    _CollectionService_UpdateExample_Handler,
+		},
+		{
+			MethodName: "DeleteExample",
+			Handler:    _CollectionService_DeleteExample_Handler,
+		},
+		{
+			MethodName: "ListRunHistory",
+			Handler:    _CollectionService_ListRunHistory_Handler,
+		},
+		{
+			MethodName: "GetRun",
+			Handler:    _CollectionService_GetRun_Handler,
+		},
+		{
+			MethodName: "CreateMonitor",
+			Handler:    _CollectionService_CreateMonitor_Handler,
+		},
+		{
+			MethodName: "GetMonitor",
+			Handler:    _CollectionService_GetMonitor_Handler,
+		},
+		{
+			MethodName: "ListMonitors",
+			Handler:    _CollectionService_ListMonitors_Handler,
+		},
+		{
+			MethodName: "UpdateMonitor",
+			Handler:    _CollectionService_UpdateMonitor_Handler,
+		},
+		{
+			MethodName: "SetMonitorEnabled",
+			Handler:    _CollectionService_SetMonitorEnabled_Handler,
+		},
+		{
+			MethodName: "DeleteMonitor",
+			Handler:    _CollectionService_DeleteMonitor_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _CollectionService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _CollectionService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "UpdateWebhook",
+			Handler:    _CollectionService_UpdateWebhook_Handler,
+		},
+		{
+			MethodName: "SetWebhookEnabled",
+			Handler:    _CollectionService_SetWebhookEnabled_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _CollectionService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _CollectionService_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _CollectionService_ListAuditEvents_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCollections",
+			Handler:       _CollectionService_WatchCollections_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportAuditEvents",
+			Handler:       _CollectionService_ExportAuditEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "internal/api/proto/collections.proto",
+}
+
+const (
+	WorkspaceService_CreateWorkspace_FullMethodName      = "/collections.WorkspaceService/CreateWorkspace"
+	WorkspaceService_GetWorkspace_FullMethodName         = "/collections.WorkspaceService/GetWorkspace"
+	WorkspaceService_ListWorkspaces_FullMethodName       = "/collections.WorkspaceService/ListWorkspaces"
+	WorkspaceService_UpdateWorkspace_FullMethodName      = "/collections.WorkspaceService/UpdateWorkspace"
+	WorkspaceService_DeleteWorkspace_FullMethodName      = "/collections.WorkspaceService/DeleteWorkspace"
+	WorkspaceService_GrantWorkspaceRole_FullMethodName   = "/collections.WorkspaceService/GrantWorkspaceRole"
+	WorkspaceService_RevokeWorkspaceRole_FullMethodName  = "/collections.WorkspaceService/RevokeWorkspaceRole"
+	WorkspaceService_ListWorkspaceMembers_FullMethodName = "/collections.WorkspaceService/ListWorkspaceMembers"
+)
+
+// WorkspaceServiceClient is the client API for WorkspaceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Calls to CollectionService are scoped to the workspace named in the
+// x-workspace-id metadata header, or the default workspace when it is absent.
+type WorkspaceServiceClient interface {
+	CreateWorkspace(ctx context.Context, in *CreateWorkspaceRequest, opts ...grpc.CallOption) (*WorkspaceResponse, error)
+	GetWorkspace(ctx context.Context, in *GetWorkspaceRequest, opts ...grpc.CallOption) (*WorkspaceResponse, error)
+	ListWorkspaces(ctx context.Context, in *ListWorkspacesRequest, opts ...grpc.CallOption) (*ListWorkspacesResponse, error)
+	UpdateWorkspace(ctx context.Context, in *UpdateWorkspaceRequest, opts ...grpc.CallOption) (*WorkspaceResponse, error)
+	DeleteWorkspace(ctx context.Context, in *DeleteWorkspaceRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	GrantWorkspaceRole(ctx context.Context, in *GrantWorkspaceRoleRequest, opts ...grpc.CallOption) (*Member, error)
+	RevokeWorkspaceRole(ctx context.Context, in *RevokeWorkspaceRoleRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	ListWorkspaceMembers(ctx context.Context, in *ListWorkspaceMembersRequest, opts ...grpc.CallOption) (*ListMembersResponse, error)
+}
+
+type workspaceServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWorkspaceServiceClient(cc grpc.ClientConnInterface) WorkspaceServiceClient {
+	return &workspaceServiceClient{cc}
+}
+
+func (c *workspaceServiceClient) CreateWorkspace(ctx context.Context, in *CreateWorkspaceRequest, opts ...grpc.CallOption) (*WorkspaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkspaceResponse)
+	err := c.cc.Invoke(ctx, WorkspaceService_CreateWorkspace_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *collectionServiceClient) UpdateCollection(ctx context.Context, in *UpdateCollectionRequest, opts ...grpc.CallOption) (*CollectionResponse, error) {
+func (c *workspaceServiceClient) GetWorkspace(ctx context.Context, in *GetWorkspaceRequest, opts ...grpc.CallOption) (*WorkspaceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(CollectionResponse)
-	err := c.cc.Invoke(ctx, CollectionService_UpdateCollection_FullMethodName, in, out, cOpts...)
+	out := new(WorkspaceResponse)
+	err := c.cc.Invoke(ctx, WorkspaceService_GetWorkspace_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *collectionServiceClient) UpdateRequestInCollection(ctx context.Context, in *UpdateRequestInCollectionRequest, opts ...grpc.CallOption) (*UpdateRequestInCollectionResponse, error) {
+func (c *workspaceServiceClient) ListWorkspaces(ctx context.Context, in *ListWorkspacesRequest, opts ...grpc.CallOption) (*ListWorkspacesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(UpdateRequestInCollectionResponse)
-	err := c.cc.Invoke(ctx, CollectionService_UpdateRequestInCollection_FullMethodName, in, out, cOpts...)
+	out := new(ListWorkspacesResponse)
+	err := c.cc.Invoke(ctx, WorkspaceService_ListWorkspaces_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *collectionServiceClient) DeleteRequestFromCollection(ctx context.Context, in *DeleteRequestFromCollectionRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+func (c *workspaceServiceClient) UpdateWorkspace(ctx context.Context, in *UpdateWorkspaceRequest, opts ...grpc.CallOption) (*WorkspaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkspaceResponse)
+	err := c.cc.Invoke(ctx, WorkspaceService_UpdateWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceServiceClient) DeleteWorkspace(ctx context.Context, in *DeleteWorkspaceRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeleteResponse)
-	err := c.cc.Invoke(ctx, CollectionService_DeleteRequestFromCollection_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, WorkspaceService_DeleteWorkspace_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *collectionServiceClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+func (c *workspaceServiceClient) GrantWorkspaceRole(ctx context.Context, in *GrantWorkspaceRoleRequest, opts ...grpc.CallOption) (*Member, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Member)
+	err := c.cc.Invoke(ctx, WorkspaceService_GrantWorkspaceRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceServiceClient) RevokeWorkspaceRole(ctx context.Context, in *RevokeWorkspaceRoleRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeleteResponse)
-	err := c.cc.Invoke(ctx, CollectionService_DeleteCollection_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, WorkspaceService_RevokeWorkspaceRole_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// CollectionServiceServer is the server API for CollectionService service.
-// All implementations must embed UnimplementedCollectionServiceServer
+func (c *workspaceServiceClient) ListWorkspaceMembers(ctx context.Context, in *ListWorkspaceMembersRequest, opts ...grpc.CallOption) (*ListMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMembersResponse)
+	err := c.cc.Invoke(ctx, WorkspaceService_ListWorkspaceMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WorkspaceServiceServer is the server API for WorkspaceService service.
+// All implementations must embed UnimplementedWorkspaceServiceServer
 // for forward compatibility.
-type CollectionServiceServer interface {
-	CreateCollection(context.Context, *CreateCollectionRequest) (*CreateCollectionResponse, error)
-	AddRequestToCollection(context.Context, *AddRequestToCollectionRequest) (*CollectionResponse, error)
-	ListCollectionsAndRequests(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error)
-	UpdateCollection(context.Context, *UpdateCollectionRequest) (*CollectionResponse, error)
-	UpdateRequestInCollection(context.Context, *UpdateRequestInCollectionRequest) (*UpdateRequestInCollectionResponse, error)
-	DeleteRequestFromCollection(context.Context, *DeleteRequestFromCollectionRequest) (*DeleteResponse, error)
-	DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteResponse, error)
-	mustEmbedUnimplementedCollectionServiceServer()
+//
+// Calls to CollectionService are scoped to the workspace named in the
+// x-workspace-id metadata header, or the default workspace when it is absent.
+type WorkspaceServiceServer interface {
+	CreateWorkspace(context.Context, *CreateWorkspaceRequest) (*WorkspaceResponse, error)
+	GetWorkspace(context.Context, *GetWorkspaceRequest) (*WorkspaceResponse, error)
+	ListWorkspaces(context.Context, *ListWorkspacesRequest) (*ListWorkspacesResponse, error)
+	UpdateWorkspace(context.Context, *UpdateWorkspaceRequest) (*WorkspaceResponse, error)
+	DeleteWorkspace(context.Context, *DeleteWorkspaceRequest) (*DeleteResponse, error)
+	GrantWorkspaceRole(context.Context, *GrantWorkspaceRoleRequest) (*Member, error)
+	RevokeWorkspaceRole(context.Context, *RevokeWorkspaceRoleRequest) (*DeleteResponse, error)
+	ListWorkspaceMembers(context.Context, *ListWorkspaceMembersRequest) (*ListMembersResponse, error)
+	mustEmbedUnimplementedWorkspaceServiceServer()
 }
 
-// UnimplementedCollectionServiceServer must be embedded to have
+// UnimplementedWorkspaceServiceServer must be embedded to have
 // forward compatible implementations.
 //
 // NOTE: this should be embedded by value instead of pointer to avoid a nil
 // pointer dereference when methods are called.
-type UnimplementedCollectionServiceServer struct{}
+type UnimplementedWorkspaceServiceServer struct{}
 
-func (UnimplementedCollectionServiceServer) CreateCollection(context.Context, *CreateCollectionRequest) (*CreateCollectionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateCollection not implemented")
+func (UnimplementedWorkspaceServiceServer) CreateWorkspace(context.Context, *CreateWorkspaceRequest) (*WorkspaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWorkspace not implemented")
 }
-func (UnimplementedCollectionServiceServer) AddRequestToCollection(context.Context, *AddRequestToCollectionRequest) (*CollectionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddRequestToCollection not implemented")
+func (UnimplementedWorkspaceServiceServer) GetWorkspace(context.Context, *GetWorkspaceRequest) (*WorkspaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkspace not implemented")
 }
-func (UnimplementedCollectionServiceServer) ListCollectionsAndRequests(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListCollectionsAndRequests not implemented")
+func (UnimplementedWorkspaceServiceServer) ListWorkspaces(context.Context, *ListWorkspacesRequest) (*ListWorkspacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkspaces not implemented")
 }
-func (UnimplementedCollectionServiceServer) UpdateCollection(context.Context, *UpdateCollectionRequest) (*CollectionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateCollection not implemented")
+func (UnimplementedWorkspaceServiceServer) UpdateWorkspace(context.Context, *UpdateWorkspaceRequest) (*WorkspaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWorkspace not implemented")
 }
-func (UnimplementedCollectionServiceServer) UpdateRequestInCollection(context.Context, *UpdateRequestInCollectionRequest) (*UpdateRequestInCollectionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateRequestInCollection not implemented")
+func (UnimplementedWorkspaceServiceServer) DeleteWorkspace(context.Context, *DeleteWorkspaceRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWorkspace not implemented")
 }
-func (UnimplementedCollectionServiceServer) DeleteRequestFromCollection(context.Context, *DeleteRequestFromCollectionRequest) (*DeleteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteRequestFromCollection not implemented")
+func (UnimplementedWorkspaceServiceServer) GrantWorkspaceRole(context.Context, *GrantWorkspaceRoleRequest) (*Member, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantWorkspaceRole not implemented")
 }
-func (UnimplementedCollectionServiceServer) DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteCollection not implemented")
+func (UnimplementedWorkspaceServiceServer) RevokeWorkspaceRole(context.Context, *RevokeWorkspaceRoleRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeWorkspaceRole not implemented")
 }
-func (UnimplementedCollectionServiceServer) mustEmbedUnimplementedCollectionServiceServer() {}
-func (UnimplementedCollectionServiceServer) testEmbeddedByValue()                           {}
+func (UnimplementedWorkspaceServiceServer) ListWorkspaceMembers(context.Context, *ListWorkspaceMembersRequest) (*ListMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkspaceMembers not implemented")
+}
+func (UnimplementedWorkspaceServiceServer) mustEmbedUnimplementedWorkspaceServiceServer() {}
+func (UnimplementedWorkspaceServiceServer) testEmbeddedByValue()                          {}
 
-// UnsafeCollectionServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to CollectionServiceServer will
+// UnsafeWorkspaceServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WorkspaceServiceServer will
 // result in compilation errors.
-type UnsafeCollectionServiceServer interface {
-	mustEmbedUnimplementedCollectionServiceServer()
+type UnsafeWorkspaceServiceServer interface {
+	mustEmbedUnimplementedWorkspaceServiceServer()
 }
 
-func RegisterCollectionServiceServer(s grpc.ServiceRegistrar, srv CollectionServiceServer) {
-	// If the following call pancis, it indicates UnimplementedCollectionServiceServer was
+func RegisterWorkspaceServiceServer(s grpc.ServiceRegistrar, srv WorkspaceServiceServer) {
+	// If the following call pancis, it indicates UnimplementedWorkspaceServiceServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
 	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
 		t.testEmbeddedByValue()
 	}
-	s.RegisterService(&CollectionService_ServiceDesc, srv)
+	s.RegisterService(&WorkspaceService_ServiceDesc, srv)
 }
 
-func _CollectionService_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateCollectionRequest)
+func _WorkspaceService_CreateWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWorkspaceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CollectionServiceServer).CreateCollection(ctx, in)
+		return srv.(WorkspaceServiceServer).CreateWorkspace(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CollectionService_CreateCollection_FullMethodName,
+		FullMethod: WorkspaceService_CreateWorkspace_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CollectionServiceServer).CreateCollection(ctx, req.(*CreateCollectionRequest))
+		return srv.(WorkspaceServiceServer).CreateWorkspace(ctx, req.(*CreateWorkspaceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CollectionService_AddRequestToCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddRequestToCollectionRequest)
+func _WorkspaceService_GetWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkspaceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CollectionServiceServer).AddRequestToCollection(ctx, in)
+		return srv.(WorkspaceServiceServer).GetWorkspace(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CollectionService_AddRequestToCollection_FullMethodName,
+		FullMethod: WorkspaceService_GetWorkspace_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CollectionServiceServer).AddRequestToCollection(ctx, req.(*AddRequestToCollectionRequest))
+		return srv.(WorkspaceServiceServer).GetWorkspace(ctx, req.(*GetWorkspaceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CollectionService_ListCollectionsAndRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListCollectionsRequest)
+func _WorkspaceService_ListWorkspaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkspacesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CollectionServiceServer).ListCollectionsAndRequests(ctx, in)
+		return srv.(WorkspaceServiceServer).ListWorkspaces(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CollectionService_ListCollectionsAndRequests_FullMethodName,
+		FullMethod: WorkspaceService_ListWorkspaces_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CollectionServiceServer).ListCollectionsAndRequests(ctx, req.(*ListCollectionsRequest))
+		return srv.(WorkspaceServiceServer).ListWorkspaces(ctx, req.(*ListWorkspacesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CollectionService_UpdateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateCollectionRequest)
+func _WorkspaceService_UpdateWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWorkspaceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CollectionServiceServer).UpdateCollection(ctx, in)
+		return srv.(WorkspaceServiceServer).UpdateWorkspace(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CollectionService_UpdateCollection_FullMethodName,
+		FullMethod: WorkspaceService_UpdateWorkspace_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CollectionServiceServer).UpdateCollection(ctx, req.(*UpdateCollectionRequest))
+		return srv.(WorkspaceServiceServer).UpdateWorkspace(ctx, req.(*UpdateWorkspaceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CollectionService_UpdateRequestInCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateRequestInCollectionRequest)
+func _WorkspaceService_DeleteWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWorkspaceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CollectionServiceServer).UpdateRequestInCollection(ctx, in)
+		return srv.(WorkspaceServiceServer).DeleteWorkspace(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CollectionService_UpdateRequestInCollection_FullMethodName,
+		FullMethod: WorkspaceService_DeleteWorkspace_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CollectionServiceServer).UpdateRequestInCollection(ctx, req.(*UpdateRequestInCollectionRequest))
+		return srv.(WorkspaceServiceServer).DeleteWorkspace(ctx, req.(*DeleteWorkspaceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CollectionService_DeleteRequestFromCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteRequestFromCollectionRequest)
+func _WorkspaceService_GrantWorkspaceRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantWorkspaceRoleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CollectionServiceServer).DeleteRequestFromCollection(ctx, in)
+		return srv.(WorkspaceServiceServer).GrantWorkspaceRole(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CollectionService_DeleteRequestFromCollection_FullMethodName,
+		FullMethod: WorkspaceService_GrantWorkspaceRole_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CollectionServiceServer).DeleteRequestFromCollection(ctx, req.(*DeleteRequestFromCollectionRequest))
+		return srv.(WorkspaceServiceServer).GrantWorkspaceRole(ctx, req.(*GrantWorkspaceRoleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CollectionService_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteCollectionRequest)
+func _WorkspaceService_RevokeWorkspaceRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeWorkspaceRoleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CollectionServiceServer).DeleteCollection(ctx, in)
+		return srv.(WorkspaceServiceServer).RevokeWorkspaceRole(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CollectionService_DeleteCollection_FullMethodName,
+		FullMethod: WorkspaceService_RevokeWorkspaceRole_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CollectionServiceServer).DeleteCollection(ctx, req.(*DeleteCollectionRequest))
+		return srv.(WorkspaceServiceServer).RevokeWorkspaceRole(ctx, req.(*RevokeWorkspaceRoleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// CollectionService_ServiceDesc is the grpc.ServiceDesc for CollectionService service.
+func _WorkspaceService_ListWorkspaceMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkspaceMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).ListWorkspaceMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_ListWorkspaceMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).ListWorkspaceMembers(ctx, req.(*ListWorkspaceMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WorkspaceService_ServiceDesc is the grpc.ServiceDesc for WorkspaceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var CollectionService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "collections.CollectionService",
-	HandlerType: (*CollectionServiceServer)(nil),
+var WorkspaceService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "collections.WorkspaceService",
+	HandlerType: (*WorkspaceServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "CreateCollection",
-			Handler:    _CollectionService_CreateCollection_Handler,
+			MethodName: "CreateWorkspace",
+			Handler:    _WorkspaceService_CreateWorkspace_Handler,
 		},
 		{
-			MethodName: "AddRequestToCollection",
-			Handler:    _CollectionService_AddRequestToCollection_Handler,
+			MethodName: "GetWorkspace",
+			Handler:    _WorkspaceService_GetWorkspace_Handler,
 		},
 		{
-			MethodName: "ListCollectionsAndRequests",
-			Handler:    _CollectionService_ListCollectionsAndRequests_Handler,
+			MethodName: "ListWorkspaces",
+			Handler:    _WorkspaceService_ListWorkspaces_Handler,
 		},
 		{
-			MethodName: "UpdateCollection",
-			Handler:    _CollectionService_UpdateCollection_Handler,
+			MethodName: "UpdateWorkspace",
+			Handler:    _WorkspaceService_UpdateWorkspace_Handler,
 		},
 		{
-			MethodName: "UpdateRequestInCollection",
-			Handler:    _CollectionService_UpdateRequestInCollection_Handler,
+			MethodName: "DeleteWorkspace",
+			Handler:    _WorkspaceService_DeleteWorkspace_Handler,
 		},
 		{
-			MethodName: "DeleteRequestFromCollection",
-			Handler:    _CollectionService_DeleteRequestFromCollection_Handler,
+			MethodName: "GrantWorkspaceRole",
+			Handler:    _WorkspaceService_GrantWorkspaceRole_Handler,
 		},
 		{
-			MethodName: "DeleteCollection",
-			Handler:    _CollectionService_DeleteCollection_Handler,
+			MethodName: "RevokeWorkspaceRole",
+			Handler:    _WorkspaceService_RevokeWorkspaceRole_Handler,
+		},
+		{
+			MethodName: "ListWorkspaceMembers",
+			Handler:    _WorkspaceService_ListWorkspaceMembers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/api/proto/collections.proto",
+}
+
+const (
+	AuthService_CreateAPIKey_FullMethodName = "/collections.AuthService/CreateAPIKey"
+	AuthService_ListAPIKeys_FullMethodName  = "/collections.AuthService/ListAPIKeys"
+	AuthService_RevokeAPIKey_FullMethodName = "/collections.AuthService/RevokeAPIKey"
+)
+
+// AuthServiceClient is the client API for AuthService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Every RPC requires either an API key (x-api-key metadata or an
+// "authorization: Bearer csk_..." header) or a bearer JWT.
+type AuthServiceClient interface {
+	CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error)
+	ListAPIKeys(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysResponse, error)
+	RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*APIKeyInfo, error)
+}
+
+type authServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuthServiceClient(cc grpc.ClientConnInterface) AuthServiceClient {
+	return &authServiceClient{cc}
+}
+
+func (c *authServiceClient) CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAPIKeyResponse)
+	err := c.cc.Invoke(ctx, AuthService_CreateAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListAPIKeys(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAPIKeysResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListAPIKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*APIKeyInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APIKeyInfo)
+	err := c.cc.Invoke(ctx, AuthService_RevokeAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthServiceServer is the server API for AuthService service.
+// All implementations must embed UnimplementedAuthServiceServer
+// for forward compatibility.
+//
+// Every RPC requires either an API key (x-api-key metadata or an
+// "authorization: Bearer csk_..." header) or a bearer JWT.
+type AuthServiceServer interface {
+	CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error)
+	ListAPIKeys(context.Context, *ListAPIKeysRequest) (*ListAPIKeysResponse, error)
+	RevokeAPIKey(context.Context, *RevokeAPIKeyRequest) (*APIKeyInfo, error)
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+// UnimplementedAuthServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAuthServiceServer struct{}
+
+func (UnimplementedAuthServiceServer) CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPIKey not implemented")
+}
+func (UnimplementedAuthServiceServer) ListAPIKeys(context.Context, *ListAPIKeysRequest) (*ListAPIKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAPIKeys not implemented")
+}
+func (UnimplementedAuthServiceServer) RevokeAPIKey(context.Context, *RevokeAPIKeyRequest) (*APIKeyInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAPIKey not implemented")
+}
+func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
+func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthServiceServer will
+// result in compilation errors.
+type UnsafeAuthServiceServer interface {
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+func RegisterAuthServiceServer(s grpc.ServiceRegistrar, srv AuthServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAuthServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AuthService_ServiceDesc, srv)
+}
+
+func _AuthService_CreateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CreateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CreateAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CreateAPIKey(ctx, req.(*CreateAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListAPIKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAPIKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListAPIKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListAPIKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListAPIKeys(ctx, req.(*ListAPIKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RevokeAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RevokeAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RevokeAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RevokeAPIKey(ctx, req.(*RevokeAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuthService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "collections.AuthService",
+	HandlerType: (*AuthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAPIKey",
+			Handler:    _AuthService_CreateAPIKey_Handler,
+		},
+		{
+			MethodName: "ListAPIKeys",
+			Handler:    _AuthService_ListAPIKeys_Handler,
+		},
+		{
+			MethodName: "RevokeAPIKey",
+			Handler:    _AuthService_RevokeAPIKey_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
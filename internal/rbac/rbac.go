@@ -0,0 +1,202 @@
+package rbac
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/auth"
+	"collectionsservice/internal/models"
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Permission int
+
+const (
+	// PermRead allows reading collections, requests and proposals.
+	PermRead Permission = iota + 1
+	// PermRun allows executing collections and requests.
+	PermRun
+	// PermWrite allows creating, editing and deleting collections and requests.
+	PermWrite
+	// PermReveal allows reading secret values in plaintext.
+	PermReveal
+	// PermManage allows managing members, protection and proposal reviews.
+	PermManage
+)
+
+func (p Permission) String() string {
+	switch p {
+	case PermRead:
+		return "read"
+	case PermRun:
+		return "run"
+	case PermWrite:
+		return "write"
+	case PermReveal:
+		return "reveal"
+	case PermManage:
+		return "manage"
+	}
+	return "unknown"
+}
+
+var roleRank = map[models.Role]Permission{
+	models.RoleViewer: PermRead,
+	models.RoleRunner: PermRun,
+	models.RoleEditor: PermWrite,
+	models.RoleOwner:  PermManage,
+}
+
+func Allows(role models.Role, p Permission) bool {
+	return roleRank[role] >= p
+}
+
+func ParseRole(s string) (models.Role, error) {
+	role := models.Role(strings.ToLower(s))
+	if _, ok := roleRank[role]; !ok {
+		return "", fmt.Errorf("unknown role %q: expected owner, editor, runner or viewer", s)
+	}
+	return role, nil
+}
+
+type RoleStore interface {
+	GetWorkspaceRole(ctx context.Context, workspaceID, subject string) (models.Role, error)
+	GetCollectionRole(ctx context.Context, collectionID, subject string) (models.Role, error)
+	GetCollectionRoles(ctx context.Context, collectionIDs []string, subject string) (map[string]models.Role, error)
+}
+
+type Authorizer struct {
+	store  RoleStore
+	admins map[string]bool
+}
+
+func NewAuthorizer(store RoleStore, adminSubjects []string) *Authorizer {
+	admins := make(map[string]bool, len(adminSubjects))
+	for _, s := range adminSubjects {
+		if s = strings.TrimSpace(s); s != "" {
+			admins[s] = true
+		}
+	}
+	return &Authorizer{
+		store:  store,
+		admins: admins,
+	}
+}
+
+// IsAdmin reports whether the caller bypasses role checks. Anonymous callers
+// only exist when authentication is disabled, so they are unrestricted too.
+func (a *Authorizer) IsAdmin(ctx context.Context) bool {
+	id, ok := auth.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	return id.Method == auth.MethodAnonymous || a.admins[id.Subject]
+}
+
+func (a *Authorizer) CheckWorkspace(ctx context.Context, workspaceID string, p Permission) error {
+	subject, err := a.subject(ctx)
+	if err != nil || a.IsAdmin(ctx) {
+		return err
+	}
+
+	role, err := a.store.GetWorkspaceRole(ctx, workspaceID, subject)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to resolve workspace role: %v", err)
+	}
+	if !Allows(role, p) {
+		return denied(p, "workspace", workspaceID)
+	}
+	return nil
+}
+
+// CheckCollection uses the caller's role on the collection when one was
+// granted, and their workspace role otherwise.
+func (a *Authorizer) CheckCollection(ctx context.Context, col *models.Collection, p Permission) error {
+	role, err := a.CollectionRole(ctx, col)
+	if err != nil {
+		return err
+	}
+	if !Allows(role, p) {
+		return denied(p, "collection", col.ID)
+	}
+	return nil
+}
+
+// CollectionRole returns the caller's effective role on col. Admins are
+// reported as owners.
+func (a *Authorizer) CollectionRole(ctx context.Context, col *models.Collection) (models.Role, error) {
+	subject, err := a.subject(ctx)
+	if err != nil {
+		return "", err
+	}
+	if a.IsAdmin(ctx) {
+		return models.RoleOwner, nil
+	}
+
+	role, err := a.store.GetCollectionRole(ctx, col.ID, subject)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to resolve collection role: %v", err)
+	}
+	if role != "" {
+		return role, nil
+	}
+
+	role, err = a.store.GetWorkspaceRole(ctx, col.WorkspaceID, subject)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to resolve workspace role: %v", err)
+	}
+	return role, nil
+}
+
+// FilterCollections keeps the collections of one workspace on which the
+// caller holds p.
+func (a *Authorizer) FilterCollections(ctx context.Context, workspaceID string, cols []*models.Collection, p Permission) ([]*models.Collection, error) {
+	subject, err := a.subject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if a.IsAdmin(ctx) {
+		return cols, nil
+	}
+
+	wsRole, err := a.store.GetWorkspaceRole(ctx, workspaceID, subject)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to resolve workspace role: %v", err)
+	}
+
+	ids := make([]string, 0, len(cols))
+	for _, c := range cols {
+		ids = append(ids, c.ID)
+	}
+	overrides, err := a.store.GetCollectionRoles(ctx, ids, subject)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to resolve collection roles: %v", err)
+	}
+
+	var allowed []*models.Collection
+	for _, c := range cols {
+		role, ok := overrides[c.ID]
+		if !ok {
+			role = wsRole
+		}
+		if Allows(role, p) {
+			allowed = append(allowed, c)
+		}
+	}
+	return allowed, nil
+}
+
+func (a *Authorizer) subject(ctx context.Context) (string, error) {
+	id, ok := auth.FromContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "caller identity is missing")
+	}
+	return id.Subject, nil
+}
+
+func denied(p Permission, kind, id string) error {
+	return apperr.PermissionDenied("ROLE_REQUIRED", "%s permission required on %s %s", p, kind, id)
+}
@@ -0,0 +1,212 @@
+package rbac
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/auth"
+	"collectionsservice/internal/models"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeRoles holds workspace roles by workspace and collection overrides by
+// collection, both keyed by subject.
+type fakeRoles struct {
+	workspace  map[string]map[string]models.Role
+	collection map[string]map[string]models.Role
+	err        error
+}
+
+func (f *fakeRoles) GetWorkspaceRole(_ context.Context, workspaceID, subject string) (models.Role, error) {
+	return f.workspace[workspaceID][subject], f.err
+}
+
+func (f *fakeRoles) GetCollectionRole(_ context.Context, collectionID, subject string) (models.Role, error) {
+	return f.collection[collectionID][subject], f.err
+}
+
+func (f *fakeRoles) GetCollectionRoles(_ context.Context, collectionIDs []string, subject string) (map[string]models.Role, error) {
+	roles := make(map[string]models.Role)
+	for _, id := range collectionIDs {
+		if role, ok := f.collection[id][subject]; ok {
+			roles[id] = role
+		}
+	}
+	return roles, f.err
+}
+
+func caller(subject string, method auth.Method) context.Context {
+	return auth.NewContext(context.Background(), &auth.Identity{Subject: subject, Method: method})
+}
+
+func TestAllows(t *testing.T) {
+	perms := []Permission{PermRead, PermRun, PermWrite, PermReveal, PermManage}
+	tests := []struct {
+		role models.Role
+		// want is whether the role holds each of perms in order.
+		want []bool
+	}{
+		{models.RoleViewer, []bool{true, false, false, false, false}},
+		{models.RoleRunner, []bool{true, true, false, false, false}},
+		{models.RoleEditor, []bool{true, true, true, false, false}},
+		{models.RoleOwner, []bool{true, true, true, true, true}},
+		{"", []bool{false, false, false, false, false}},
+		{"superuser", []bool{false, false, false, false, false}},
+	}
+	for _, tt := range tests {
+		for i, p := range perms {
+			if got := Allows(tt.role, p); got != tt.want[i] {
+				t.Errorf("Allows(%q, %s) = %v, want %v", tt.role, p, got, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    models.Role
+		wantErr bool
+	}{
+		{"viewer", models.RoleViewer, false},
+		{"Owner", models.RoleOwner, false},
+		{"EDITOR", models.RoleEditor, false},
+		{"admin", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRole(tt.in)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("ParseRole(%q) = %q, %v; want %q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestNewAuthorizerIgnoresBlankSubjects(t *testing.T) {
+	a := NewAuthorizer(&fakeRoles{}, []string{"", " ", " root "})
+	if a.IsAdmin(caller("", auth.MethodJWT)) {
+		t.Error("a token without a subject is an admin")
+	}
+	if !a.IsAdmin(caller("root", auth.MethodJWT)) {
+		t.Error("listed subject is not an admin")
+	}
+}
+
+func TestCheckCollection(t *testing.T) {
+	store := &fakeRoles{
+		workspace: map[string]map[string]models.Role{
+			"w1": {"alice": models.RoleEditor, "bob": models.RoleViewer, "carol": models.RoleEditor},
+		},
+		collection: map[string]map[string]models.Role{
+			"c1": {"bob": models.RoleOwner, "carol": models.RoleViewer},
+		},
+	}
+	col := &models.Collection{ID: "c1", WorkspaceID: "w1"}
+	a := NewAuthorizer(store, []string{"root"})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		perm     Permission
+		wantRole models.Role
+		wantCode codes.Code
+	}{
+		{"workspace role applies", caller("alice", auth.MethodJWT), PermWrite, models.RoleEditor, codes.OK},
+		{"workspace role is not enough", caller("alice", auth.MethodJWT), PermManage, models.RoleEditor, codes.PermissionDenied},
+		{"override raises the role", caller("bob", auth.MethodAPIKey), PermManage, models.RoleOwner, codes.OK},
+		{"override lowers the role", caller("carol", auth.MethodJWT), PermWrite, models.RoleViewer, codes.PermissionDenied},
+		{"no role", caller("dave", auth.MethodJWT), PermRead, "", codes.PermissionDenied},
+		{"admin subject", caller("root", auth.MethodJWT), PermManage, models.RoleOwner, codes.OK},
+		{"anonymous caller", caller("", auth.MethodAnonymous), PermManage, models.RoleOwner, codes.OK},
+		{"missing identity", context.Background(), PermRead, "", codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, _ := a.CollectionRole(tt.ctx, col)
+			if role != tt.wantRole {
+				t.Errorf("role = %q, want %q", role, tt.wantRole)
+			}
+			err := a.CheckCollection(tt.ctx, col, tt.perm)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("code = %s, want %s (%v)", got, tt.wantCode, err)
+			}
+			if tt.wantCode == codes.PermissionDenied {
+				if e, ok := apperr.As(err); !ok || e.Reason != "ROLE_REQUIRED" {
+					t.Errorf("error %v, want reason ROLE_REQUIRED", err)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckWorkspace(t *testing.T) {
+	store := &fakeRoles{workspace: map[string]map[string]models.Role{"w1": {"alice": models.RoleRunner}}}
+	a := NewAuthorizer(store, nil)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		perm     Permission
+		wantCode codes.Code
+	}{
+		{"role allows", caller("alice", auth.MethodJWT), PermRun, codes.OK},
+		{"role does not allow", caller("alice", auth.MethodJWT), PermWrite, codes.PermissionDenied},
+		{"admin is not configured", caller("admin", auth.MethodJWT), PermRead, codes.PermissionDenied},
+		{"anonymous caller", caller("", auth.MethodAnonymous), PermManage, codes.OK},
+	}
+	for _, tt := range tests {
+		if got := status.Code(a.CheckWorkspace(tt.ctx, "w1", tt.perm)); got != tt.wantCode {
+			t.Errorf("%s: code = %s, want %s", tt.name, got, tt.wantCode)
+		}
+	}
+
+	store.err = errors.New("connection refused")
+	if got := status.Code(a.CheckWorkspace(caller("alice", auth.MethodJWT), "w1", PermRead)); got != codes.Internal {
+		t.Errorf("store failure: code = %s, want Internal", got)
+	}
+}
+
+func TestFilterCollections(t *testing.T) {
+	store := &fakeRoles{
+		workspace: map[string]map[string]models.Role{"w1": {"alice": models.RoleViewer}},
+		collection: map[string]map[string]models.Role{
+			"c2": {"alice": models.RoleEditor},
+			"c3": {"alice": ""},
+		},
+	}
+	cols := []*models.Collection{{ID: "c1"}, {ID: "c2"}, {ID: "c3"}}
+	a := NewAuthorizer(store, []string{"root"})
+
+	ids := func(cols []*models.Collection) []string {
+		out := []string{}
+		for _, c := range cols {
+			out = append(out, c.ID)
+		}
+		return out
+	}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		perm Permission
+		want []string
+	}{
+		{"workspace role and overrides", caller("alice", auth.MethodJWT), PermRead, []string{"c1", "c2"}},
+		{"only the override allows writes", caller("alice", auth.MethodJWT), PermWrite, []string{"c2"}},
+		{"no role", caller("bob", auth.MethodJWT), PermRead, []string{}},
+		{"admin sees every collection", caller("root", auth.MethodJWT), PermManage, []string{"c1", "c2", "c3"}},
+		{"anonymous sees every collection", caller("", auth.MethodAnonymous), PermManage, []string{"c1", "c2", "c3"}},
+	}
+	for _, tt := range tests {
+		got, err := a.FilterCollections(tt.ctx, "w1", cols, tt.perm)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(ids(got), tt.want) {
+			t.Errorf("%s: kept %v, want %v", tt.name, ids(got), tt.want)
+		}
+	}
+}
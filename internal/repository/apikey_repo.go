@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"collectionsservice/internal/models"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository struct {
+	DB *gorm.DB
+}
+
+type APIKeyRepoInterface interface {
+	CreateAPIKey(ctx context.Context, key *models.APIKey) error
+	ListAPIKeys(ctx context.Context, subject string) ([]*models.APIKey, error)
+	GetAPIKey(ctx context.Context, id string) (*models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) error
+	FindAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	TouchAPIKey(ctx context.Context, id string, at time.Time) error
+}
+
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{
+		DB: db,
+	}
+}
+
+func (r *APIKeyRepository) CreateAPIKey(ctx context.Context, key *models.APIKey) error {
+	if err := r.DB.WithContext(ctx).Create(key).Error; err != nil {
+		log.Error().Err(err).Str("subject", key.Subject).Msg("Failed to create API key")
+		return err
+	}
+	log.Info().Str("key_id", key.ID).Str("subject", key.Subject).Msg("API key created")
+	return nil
+}
+
+func (r *APIKeyRepository) ListAPIKeys(ctx context.Context, subject string) ([]*models.APIKey, error) {
+	var keys []*models.APIKey
+	q := r.DB.WithContext(ctx).Order("created_at DESC")
+	if subject != "" {
+		q = q.Where("subject = ?", subject)
+	}
+	if err := q.Find(&keys).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to list API keys")
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepository) GetAPIKey(ctx context.Context, id string) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.DB.WithContext(ctx).First(&key, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *APIKeyRepository) RevokeAPIKey(ctx context.Context, id string) error {
+	res := r.DB.WithContext(ctx).Model(&models.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if res.Error != nil {
+		log.Error().Err(res.Error).Str("key_id", id).Msg("Failed to revoke API key")
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	log.Info().Str("key_id", id).Msg("API key revoked")
+	return nil
+}
+
+func (r *APIKeyRepository) FindAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.DB.WithContext(ctx).First(&key, "hash = ?", hash).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *APIKeyRepository) TouchAPIKey(ctx context.Context, id string, at time.Time) error {
+	return r.DB.WithContext(ctx).Model(&models.APIKey{}).Where("id = ?", id).Update("last_used_at", at).Error
+}
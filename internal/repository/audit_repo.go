@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"collectionsservice/internal/models"
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+type AuditRepoInterface interface {
+	CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error
+	ListAuditEvents(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEvent, error)
+}
+
+func (r *CollectionRepository) CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	if err := r.DB.WithContext(ctx).Create(event).Error; err != nil {
+		log.Error().Err(err).Str("method", event.Method).Str("subject", event.Subject).Msg("Failed to record audit event")
+		return err
+	}
+	return nil
+}
+
+// ListAuditEvents lists audit events newest first.
+func (r *CollectionRepository) ListAuditEvents(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEvent, error) {
+	q := r.DB.WithContext(ctx)
+	if filter.WorkspaceID != "" {
+		q = q.Where("workspace_id = ?", filter.WorkspaceID)
+	}
+	if filter.Subject != "" {
+		q = q.Where("subject = ?", filter.Subject)
+	}
+	if filter.Method != "" {
+		q = q.Where("method = ?", filter.Method)
+	}
+	if filter.CollectionID != "" {
+		q = q.Where("collection_id = ?", filter.CollectionID)
+	}
+	if filter.Outcome != "" {
+		q = q.Where("outcome = ?", filter.Outcome)
+	}
+	if !filter.Since.IsZero() {
+		q = q.Where("created_at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		q = q.Where("created_at < ?", filter.Until)
+	}
+	if filter.Before != nil {
+		q = q.Where("(created_at, id) < (?, ?)", filter.Before.CreatedAt, filter.Before.ID)
+	}
+
+	var events []*models.AuditEvent
+	if err := q.Order("created_at DESC, id DESC").Limit(filter.Limit).Find(&events).Error; err != nil {
+		log.Error().Err(err).Str("workspace_id", filter.WorkspaceID).Msg("Failed to list audit events")
+		return nil, err
+	}
+	return events, nil
+}
@@ -1,28 +1,54 @@
 package repository
 
 import (
+	"collectionsservice/internal/apperr"
 	"collectionsservice/internal/models"
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/rs/zerolog/log"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+var ErrCollectionNameTaken = apperr.AlreadyExists("collection", "", "a collection with this name already exists in the workspace")
+
+// VariablesUpdater turns a collection's stored variables into their new value.
+type VariablesUpdater func(stored datatypes.JSON) (datatypes.JSON, error)
+
 type CollectionRepository struct {
 	DB *gorm.DB
 }
 
 type CollectionRepoInterface interface {
 	CreateCollection(ctx context.Context, collection models.Collection) (string, error)
-	AddRequestToCollection(ctx context.Context, collectionName string, req []models.Request) error
-	GetCollectionByName(ctx context.Context, name string) (*models.Collection, error)
-	ListCollectionsAndRequests(ctx context.Context) ([]*models.Collection, error)
+	AddRequestToCollection(ctx context.Context, workspaceID, collectionName string, req []models.Request) error
+	AddRequestsToCollectionByID(ctx context.Context, collectionID string, reqs []models.Request) error
+	GetCollectionByName(ctx context.Context, workspaceID, name string) (*models.Collection, error)
+	CollectionNames(ctx context.Context, workspaceID string) ([]string, error)
+	ListCollectionsAndRequests(ctx context.Context, workspaceID string) ([]*models.Collection, error)
 	GetByID(ctx context.Context, id string) (*models.Collection, error)
 	Update(ctx context.Context, collection *models.Collection) (*models.Collection, error)
 	UpdateRequestInCollection(ctx context.Context, collectionID, requestID string, input *models.Request) (*models.UpdateRequestInCollectionResponse, error)
-	DeleteCollection(collectionID string) error
-	RemoveRequestFromCollection(collectionID, requestID string) error
+	DeleteCollection(ctx context.Context, collectionID string) error
+	RemoveRequestFromCollection(ctx context.Context, collectionID, requestID string) error
+	UpdateRequestsInCollection(ctx context.Context, collectionID string, inputs []models.Request) error
+	RemoveRequestsFromCollection(ctx context.Context, collectionID string, requestIDs []string) error
+	GetByIDWithRequests(ctx context.Context, id string) (*models.Collection, error)
+	ApplyMerge(ctx context.Context, targetID string, creates, updates []models.Request) error
+	UpdateVariables(ctx context.Context, collectionID string, update VariablesUpdater) error
+	ProposalRepoInterface
+	ShareRepoInterface
+	SecretRepoInterface
+	ExampleRepoInterface
+	RunRepoInterface
+	MonitorRepoInterface
+	WebhookRepoInterface
+	OutboxRepoInterface
+	AuditRepoInterface
+	IdempotencyRepoInterface
 }
 
 func NewCollectionRepository(db *gorm.DB) *CollectionRepository {
@@ -33,17 +59,23 @@ func NewCollectionRepository(db *gorm.DB) *CollectionRepository {
 
 func (r *CollectionRepository) CreateCollection(ctx context.Context, collection models.Collection) (string, error) {
 	log.Info().Str("name", collection.Name).Msg("Creating collection")
-	if err := r.DB.WithContext(ctx).Create(&collection).Error; err != nil {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&collection).Error; err != nil {
+			return err
+		}
+		return emit(ctx, tx, models.EventCollectionCreated, collection.WorkspaceID, collection.ID, map[string]interface{}{"name": collection.Name})
+	})
+	if err != nil {
 		log.Error().Err(err).Str("name", collection.Name).Msg("Failed to create collection")
-		return "", err
+		return "", translateCollectionError(err)
 	}
 	log.Info().Str("id", collection.ID).Msg("Collection created")
 	return collection.ID, nil
 }
 
-func (r *CollectionRepository) AddRequestToCollection(ctx context.Context, collectionName string, reqs []models.Request) error {
+func (r *CollectionRepository) AddRequestToCollection(ctx context.Context, workspaceID, collectionName string, reqs []models.Request) error {
 	var collection models.Collection
-	if err := r.DB.WithContext(ctx).Where("name = ?", collectionName).First(&collection).Error; err != nil {
+	if err := r.DB.WithContext(ctx).Where("workspace_id = ? AND name = ?", workspaceID, collectionName).First(&collection).Error; err != nil {
 		log.Error().Err(err).Str("collection_name", collectionName).Msg("Collection not found")
 		return err
 	}
@@ -52,7 +84,13 @@ func (r *CollectionRepository) AddRequestToCollection(ctx context.Context, colle
 		reqs[i].CollectionID = collection.ID
 	}
 
-	if err := r.DB.WithContext(ctx).Create(&reqs).Error; err != nil {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&reqs).Error; err != nil {
+			return err
+		}
+		return emit(ctx, tx, models.EventRequestAdded, collection.WorkspaceID, collection.ID, requestEventData(reqs))
+	})
+	if err != nil {
 		log.Error().Err(err).Str("collection_id", collection.ID).Msg("Failed to add requests")
 		return err
 	}
@@ -61,9 +99,36 @@ func (r *CollectionRepository) AddRequestToCollection(ctx context.Context, colle
 	return nil
 }
 
-func (r *CollectionRepository) GetCollectionByName(ctx context.Context, name string) (*models.Collection, error) {
+func (r *CollectionRepository) AddRequestsToCollectionByID(ctx context.Context, collectionID string, reqs []models.Request) error {
+	for i := range reqs {
+		reqs[i].CollectionID = collectionID
+	}
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.Collection{}).Where("id = ?", collectionID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Create(&reqs).Error; err != nil {
+			return err
+		}
+		return emitForCollection(ctx, tx, models.EventRequestAdded, collectionID, requestEventData(reqs))
+	})
+	if err != nil {
+		log.Error().Err(err).Str("collection_id", collectionID).Msg("Failed to add requests")
+		return err
+	}
+
+	log.Info().Str("collection_id", collectionID).Int("count", len(reqs)).Msg("Requests added")
+	return nil
+}
+
+func (r *CollectionRepository) GetCollectionByName(ctx context.Context, workspaceID, name string) (*models.Collection, error) {
 	var collection models.Collection
-	err := r.DB.WithContext(ctx).Preload("Requests").Where("name = ?", name).First(&collection).Error
+	err := r.DB.WithContext(ctx).Preload("Requests").Where("workspace_id = ? AND name = ?", workspaceID, name).First(&collection).Error
 	if err != nil {
 		log.Error().Err(err).Str("collection_name", name).Msg("Failed to get collection")
 		return nil, err
@@ -71,9 +136,19 @@ func (r *CollectionRepository) GetCollectionByName(ctx context.Context, name str
 	return &collection, nil
 }
 
-func (r *CollectionRepository) ListCollectionsAndRequests(ctx context.Context) ([]*models.Collection, error) {
+func (r *CollectionRepository) CollectionNames(ctx context.Context, workspaceID string) ([]string, error) {
+	var names []string
+	err := r.DB.WithContext(ctx).Model(&models.Collection{}).Where("workspace_id = ?", workspaceID).Pluck("name", &names).Error
+	if err != nil {
+		log.Error().Err(err).Str("workspace_id", workspaceID).Msg("Failed to list collection names")
+		return nil, err
+	}
+	return names, nil
+}
+
+func (r *CollectionRepository) ListCollectionsAndRequests(ctx context.Context, workspaceID string) ([]*models.Collection, error) {
 	var collections []*models.Collection
-	err := r.DB.WithContext(ctx).Preload("Requests").Find(&collections).Error
+	err := r.DB.WithContext(ctx).Preload("Requests").Where("workspace_id = ?", workspaceID).Find(&collections).Error
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to list collections")
 		return nil, err
@@ -92,9 +167,15 @@ func (r *CollectionRepository) GetByID(ctx context.Context, id string) (*models.
 
 func (r *CollectionRepository) Update(ctx context.Context, collection *models.Collection) (*models.Collection, error) {
 	log.Info().Str("collection_id", collection.ID).Msg("Updating collection")
-	if err := r.DB.WithContext(ctx).Save(collection).Error; err != nil {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(collection).Error; err != nil {
+			return err
+		}
+		return emit(ctx, tx, models.EventCollectionUpdated, collection.WorkspaceID, collection.ID, map[string]interface{}{"name": collection.Name})
+	})
+	if err != nil {
 		log.Error().Err(err).Str("collection_id", collection.ID).Msg("Update failed")
-		return nil, err
+		return nil, translateCollectionError(err)
 	}
 	log.Info().Str("collection_id", collection.ID).Msg("Collection updated")
 	return collection, nil
@@ -102,26 +183,21 @@ func (r *CollectionRepository) Update(ctx context.Context, collection *models.Co
 
 func (r *CollectionRepository) UpdateRequestInCollection(ctx context.Context, collectionID, requestID string, input *models.Request) (*models.UpdateRequestInCollectionResponse, error) {
 	var request models.Request
-	if err := r.DB.Where("id = ? AND collection_id = ?", requestID, collectionID).First(&request).Error; err != nil {
+	if err := r.DB.WithContext(ctx).Where("id = ? AND collection_id = ?", requestID, collectionID).First(&request).Error; err != nil {
 		log.Error().Err(err).Str("request_id", requestID).Msg("Request not found")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NotFound("request", requestID)
+		}
 		return nil, err
 	}
 
-	updates := map[string]interface{}{
-		"kind":               input.Kind,
-		"name":               input.Name,
-		"http_method":        input.HTTPMethod,
-		"http_url":           input.HTTPURL,
-		"http_headers":       input.HTTPHeaders,
-		"http_query_params":  input.HTTPQueryParams,
-		"http_body":          input.HTTPBody,
-		"graph_ql_endpoint":  input.GraphQLEndpoint,
-		"graph_ql_query":     input.GraphQLQuery,
-		"graph_ql_variables": input.GraphQLVariables,
-		"graph_ql_headers":   input.GraphQLHeaders,
-	}
-
-	if err := r.DB.Model(&request).Updates(updates).Error; err != nil {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&request).Updates(requestPatch(input)).Error; err != nil {
+			return err
+		}
+		return emitForCollection(ctx, tx, models.EventRequestUpdated, collectionID, requestEventData([]models.Request{request}))
+	})
+	if err != nil {
 		log.Error().Err(err).Str("request_id", requestID).Msg("Failed to update request")
 		return nil, err
 	}
@@ -133,9 +209,18 @@ func (r *CollectionRepository) UpdateRequestInCollection(ctx context.Context, co
 	}, nil
 }
 
-func (r *CollectionRepository) DeleteCollection(collectionID string) error {
+func (r *CollectionRepository) DeleteCollection(ctx context.Context, collectionID string) error {
 	log.Info().Str("collection_id", collectionID).Msg("Deleting collection")
-	err := r.DB.Delete(&models.Collection{}, "id = ?", collectionID).Error
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var collection models.Collection
+		if err := tx.Select("id", "workspace_id", "name").First(&collection, "id = ?", collectionID).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&collection).Error; err != nil {
+			return err
+		}
+		return emit(ctx, tx, models.EventCollectionDeleted, collection.WorkspaceID, collection.ID, map[string]interface{}{"name": collection.Name})
+	})
 	if err != nil {
 		log.Error().Err(err).Str("collection_id", collectionID).Msg("Delete failed")
 		return err
@@ -144,16 +229,25 @@ func (r *CollectionRepository) DeleteCollection(collectionID string) error {
 	return nil
 }
 
-func (r *CollectionRepository) RemoveRequestFromCollection(collectionID, requestID string) error {
+func (r *CollectionRepository) RemoveRequestFromCollection(ctx context.Context, collectionID, requestID string) error {
 	var request models.Request
 
-	err := r.DB.First(&request, "id = ? AND collection_id = ?", requestID, collectionID).Error
+	err := r.DB.WithContext(ctx).First(&request, "id = ? AND collection_id = ?", requestID, collectionID).Error
 	if err != nil {
 		log.Error().Err(err).Str("request_id", requestID).Str("collection_id", collectionID).Msg("Request not found in collection")
-		return errors.New("request not found in collection")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperr.NotFound("request", requestID)
+		}
+		return err
 	}
 
-	if err := r.DB.Delete(&request).Error; err != nil {
+	err = r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&request).Error; err != nil {
+			return err
+		}
+		return emitForCollection(ctx, tx, models.EventRequestRemoved, collectionID, requestEventData([]models.Request{request}))
+	})
+	if err != nil {
 		log.Error().Err(err).Msg("Failed to delete request")
 		return err
 	}
@@ -162,3 +256,205 @@ func (r *CollectionRepository) RemoveRequestFromCollection(collectionID, request
 	return nil
 }
 
+func (r *CollectionRepository) UpdateRequestsInCollection(ctx context.Context, collectionID string, inputs []models.Request) error {
+	ids := make([]string, 0, len(inputs))
+	for _, in := range inputs {
+		ids = append(ids, in.ID)
+	}
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureRequestsInCollection(tx, collectionID, ids, "requests[%d].request_id"); err != nil {
+			return err
+		}
+
+		for i := range inputs {
+			err := tx.Model(&models.Request{}).
+				Where("id = ? AND collection_id = ?", inputs[i].ID, collectionID).
+				Updates(requestPatch(&inputs[i])).Error
+			if err != nil {
+				return fmt.Errorf("failed to update request %s: %w", inputs[i].ID, err)
+			}
+		}
+		return emitForCollection(ctx, tx, models.EventRequestUpdated, collectionID, requestEventData(inputs))
+	})
+	if err != nil {
+		log.Error().Err(err).Str("collection_id", collectionID).Msg("Batch update failed")
+		return err
+	}
+
+	log.Info().Str("collection_id", collectionID).Int("count", len(inputs)).Msg("Requests updated")
+	return nil
+}
+
+func (r *CollectionRepository) RemoveRequestsFromCollection(ctx context.Context, collectionID string, requestIDs []string) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureRequestsInCollection(tx, collectionID, requestIDs, "request_ids[%d]"); err != nil {
+			return err
+		}
+		if err := tx.Where("collection_id = ? AND id IN ?", collectionID, requestIDs).Delete(&models.Request{}).Error; err != nil {
+			return err
+		}
+		return emitForCollection(ctx, tx, models.EventRequestRemoved, collectionID, map[string]interface{}{"request_ids": requestIDs})
+	})
+	if err != nil {
+		log.Error().Err(err).Str("collection_id", collectionID).Msg("Batch delete failed")
+		return err
+	}
+
+	log.Info().Str("collection_id", collectionID).Int("count", len(requestIDs)).Msg("Requests deleted from collection")
+	return nil
+}
+
+func (r *CollectionRepository) GetByIDWithRequests(ctx context.Context, id string) (*models.Collection, error) {
+	var collection models.Collection
+	if err := r.DB.WithContext(ctx).Preload("Requests").First(&collection, "id = ?", id).Error; err != nil {
+		log.Error().Err(err).Str("collection_id", id).Msg("Failed to fetch collection with requests")
+		return nil, err
+	}
+	return &collection, nil
+}
+
+func (r *CollectionRepository) UpdateVariables(ctx context.Context, collectionID string, update VariablesUpdater) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var collection models.Collection
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "workspace_id", "variables").
+			First(&collection, "id = ?", collectionID).Error; err != nil {
+			return err
+		}
+		vars, err := update(collection.Variables)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&collection).Update("variables", vars).Error; err != nil {
+			return err
+		}
+		return emit(ctx, tx, models.EventCollectionUpdated, collection.WorkspaceID, collection.ID, map[string]interface{}{"fields": []string{"variables"}})
+	})
+	if err != nil {
+		log.Error().Err(err).Str("collection_id", collectionID).Msg("Failed to update collection variables")
+		return err
+	}
+	log.Info().Str("collection_id", collectionID).Msg("Collection variables updated")
+	return nil
+}
+
+func (r *CollectionRepository) ApplyMerge(ctx context.Context, targetID string, creates, updates []models.Request) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(creates) > 0 {
+			if err := tx.Create(&creates).Error; err != nil {
+				return fmt.Errorf("failed to create merged requests: %w", err)
+			}
+		}
+		for i := range updates {
+			err := tx.Model(&models.Request{}).
+				Where("id = ? AND collection_id = ?", updates[i].ID, targetID).
+				Updates(requestUpdates(&updates[i])).Error
+			if err != nil {
+				return fmt.Errorf("failed to update request %s: %w", updates[i].ID, err)
+			}
+		}
+		if len(creates) > 0 {
+			if err := emitForCollection(ctx, tx, models.EventRequestAdded, targetID, requestEventData(creates)); err != nil {
+				return err
+			}
+		}
+		if len(updates) > 0 {
+			return emitForCollection(ctx, tx, models.EventRequestUpdated, targetID, requestEventData(updates))
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Str("collection_id", targetID).Msg("Merge failed")
+		return err
+	}
+
+	log.Info().Str("collection_id", targetID).Int("created", len(creates)).Int("updated", len(updates)).Msg("Collections merged")
+	return nil
+}
+
+func translateCollectionError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrCollectionNameTaken
+	}
+	return err
+}
+
+// ensureRequestsInCollection reports each id not in the collection as a
+// violation of field, a format taking the id's index in the batch.
+func ensureRequestsInCollection(tx *gorm.DB, collectionID string, requestIDs []string, field string) error {
+	var found []string
+	if err := tx.Model(&models.Request{}).
+		Where("collection_id = ? AND id IN ?", collectionID, requestIDs).
+		Pluck("id", &found).Error; err != nil {
+		return err
+	}
+	if len(found) == len(requestIDs) {
+		return nil
+	}
+
+	existing := make(map[string]bool, len(found))
+	for _, id := range found {
+		existing[id] = true
+	}
+	var missing []apperr.Violation
+	for i, id := range requestIDs {
+		if !existing[id] {
+			missing = append(missing, apperr.Violation{
+				Field:       fmt.Sprintf(field, i),
+				Description: fmt.Sprintf("request %q not found in collection", id),
+			})
+		}
+	}
+	return apperr.InvalidFields(missing...)
+}
+
+// requestUpdates lists every request column, so the stored request becomes
+// an exact copy of input; empty fields are written as NULL. Merges and
+// proposals rely on this to carry cleared fields over.
+func requestUpdates(input *models.Request) map[string]interface{} {
+	return map[string]interface{}{
+		"kind":               input.Kind,
+		"name":               input.Name,
+		"http_method":        input.HTTPMethod,
+		"http_url":           input.HTTPURL,
+		"http_headers":       input.HTTPHeaders,
+		"http_query_params":  input.HTTPQueryParams,
+		"http_body":          input.HTTPBody,
+		"graph_ql_endpoint":  input.GraphQLEndpoint,
+		"graph_ql_query":     input.GraphQLQuery,
+		"graph_ql_variables": input.GraphQLVariables,
+		"graph_ql_headers":   input.GraphQLHeaders,
+		"auth":               input.Auth,
+		"pre_request_script": input.PreRequestScript,
+		"test_script":        input.TestScript,
+		"assertions":         input.Assertions,
+		"captures":           input.Captures,
+	}
+}
+
+// requestPatch lists only the columns input sets. Fields it leaves empty are
+// left out, so a partial update keeps the stored values.
+func requestPatch(input *models.Request) map[string]interface{} {
+	updates := requestUpdates(input)
+	for column, value := range updates {
+		switch v := value.(type) {
+		case models.RequestKind:
+			if v == "" {
+				delete(updates, column)
+			}
+		case string:
+			if v == "" {
+				delete(updates, column)
+			}
+		case *string:
+			if v == nil {
+				delete(updates, column)
+			}
+		case datatypes.JSON:
+			if len(v) == 0 {
+				delete(updates, column)
+			}
+		}
+	}
+	return updates
+}
@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/merge"
+	"collectionsservice/internal/models"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gorm.io/datatypes"
+)
+
+var requestColumns = []string{"kind", "name", "http_method", "http_url", "http_headers", "http_query_params", "http_body",
+	"graph_ql_endpoint", "graph_ql_query", "graph_ql_variables", "graph_ql_headers",
+	"auth", "pre_request_script", "test_script", "assertions", "captures"}
+
+func TestRequestPatchKeepsUnsetFields(t *testing.T) {
+	script := "pm.test('ok', () => {})"
+	tests := []struct {
+		name  string
+		input models.Request
+		want  []string
+	}{
+		{
+			name:  "name only",
+			input: models.Request{Name: "renamed"},
+			want:  []string{"name"},
+		},
+		{
+			name: "auth and scripts",
+			input: models.Request{
+				Auth:       datatypes.JSON(`{"type":"bearer","token":"t"}`),
+				TestScript: &script,
+			},
+			want: []string{"auth", "test_script"},
+		},
+		{
+			name:  "nothing",
+			input: models.Request{},
+			want:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := requestPatch(&tt.input)
+			columns := make([]string, 0, len(got))
+			for _, c := range requestColumns {
+				if _, ok := got[c]; ok {
+					columns = append(columns, c)
+				}
+			}
+			if len(columns) != len(got) {
+				t.Fatalf("unexpected columns in %v", got)
+			}
+			if !reflect.DeepEqual(columns, tt.want) {
+				t.Errorf("columns = %v, want %v", columns, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestUpdatesWritesEveryColumn(t *testing.T) {
+	got := requestUpdates(&models.Request{Name: "only a name"})
+	if len(got) != len(requestColumns) {
+		t.Fatalf("wrote %d columns, want %d", len(got), len(requestColumns))
+	}
+	for _, c := range requestColumns {
+		if _, ok := got[c]; !ok {
+			t.Errorf("column %s missing", c)
+		}
+	}
+}
+
+func str(s string) *string { return &s }
+
+// wantCleared fails unless the single UPDATE of requests logged by fake sets
+// every column in cleared to NULL and every column in kept to its value.
+func wantCleared(t *testing.T, fake *fakeDB, cleared []string, kept map[string]driver.Value) {
+	t.Helper()
+	updates := fake.find(`UPDATE "requests"`)
+	if len(updates) != 1 {
+		t.Fatalf("logged %d request updates, want 1: %v", len(updates), fake.statements())
+	}
+	set := updates[0].assignments()
+	for _, c := range cleared {
+		v, ok := set[c]
+		if !ok {
+			t.Errorf("column %s not written, so the old value stays", c)
+		} else if v != nil {
+			t.Errorf("column %s = %v, want NULL", c, v)
+		}
+	}
+	for c, want := range kept {
+		if got := set[c]; got != want {
+			t.Errorf("column %s = %v, want %v", c, got, want)
+		}
+	}
+}
+
+func TestUpdateRequestInCollectionKeepsUnsetFields(t *testing.T) {
+	fake, db := newFakeDB(t)
+	fake.respond(`FROM "requests"`, []string{"id", "collection_id", "kind", "name", "http_body"},
+		[]driver.Value{"r1", "c1", "HTTP", "get", "old body"})
+	fake.respond(`SELECT "workspace_id" FROM "collections"`, []string{"workspace_id"}, []driver.Value{"w1"})
+	repo := NewCollectionRepository(db)
+
+	if _, err := repo.UpdateRequestInCollection(context.Background(), "c1", "r1", &models.Request{Name: "renamed"}); err != nil {
+		t.Fatal(err)
+	}
+
+	updates := fake.find(`UPDATE "requests"`)
+	if len(updates) != 1 {
+		t.Fatalf("logged %d request updates, want 1", len(updates))
+	}
+	if set := updates[0].assignments(); !reflect.DeepEqual(set, map[string]driver.Value{"name": "renamed"}) {
+		t.Errorf("update set %v, want only the name", set)
+	}
+}
+
+func TestApplyMergeClearsFields(t *testing.T) {
+	target := &models.Collection{ID: "target", Requests: []models.Request{{
+		ID: "t1", CollectionID: "target", Kind: models.RequestKindHTTP, Name: "get user",
+		HTTPMethod: str("POST"), HTTPURL: str("/users"), HTTPBody: str(`{"a":1}`),
+		HTTPHeaders: datatypes.JSON(`[{"key":"X-Trace","value":"1"}]`), TestScript: str("pm.test('x', () => {})"),
+	}}}
+	// The source cleared the body, headers and test script.
+	source := &models.Collection{ID: "source", Requests: []models.Request{{
+		ID: "s1", CollectionID: "source", Kind: models.RequestKindHTTP, Name: "get user",
+		HTTPMethod: str("POST"), HTTPURL: str("/users"),
+	}}}
+
+	plan := merge.BuildPlan(source, target, merge.MatchByName)
+	changes, err := plan.Resolve(target.ID, nil, merge.ResolutionTheirs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake, db := newFakeDB(t)
+	fake.respond(`SELECT "workspace_id" FROM "collections"`, []string{"workspace_id"}, []driver.Value{"w1"})
+	if err := NewCollectionRepository(db).ApplyMerge(context.Background(), target.ID, changes.Creates, changes.Updates); err != nil {
+		t.Fatal(err)
+	}
+
+	wantCleared(t, fake, []string{"http_body", "http_headers", "test_script"},
+		map[string]driver.Value{"http_method": "POST", "http_url": "/users"})
+}
+
+func TestApplyProposalClearsFields(t *testing.T) {
+	upstream := models.Request{
+		ID: "u1", CollectionID: "up", Kind: models.RequestKindHTTP, Name: "users",
+		HTTPMethod: str("GET"), HTTPURL: str("/users"), HTTPBody: str("body"),
+		HTTPHeaders: datatypes.JSON(`[{"key":"X-Trace","value":"1"}]`),
+	}
+	tests := []struct {
+		name    string
+		fork    models.Request
+		cleared []string
+		kept    map[string]driver.Value
+	}{
+		{
+			name: "body and headers removed",
+			fork: models.Request{
+				ID: "f1", CollectionID: "fork", Kind: models.RequestKindHTTP, Name: "users",
+				HTTPMethod: str("GET"), HTTPURL: str("/users"),
+			},
+			cleared: []string{"http_body", "http_headers"},
+			kept:    map[string]driver.Value{"http_url": "/users"},
+		},
+		{
+			name: "switched to GraphQL",
+			fork: models.Request{
+				ID: "f1", CollectionID: "fork", Kind: models.RequestKindGraphQL, Name: "users",
+				GraphQLEndpoint: str("/graphql"), GraphQLQuery: str("{ users { id } }"),
+			},
+			cleared: []string{"http_method", "http_url", "http_body", "http_headers"},
+			kept:    map[string]driver.Value{"kind": "GRAPHQL", "graph_ql_query": "{ users { id } }"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, db := newFakeDB(t)
+			fake.respond(`FROM "change_proposals"`, []string{"id", "status", "upstream_collection_id"},
+				[]driver.Value{"p1", string(models.ProposalStatusOpen), "up"})
+			fake.respond(`FROM "collections"`, []string{"id", "workspace_id", "name"}, []driver.Value{"up", "w1", "upstream"})
+			fake.respond(`FROM "requests"`,
+				[]string{"id", "collection_id", "kind", "name", "http_method", "http_url", "http_body", "http_headers"},
+				[]driver.Value{upstream.ID, upstream.CollectionID, string(upstream.Kind), upstream.Name,
+					*upstream.HTTPMethod, *upstream.HTTPURL, *upstream.HTTPBody, []byte(upstream.HTTPHeaders)})
+
+			fork := tt.fork
+			changes := []models.ProposalChange{{
+				Type:                models.ProposalChangeModified,
+				Name:                fork.Name,
+				ForkRequestID:       fork.ID,
+				UpstreamRequestID:   upstream.ID,
+				UpstreamFingerprint: merge.Fingerprint(upstream),
+				Request:             &fork,
+			}}
+			if err := NewCollectionRepository(db).ApplyProposal(context.Background(), "p1", "reviewer", changes); err != nil {
+				t.Fatal(err)
+			}
+
+			wantCleared(t, fake, tt.cleared, tt.kept)
+			if s := fake.statements(); s[len(s)-1] != "COMMIT" {
+				t.Errorf("last statement %q, want COMMIT", s[len(s)-1])
+			}
+		})
+	}
+}
+
+// wantRolledBack fails t unless the fake saw a transaction that rolled back
+// and none that committed.
+func wantRolledBack(t *testing.T, fake *fakeDB) {
+	t.Helper()
+	if len(fake.find("ROLLBACK")) != 1 || len(fake.find("COMMIT")) != 0 {
+		t.Errorf("statements %q, want the transaction rolled back", fake.statements())
+	}
+}
+
+func TestBatchReportsMissingRequests(t *testing.T) {
+	tests := []struct {
+		name      string
+		run       func(*CollectionRepository) error
+		wantField string
+	}{
+		{
+			name: "update",
+			run: func(r *CollectionRepository) error {
+				return r.UpdateRequestsInCollection(context.Background(), "c1", []models.Request{
+					{ID: "r1", Name: "one"}, {ID: "gone", Name: "two"}, {ID: "r3", Name: "three"},
+				})
+			},
+			wantField: "requests[1].request_id",
+		},
+		{
+			name: "delete",
+			run: func(r *CollectionRepository) error {
+				return r.RemoveRequestsFromCollection(context.Background(), "c1", []string{"r1", "r3", "gone"})
+			},
+			wantField: "request_ids[2]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, db := newFakeDB(t)
+			fake.respond(`SELECT "id" FROM "requests"`, []string{"id"}, []driver.Value{"r1"}, []driver.Value{"r3"})
+
+			err := tt.run(NewCollectionRepository(db))
+			appErr, ok := apperr.As(err)
+			if !ok || appErr.Kind != apperr.KindInvalidArgument {
+				t.Fatalf("error %v, want invalid argument", err)
+			}
+			want := []apperr.Violation{{Field: tt.wantField, Description: `request "gone" not found in collection`}}
+			if !reflect.DeepEqual(appErr.Violations, want) {
+				t.Errorf("violations %+v, want %+v", appErr.Violations, want)
+			}
+			if n := len(fake.find(`UPDATE "requests"`)) + len(fake.find(`DELETE FROM "requests"`)); n > 0 {
+				t.Errorf("%d requests changed before the check failed", n)
+			}
+			wantRolledBack(t, fake)
+		})
+	}
+}
+
+func TestBatchUpdateRollsBackOnFailure(t *testing.T) {
+	fake, db := newFakeDB(t)
+	fake.respond(`SELECT "id" FROM "requests"`, []string{"id"}, []driver.Value{"r1"}, []driver.Value{"r2"}, []driver.Value{"r3"})
+	fake.respond(`SELECT "workspace_id" FROM "collections"`, []string{"workspace_id"}, []driver.Value{"w1"})
+	// Only the second item sets a URL, so only its update fails.
+	fake.failExec[`"http_url"`] = errors.New("value too long")
+
+	err := NewCollectionRepository(db).UpdateRequestsInCollection(context.Background(), "c1", []models.Request{
+		{ID: "r1", Name: "one"}, {ID: "r2", HTTPURL: str("/two")}, {ID: "r3", Name: "three"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "r2") {
+		t.Fatalf("error %v, want the failure of r2", err)
+	}
+	if n := len(fake.find(`UPDATE "requests"`)); n != 2 {
+		t.Errorf("%d updates sent, want 2 before stopping", n)
+	}
+	wantRolledBack(t, fake)
+}
+
+func TestBatchAddRollsBackOnFailure(t *testing.T) {
+	fake, db := newFakeDB(t)
+	fake.respond(`SELECT count(*) FROM "collections"`, []string{"count"}, []driver.Value{int64(1)})
+	fake.respond(`SELECT "workspace_id" FROM "collections"`, []string{"workspace_id"}, []driver.Value{"w1"})
+	fake.failExec[`INSERT INTO "requests"`] = errors.New(`duplicate key value violates unique constraint "requests_pkey"`)
+
+	err := NewCollectionRepository(db).AddRequestsToCollectionByID(context.Background(), "c1", []models.Request{
+		{ID: "r1", Kind: models.RequestKindHTTP, Name: "one"}, {ID: "r1", Kind: models.RequestKindHTTP, Name: "two"},
+	})
+	if err == nil {
+		t.Fatal("batch add succeeded")
+	}
+	if len(fake.find(`INSERT INTO "requests"`)) != 1 {
+		t.Errorf("statements %q, want one insert for the batch", fake.statements())
+	}
+	wantRolledBack(t, fake)
+}
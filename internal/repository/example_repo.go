@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"collectionsservice/internal/models"
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type ExampleRepoInterface interface {
+	CreateExample(ctx context.Context, example *models.Example) error
+	GetExample(ctx context.Context, id string) (*models.Example, error)
+	ListExamples(ctx context.Context, collectionID, requestID string) ([]*models.Example, error)
+	UpdateExample(ctx context.Context, example *models.Example) error
+	DeleteExample(ctx context.Context, id string) error
+}
+
+func (r *CollectionRepository) CreateExample(ctx context.Context, example *models.Example) error {
+	if err := r.DB.WithContext(ctx).Omit("Request").Create(example).Error; err != nil {
+		log.Error().Err(err).Str("request_id", example.RequestID).Msg("Failed to create example")
+		return err
+	}
+	log.Info().Str("example_id", example.ID).Str("request_id", example.RequestID).Msg("Example created")
+	return nil
+}
+
+func (r *CollectionRepository) GetExample(ctx context.Context, id string) (*models.Example, error) {
+	var example models.Example
+	if err := r.DB.WithContext(ctx).Preload("Request").First(&example, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &example, nil
+}
+
+// ListExamples lists the examples of one request, or of every request in the
+// collection when requestID is empty.
+func (r *CollectionRepository) ListExamples(ctx context.Context, collectionID, requestID string) ([]*models.Example, error) {
+	var examples []*models.Example
+	q := r.DB.WithContext(ctx).
+		Joins("JOIN requests ON requests.id = examples.request_id").
+		Where("requests.collection_id = ?", collectionID).
+		Order("examples.created_at")
+	if requestID != "" {
+		q = q.Where("examples.request_id = ?", requestID)
+	}
+	if err := q.Find(&examples).Error; err != nil {
+		log.Error().Err(err).Str("collection_id", collectionID).Msg("Failed to list examples")
+		return nil, err
+	}
+	return examples, nil
+}
+
+func (r *CollectionRepository) UpdateExample(ctx context.Context, example *models.Example) error {
+	if err := r.DB.WithContext(ctx).Omit("Request").Save(example).Error; err != nil {
+		log.Error().Err(err).Str("example_id", example.ID).Msg("Failed to update example")
+		return err
+	}
+	log.Info().Str("example_id", example.ID).Msg("Example updated")
+	return nil
+}
+
+func (r *CollectionRepository) DeleteExample(ctx context.Context, id string) error {
+	res := r.DB.WithContext(ctx).Delete(&models.Example{}, "id = ?", id)
+	if res.Error != nil {
+		log.Error().Err(res.Error).Str("example_id", id).Msg("Failed to delete example")
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	log.Info().Str("example_id", id).Msg("Example deleted")
+	return nil
+}
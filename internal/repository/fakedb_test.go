@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeDB is a database/sql driver that logs every statement it is sent and
+// answers queries from canned rows, so repository methods can be run against
+// gorm's real Postgres dialect without a server. Transactions show up in the
+// log as BEGIN, COMMIT and ROLLBACK.
+type fakeDB struct {
+	mu        sync.Mutex
+	log       []statement
+	responses []response
+	// failExec makes every exec whose SQL contains the key return the error.
+	failExec map[string]error
+}
+
+type statement struct {
+	SQL  string
+	Args []driver.Value
+}
+
+// response answers the first query whose SQL contains match.
+type response struct {
+	match   string
+	columns []string
+	rows    [][]driver.Value
+}
+
+func newFakeDB(t *testing.T) (*fakeDB, *gorm.DB) {
+	t.Helper()
+	fake := &fakeDB{failExec: make(map[string]error)}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(fake)}), &gorm.Config{
+		TranslateError: true,
+		Logger:         logger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fake, db
+}
+
+// respond makes queries containing match return rows.
+func (f *fakeDB) respond(match string, columns []string, rows ...[]driver.Value) {
+	f.responses = append(f.responses, response{match: match, columns: columns, rows: rows})
+}
+
+// statements lists the logged SQL, transaction markers included.
+func (f *fakeDB) statements() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make([]string, 0, len(f.log))
+	for _, s := range f.log {
+		out = append(out, s.SQL)
+	}
+	return out
+}
+
+// find returns the logged statements whose SQL starts with prefix.
+func (f *fakeDB) find(prefix string) []statement {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []statement
+	for _, s := range f.log {
+		if strings.HasPrefix(s.SQL, prefix) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+var setColumn = regexp.MustCompile(`"(\w+)"=(?:\$(\d+)|NULL)`)
+
+// assignments maps each column an UPDATE sets to the value it is set to.
+func (s statement) assignments() map[string]driver.Value {
+	set := s.SQL
+	if i := strings.Index(set, " WHERE "); i >= 0 {
+		set = set[:i]
+	}
+	out := make(map[string]driver.Value)
+	for _, m := range setColumn.FindAllStringSubmatch(set, -1) {
+		if m[2] == "" {
+			out[m[1]] = nil
+			continue
+		}
+		n, _ := strconv.Atoi(m[2])
+		out[m[1]] = s.Args[n-1]
+	}
+	return out
+}
+
+func (f *fakeDB) record(query string, args []driver.NamedValue) {
+	values := make([]driver.Value, len(args))
+	for i, a := range args {
+		values[i] = a.Value
+	}
+	f.mu.Lock()
+	f.log = append(f.log, statement{SQL: query, Args: values})
+	f.mu.Unlock()
+}
+
+func (f *fakeDB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: f}, nil }
+func (f *fakeDB) Driver() driver.Driver                        { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("open through the connector")
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+func (c *fakeConn) Close() error { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	c.db.record("BEGIN", nil)
+	return fakeTx{db: c.db}, nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.record(query, args)
+	for match, err := range c.db.failExec {
+		if strings.Contains(query, match) {
+			return nil, err
+		}
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.db.record(query, args)
+	for _, r := range c.db.responses {
+		if strings.Contains(query, r.match) {
+			return &fakeRows{columns: r.columns, rows: r.rows}, nil
+		}
+	}
+	return &fakeRows{}, nil
+}
+
+type fakeTx struct {
+	db *fakeDB
+}
+
+func (t fakeTx) Commit() error {
+	t.db.record("COMMIT", nil)
+	return nil
+}
+
+func (t fakeTx) Rollback() error {
+	t.db.record("ROLLBACK", nil)
+	return nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"collectionsservice/internal/models"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyRepoInterface interface {
+	ReserveIdempotencyKey(ctx context.Context, key *models.IdempotencyKey) (*models.IdempotencyKey, error)
+	CompleteIdempotencyKey(ctx context.Context, key *models.IdempotencyKey) error
+	ReleaseIdempotencyKey(ctx context.Context, key *models.IdempotencyKey) error
+	PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int64, error)
+}
+
+func idempotencyKeyScope(tx *gorm.DB, key *models.IdempotencyKey) *gorm.DB {
+	return tx.Where("subject = ? AND workspace_id = ? AND method = ? AND key = ?", key.Subject, key.WorkspaceID, key.Method, key.Key)
+}
+
+// ReserveIdempotencyKey stores key for a call that is about to run. When the
+// key is already held, nothing is stored and the held key is returned
+// instead. A key that expired, or whose call never stored a response before
+// its lease ran out, is free again.
+func (r *CollectionRepository) ReserveIdempotencyKey(ctx context.Context, key *models.IdempotencyKey) (*models.IdempotencyKey, error) {
+	var held *models.IdempotencyKey
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := idempotencyKeyScope(tx, key).
+			Where("expires_at < ? OR (response IS NULL AND lease_expires_at < ?)", now, now).
+			Delete(&models.IdempotencyKey{}).Error
+		if err != nil {
+			return err
+		}
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(key)
+		if res.Error != nil || res.RowsAffected == 1 {
+			return res.Error
+		}
+		held = &models.IdempotencyKey{}
+		return idempotencyKeyScope(tx, key).First(held).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Str("method", key.Method).Str("subject", key.Subject).Msg("Failed to reserve idempotency key")
+		return nil, err
+	}
+	return held, nil
+}
+
+// CompleteIdempotencyKey stores the response of the call that reserved key.
+// A call whose lease ran out and whose key was taken over stores nothing.
+func (r *CollectionRepository) CompleteIdempotencyKey(ctx context.Context, key *models.IdempotencyKey) error {
+	err := idempotencyKeyScope(r.DB.WithContext(ctx), key).Model(&models.IdempotencyKey{}).
+		Where("response IS NULL AND lease_expires_at = ?", key.LeaseExpiresAt).
+		Update("response", key.Response).Error
+	if err != nil {
+		log.Error().Err(err).Str("method", key.Method).Str("subject", key.Subject).Msg("Failed to store idempotent response")
+	}
+	return err
+}
+
+// ReleaseIdempotencyKey frees a key whose call failed, so a retry runs the
+// call again.
+func (r *CollectionRepository) ReleaseIdempotencyKey(ctx context.Context, key *models.IdempotencyKey) error {
+	err := idempotencyKeyScope(r.DB.WithContext(ctx), key).
+		Where("response IS NULL AND lease_expires_at = ?", key.LeaseExpiresAt).
+		Delete(&models.IdempotencyKey{}).Error
+	if err != nil {
+		log.Error().Err(err).Str("method", key.Method).Str("subject", key.Subject).Msg("Failed to release idempotency key")
+	}
+	return err
+}
+
+// PurgeIdempotencyKeys deletes keys that expired before before.
+func (r *CollectionRepository) PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int64, error) {
+	res := r.DB.WithContext(ctx).Where("expires_at < ?", before).Delete(&models.IdempotencyKey{})
+	if res.Error != nil {
+		log.Error().Err(res.Error).Msg("Failed to purge idempotency keys")
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
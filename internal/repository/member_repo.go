@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/models"
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MemberRepository struct {
+	DB *gorm.DB
+}
+
+type MemberRepoInterface interface {
+	GetWorkspaceRole(ctx context.Context, workspaceID, subject string) (models.Role, error)
+	GetCollectionRole(ctx context.Context, collectionID, subject string) (models.Role, error)
+	GetCollectionRoles(ctx context.Context, collectionIDs []string, subject string) (map[string]models.Role, error)
+	ListWorkspaceIDsForSubject(ctx context.Context, subject string) ([]string, error)
+	GrantWorkspaceRole(ctx context.Context, member *models.WorkspaceMember) error
+	RevokeWorkspaceRole(ctx context.Context, workspaceID, subject string) error
+	ListWorkspaceMembers(ctx context.Context, workspaceID string) ([]*models.WorkspaceMember, error)
+	GrantCollectionRole(ctx context.Context, member *models.CollectionMember) error
+	RevokeCollectionRole(ctx context.Context, collectionID, subject string) error
+	ListCollectionMembers(ctx context.Context, collectionID string) ([]*models.CollectionMember, error)
+}
+
+func NewMemberRepository(db *gorm.DB) *MemberRepository {
+	return &MemberRepository{
+		DB: db,
+	}
+}
+
+func (r *MemberRepository) GetWorkspaceRole(ctx context.Context, workspaceID, subject string) (models.Role, error) {
+	var m models.WorkspaceMember
+	err := r.DB.WithContext(ctx).First(&m, "workspace_id = ? AND subject = ?", workspaceID, subject).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	return m.Role, err
+}
+
+func (r *MemberRepository) GetCollectionRole(ctx context.Context, collectionID, subject string) (models.Role, error) {
+	var m models.CollectionMember
+	err := r.DB.WithContext(ctx).First(&m, "collection_id = ? AND subject = ?", collectionID, subject).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	return m.Role, err
+}
+
+func (r *MemberRepository) GetCollectionRoles(ctx context.Context, collectionIDs []string, subject string) (map[string]models.Role, error) {
+	roles := make(map[string]models.Role)
+	if len(collectionIDs) == 0 {
+		return roles, nil
+	}
+
+	var members []models.CollectionMember
+	err := r.DB.WithContext(ctx).Where("collection_id IN ? AND subject = ?", collectionIDs, subject).Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range members {
+		roles[m.CollectionID] = m.Role
+	}
+	return roles, nil
+}
+
+func (r *MemberRepository) ListWorkspaceIDsForSubject(ctx context.Context, subject string) ([]string, error) {
+	var ids []string
+	err := r.DB.WithContext(ctx).Model(&models.WorkspaceMember{}).Where("subject = ?", subject).Pluck("workspace_id", &ids).Error
+	return ids, err
+}
+
+func (r *MemberRepository) GrantWorkspaceRole(ctx context.Context, member *models.WorkspaceMember) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if member.Role != models.RoleOwner {
+			if err := ensureAnotherOwner(tx, member.WorkspaceID, member.Subject); err != nil {
+				return err
+			}
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "workspace_id"}, {Name: "subject"}},
+			DoUpdates: clause.AssignmentColumns([]string{"role", "updated_at"}),
+		}).Create(member).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Str("workspace_id", member.WorkspaceID).Str("subject", member.Subject).Msg("Failed to grant workspace role")
+		return err
+	}
+	log.Info().Str("workspace_id", member.WorkspaceID).Str("subject", member.Subject).Str("role", string(member.Role)).Msg("Workspace role granted")
+	return nil
+}
+
+func (r *MemberRepository) RevokeWorkspaceRole(ctx context.Context, workspaceID, subject string) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureAnotherOwner(tx, workspaceID, subject); err != nil {
+			return err
+		}
+		res := tx.Delete(&models.WorkspaceMember{}, "workspace_id = ? AND subject = ?", workspaceID, subject)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Str("workspace_id", workspaceID).Str("subject", subject).Msg("Failed to revoke workspace role")
+		return err
+	}
+	log.Info().Str("workspace_id", workspaceID).Str("subject", subject).Msg("Workspace role revoked")
+	return nil
+}
+
+func (r *MemberRepository) ListWorkspaceMembers(ctx context.Context, workspaceID string) ([]*models.WorkspaceMember, error) {
+	var members []*models.WorkspaceMember
+	err := r.DB.WithContext(ctx).Where("workspace_id = ?", workspaceID).Order("subject").Find(&members).Error
+	return members, err
+}
+
+func (r *MemberRepository) GrantCollectionRole(ctx context.Context, member *models.CollectionMember) error {
+	err := r.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "collection_id"}, {Name: "subject"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "updated_at"}),
+	}).Create(member).Error
+	if err != nil {
+		log.Error().Err(err).Str("collection_id", member.CollectionID).Str("subject", member.Subject).Msg("Failed to grant collection role")
+		return err
+	}
+	log.Info().Str("collection_id", member.CollectionID).Str("subject", member.Subject).Str("role", string(member.Role)).Msg("Collection role granted")
+	return nil
+}
+
+func (r *MemberRepository) RevokeCollectionRole(ctx context.Context, collectionID, subject string) error {
+	res := r.DB.WithContext(ctx).Delete(&models.CollectionMember{}, "collection_id = ? AND subject = ?", collectionID, subject)
+	if res.Error != nil {
+		log.Error().Err(res.Error).Str("collection_id", collectionID).Str("subject", subject).Msg("Failed to revoke collection role")
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	log.Info().Str("collection_id", collectionID).Str("subject", subject).Msg("Collection role revoked")
+	return nil
+}
+
+func (r *MemberRepository) ListCollectionMembers(ctx context.Context, collectionID string) ([]*models.CollectionMember, error) {
+	var members []*models.CollectionMember
+	err := r.DB.WithContext(ctx).Where("collection_id = ?", collectionID).Order("subject").Find(&members).Error
+	return members, err
+}
+
+// ensureAnotherOwner refuses changes that would leave a workspace that has
+// owners without any.
+func ensureAnotherOwner(tx *gorm.DB, workspaceID, subject string) error {
+	var current models.WorkspaceMember
+	err := tx.First(&current, "workspace_id = ? AND subject = ?", workspaceID, subject).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if current.Role != models.RoleOwner {
+		return nil
+	}
+
+	var owners int64
+	if err := tx.Model(&models.WorkspaceMember{}).
+		Where("workspace_id = ? AND role = ?", workspaceID, models.RoleOwner).
+		Count(&owners).Error; err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return apperr.FailedPrecondition("LAST_OWNER", "workspace %s must keep at least one owner", workspaceID)
+	}
+	return nil
+}
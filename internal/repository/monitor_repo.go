@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"collectionsservice/internal/models"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type MonitorRepoInterface interface {
+	CreateMonitor(ctx context.Context, monitor *models.Monitor) error
+	GetMonitor(ctx context.Context, id string) (*models.Monitor, error)
+	ListMonitors(ctx context.Context, collectionID string) ([]*models.Monitor, error)
+	UpdateMonitor(ctx context.Context, monitor *models.Monitor) error
+	DeleteMonitor(ctx context.Context, id string) error
+	DueMonitors(ctx context.Context, now time.Time, limit int) ([]*models.Monitor, error)
+	ClaimMonitor(ctx context.Context, id string, due, next time.Time) (bool, error)
+	FinishMonitorRun(ctx context.Context, id string, status models.RunStatus, collectionRunID string, at time.Time) error
+}
+
+func (r *CollectionRepository) CreateMonitor(ctx context.Context, monitor *models.Monitor) error {
+	if err := r.DB.WithContext(ctx).Omit("Collection").Create(monitor).Error; err != nil {
+		log.Error().Err(err).Str("collection_id", monitor.CollectionID).Msg("Failed to create monitor")
+		return err
+	}
+	log.Info().Str("monitor_id", monitor.ID).Str("collection_id", monitor.CollectionID).Str("cron", monitor.Cron).Msg("Monitor created")
+	return nil
+}
+
+func (r *CollectionRepository) GetMonitor(ctx context.Context, id string) (*models.Monitor, error) {
+	var monitor models.Monitor
+	if err := r.DB.WithContext(ctx).First(&monitor, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &monitor, nil
+}
+
+func (r *CollectionRepository) ListMonitors(ctx context.Context, collectionID string) ([]*models.Monitor, error) {
+	var monitors []*models.Monitor
+	err := r.DB.WithContext(ctx).Where("collection_id = ?", collectionID).Order("created_at").Find(&monitors).Error
+	if err != nil {
+		log.Error().Err(err).Str("collection_id", collectionID).Msg("Failed to list monitors")
+		return nil, err
+	}
+	return monitors, nil
+}
+
+// UpdateMonitor saves a monitor's settings and schedule. The result of the
+// last run is left to FinishMonitorRun, which the scheduler may be writing
+// concurrently.
+func (r *CollectionRepository) UpdateMonitor(ctx context.Context, monitor *models.Monitor) error {
+	err := r.DB.WithContext(ctx).Model(monitor).
+		Select("name", "cron", "timezone", "enabled", "variables", "notify_url", "next_run_at", "updated_at").
+		Updates(monitor).Error
+	if err != nil {
+		log.Error().Err(err).Str("monitor_id", monitor.ID).Msg("Failed to update monitor")
+		return err
+	}
+	return nil
+}
+
+func (r *CollectionRepository) DeleteMonitor(ctx context.Context, id string) error {
+	res := r.DB.WithContext(ctx).Delete(&models.Monitor{}, "id = ?", id)
+	if res.Error != nil {
+		log.Error().Err(res.Error).Str("monitor_id", id).Msg("Failed to delete monitor")
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	log.Info().Str("monitor_id", id).Msg("Monitor deleted")
+	return nil
+}
+
+// DueMonitors lists enabled monitors whose next run is at or before now,
+// most overdue first.
+func (r *CollectionRepository) DueMonitors(ctx context.Context, now time.Time, limit int) ([]*models.Monitor, error) {
+	var monitors []*models.Monitor
+	err := r.DB.WithContext(ctx).
+		Where("enabled AND next_run_at <= ?", now).
+		Order("next_run_at").
+		Limit(limit).
+		Find(&monitors).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list due monitors")
+		return nil, err
+	}
+	return monitors, nil
+}
+
+// ClaimMonitor moves a monitor's next run from due to next. It reports false
+// when the monitor was claimed, rescheduled or disabled in the meantime, so a
+// scheduled run is only started once.
+func (r *CollectionRepository) ClaimMonitor(ctx context.Context, id string, due, next time.Time) (bool, error) {
+	res := r.DB.WithContext(ctx).Model(&models.Monitor{}).
+		Where("id = ? AND enabled AND next_run_at = ?", id, due).
+		Update("next_run_at", next)
+	if res.Error != nil {
+		log.Error().Err(res.Error).Str("monitor_id", id).Msg("Failed to claim monitor")
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+// FinishMonitorRun records the result of a monitor's latest run. An empty
+// collectionRunID means the collection could not be run.
+func (r *CollectionRepository) FinishMonitorRun(ctx context.Context, id string, status models.RunStatus, collectionRunID string, at time.Time) error {
+	var runID *string
+	if collectionRunID != "" {
+		runID = &collectionRunID
+	}
+	err := r.DB.WithContext(ctx).Model(&models.Monitor{}).Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"last_run_at":            at,
+			"last_status":            status,
+			"last_collection_run_id": runID,
+		}).Error
+	if err != nil {
+		log.Error().Err(err).Str("monitor_id", id).Msg("Failed to record monitor run")
+		return err
+	}
+	return nil
+}
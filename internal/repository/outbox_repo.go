@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"collectionsservice/internal/auth"
+	"collectionsservice/internal/models"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+const (
+	// outboxLockKey is the transaction-level advisory lock held while
+	// relaying, so only one replica publishes at a time and events keep
+	// their order.
+	outboxLockKey int64 = 0x6f7574626f78 // "outbox"
+	// outboxPositions is the sequence published events take their position
+	// from.
+	outboxPositions = "outbox_positions"
+)
+
+type OutboxRepoInterface interface {
+	RelayOutbox(ctx context.Context, limit int, publish func(*models.OutboxEvent) error) (int, error)
+	PurgeOutbox(ctx context.Context, before time.Time) (int64, error)
+	ListChanges(ctx context.Context, filter models.ChangeFilter) ([]*models.OutboxEvent, error)
+	ChangePositions(ctx context.Context) (oldest, latest int64, err error)
+}
+
+// RelayOutbox passes unpublished events to publish in order, marking each
+// published as it succeeds. It stops at the first failure, which is recorded
+// on the event and retried on the next call. It returns the number of
+// events published, or 0 when another replica is relaying.
+func (r *CollectionRepository) RelayOutbox(ctx context.Context, limit int, publish func(*models.OutboxEvent) error) (int, error) {
+	published := 0
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", outboxLockKey).Scan(&locked).Error; err != nil || !locked {
+			return err
+		}
+
+		var events []*models.OutboxEvent
+		if err := tx.Where("published_at IS NULL").Order("seq").Limit(limit).Find(&events).Error; err != nil {
+			return err
+		}
+		for _, e := range events {
+			if err := publish(e); err != nil {
+				msg := err.Error()
+				return tx.Model(e).Updates(map[string]interface{}{
+					"attempts":   gorm.Expr("attempts + 1"),
+					"last_error": msg,
+				}).Error
+			}
+			err := tx.Model(e).Updates(map[string]interface{}{
+				"published_at": time.Now(),
+				"position":     gorm.Expr("nextval('" + outboxPositions + "')"),
+			}).Error
+			if err != nil {
+				return err
+			}
+			published++
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to relay outbox events")
+		return 0, err
+	}
+	return published, nil
+}
+
+// PurgeOutbox deletes events published before before.
+func (r *CollectionRepository) PurgeOutbox(ctx context.Context, before time.Time) (int64, error) {
+	res := r.DB.WithContext(ctx).Where("published_at < ?", before).Delete(&models.OutboxEvent{})
+	if res.Error != nil {
+		log.Error().Err(res.Error).Msg("Failed to purge outbox events")
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
+
+// ListChanges lists published events in position order.
+func (r *CollectionRepository) ListChanges(ctx context.Context, filter models.ChangeFilter) ([]*models.OutboxEvent, error) {
+	q := r.DB.WithContext(ctx).Where("position > ?", filter.After)
+	if filter.WorkspaceID != "" {
+		q = q.Where("workspace_id = ?", filter.WorkspaceID)
+	}
+	if filter.CollectionID != "" {
+		q = q.Where("collection_id = ?", filter.CollectionID)
+	}
+
+	var events []*models.OutboxEvent
+	if err := q.Order("position").Limit(filter.Limit).Find(&events).Error; err != nil {
+		log.Error().Err(err).Int64("after", filter.After).Msg("Failed to list changes")
+		return nil, err
+	}
+	return events, nil
+}
+
+// ChangePositions returns the lowest and highest positions of the published
+// events still kept, or zeros when there are none.
+func (r *CollectionRepository) ChangePositions(ctx context.Context) (oldest, latest int64, err error) {
+	var row struct {
+		Oldest *int64
+		Latest *int64
+	}
+	err = r.DB.WithContext(ctx).Model(&models.OutboxEvent{}).
+		Select("MIN(position) AS oldest, MAX(position) AS latest").
+		Where("position IS NOT NULL").Scan(&row).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to read change positions")
+		return 0, 0, err
+	}
+	if row.Oldest == nil || row.Latest == nil {
+		return 0, 0, nil
+	}
+	return *row.Oldest, *row.Latest, nil
+}
+
+// emit records event in the outbox as part of tx, so it is published if and
+// only if tx commits.
+func emit(ctx context.Context, tx *gorm.DB, event, workspaceID, collectionID string, data map[string]interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&models.OutboxEvent{
+		ID:           uuid.New().String(),
+		Event:        event,
+		WorkspaceID:  workspaceID,
+		CollectionID: collectionID,
+		Actor:        auth.Subject(ctx, ""),
+		Data:         raw,
+	}).Error
+}
+
+// emitForCollection is emit for changes that only know the collection ID.
+func emitForCollection(ctx context.Context, tx *gorm.DB, event, collectionID string, data map[string]interface{}) error {
+	var workspaceIDs []string
+	if err := tx.Model(&models.Collection{}).Where("id = ?", collectionID).Pluck("workspace_id", &workspaceIDs).Error; err != nil {
+		return err
+	}
+	if len(workspaceIDs) == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return emit(ctx, tx, event, workspaceIDs[0], collectionID, data)
+}
+
+func requestEventData(reqs []models.Request) map[string]interface{} {
+	ids := make([]string, 0, len(reqs))
+	for _, req := range reqs {
+		ids = append(ids, req.ID)
+	}
+	return map[string]interface{}{"request_ids": ids}
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/merge"
+	"collectionsservice/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProposalRepoInterface interface {
+	ForkCollection(ctx context.Context, fork *models.Collection) error
+	SetProtected(ctx context.Context, collectionID string, protected bool) error
+	CreateProposal(ctx context.Context, proposal *models.ChangeProposal) error
+	GetProposal(ctx context.Context, id string) (*models.ChangeProposal, error)
+	ListProposals(ctx context.Context, workspaceID, upstreamID string, status models.ProposalStatus) ([]*models.ChangeProposal, error)
+	AddProposalComment(ctx context.Context, comment *models.ProposalComment) error
+	RejectProposal(ctx context.Context, id, reviewer string) error
+	ApplyProposal(ctx context.Context, id, reviewer string, changes []models.ProposalChange) error
+}
+
+func (r *CollectionRepository) ForkCollection(ctx context.Context, fork *models.Collection) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(fork).Error; err != nil {
+			return err
+		}
+		return emit(ctx, tx, models.EventCollectionCreated, fork.WorkspaceID, fork.ID, map[string]interface{}{
+			"name":           fork.Name,
+			"forked_from_id": *fork.ForkedFromID,
+		})
+	})
+	if err != nil {
+		log.Error().Err(err).Str("upstream_id", *fork.ForkedFromID).Msg("Failed to fork collection")
+		return translateCollectionError(err)
+	}
+	log.Info().Str("collection_id", fork.ID).Str("upstream_id", *fork.ForkedFromID).Msg("Collection forked")
+	return nil
+}
+
+func (r *CollectionRepository) SetProtected(ctx context.Context, collectionID string, protected bool) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.Collection{}).Where("id = ?", collectionID).Update("protected", protected)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return emitForCollection(ctx, tx, models.EventCollectionUpdated, collectionID, map[string]interface{}{"protected": protected})
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error().Err(err).Str("collection_id", collectionID).Msg("Failed to update collection protection")
+	}
+	return err
+}
+
+func (r *CollectionRepository) CreateProposal(ctx context.Context, proposal *models.ChangeProposal) error {
+	if err := r.DB.WithContext(ctx).Create(proposal).Error; err != nil {
+		log.Error().Err(err).Str("fork_id", proposal.ForkCollectionID).Msg("Failed to create change proposal")
+		return err
+	}
+	log.Info().Str("proposal_id", proposal.ID).Msg("Change proposal opened")
+	return nil
+}
+
+func (r *CollectionRepository) GetProposal(ctx context.Context, id string) (*models.ChangeProposal, error) {
+	var proposal models.ChangeProposal
+	err := r.DB.WithContext(ctx).
+		Preload("Comments", func(db *gorm.DB) *gorm.DB { return db.Order("created_at") }).
+		First(&proposal, "id = ?", id).Error
+	if err != nil {
+		log.Error().Err(err).Str("proposal_id", id).Msg("Failed to fetch change proposal")
+		return nil, err
+	}
+	return &proposal, nil
+}
+
+func (r *CollectionRepository) ListProposals(ctx context.Context, workspaceID, upstreamID string, status models.ProposalStatus) ([]*models.ChangeProposal, error) {
+	var proposals []*models.ChangeProposal
+	q := r.DB.WithContext(ctx).
+		Joins("JOIN collections ON collections.id = change_proposals.upstream_collection_id").
+		Where("collections.workspace_id = ?", workspaceID).
+		Order("change_proposals.created_at DESC")
+	if upstreamID != "" {
+		q = q.Where("change_proposals.upstream_collection_id = ?", upstreamID)
+	}
+	if status != "" {
+		q = q.Where("change_proposals.status = ?", status)
+	}
+	if err := q.Find(&proposals).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to list change proposals")
+		return nil, err
+	}
+	return proposals, nil
+}
+
+func (r *CollectionRepository) AddProposalComment(ctx context.Context, comment *models.ProposalComment) error {
+	if err := r.DB.WithContext(ctx).Create(comment).Error; err != nil {
+		log.Error().Err(err).Str("proposal_id", comment.ProposalID).Msg("Failed to add proposal comment")
+		return err
+	}
+	return nil
+}
+
+func (r *CollectionRepository) RejectProposal(ctx context.Context, id, reviewer string) error {
+	now := time.Now()
+	res := r.DB.WithContext(ctx).Model(&models.ChangeProposal{}).
+		Where("id = ? AND status = ?", id, models.ProposalStatusOpen).
+		Updates(map[string]interface{}{
+			"status":      models.ProposalStatusRejected,
+			"reviewed_by": reviewer,
+			"reviewed_at": now,
+		})
+	if res.Error != nil {
+		log.Error().Err(res.Error).Str("proposal_id", id).Msg("Failed to reject change proposal")
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return apperr.FailedPrecondition("PROPOSAL_NOT_OPEN", "change proposal %s is not open", id)
+	}
+	log.Info().Str("proposal_id", id).Msg("Change proposal rejected")
+	return nil
+}
+
+func (r *CollectionRepository) ApplyProposal(ctx context.Context, id, reviewer string, changes []models.ProposalChange) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var proposal models.ChangeProposal
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&proposal, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if proposal.Status != models.ProposalStatusOpen {
+			return apperr.FailedPrecondition("PROPOSAL_NOT_OPEN", "change proposal %s is not open", id)
+		}
+
+		var upstream models.Collection
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Requests").
+			First(&upstream, "id = ?", proposal.UpstreamCollectionID).Error; err != nil {
+			return err
+		}
+		current := make(map[string]models.Request, len(upstream.Requests))
+		for _, req := range upstream.Requests {
+			current[req.ID] = req
+		}
+
+		for _, c := range changes {
+			switch c.Type {
+			case models.ProposalChangeCollection:
+				if merge.CollectionFingerprint(&upstream) != c.UpstreamFingerprint {
+					return apperr.Aborted("UPSTREAM_CHANGED", "upstream collection changed since the proposal was opened")
+				}
+				if err := tx.Model(&upstream).Updates(collectionChangeUpdates(c.Diffs)).Error; err != nil {
+					return err
+				}
+			case models.ProposalChangeAdded:
+				req := *c.Request
+				req.CollectionID = upstream.ID
+				req.ForkedFromRequestID = nil
+				if err := tx.Create(&req).Error; err != nil {
+					return fmt.Errorf("failed to add request %q: %w", c.Name, err)
+				}
+			case models.ProposalChangeModified, models.ProposalChangeRemoved:
+				up, ok := current[c.UpstreamRequestID]
+				if !ok || merge.Fingerprint(up) != c.UpstreamFingerprint {
+					return apperr.Aborted("UPSTREAM_CHANGED", "upstream request %s changed since the proposal was opened", c.UpstreamRequestID)
+				}
+				if c.Type == models.ProposalChangeRemoved {
+					if err := tx.Delete(&models.Request{}, "id = ?", up.ID).Error; err != nil {
+						return err
+					}
+					continue
+				}
+				if err := tx.Model(&models.Request{}).Where("id = ?", up.ID).Updates(requestUpdates(c.Request)).Error; err != nil {
+					return fmt.Errorf("failed to update request %s: %w", up.ID, err)
+				}
+			}
+		}
+
+		err := tx.Model(&proposal).Updates(map[string]interface{}{
+			"status":      models.ProposalStatusApproved,
+			"reviewed_by": reviewer,
+			"reviewed_at": time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+		return emit(ctx, tx, models.EventCollectionUpdated, upstream.WorkspaceID, upstream.ID, map[string]interface{}{"proposal_id": proposal.ID})
+	})
+	if err != nil {
+		log.Error().Err(err).Str("proposal_id", id).Msg("Failed to apply change proposal")
+		return err
+	}
+
+	log.Info().Str("proposal_id", id).Int("changes", len(changes)).Msg("Change proposal approved and applied")
+	return nil
+}
+
+// collectionChangeUpdates maps the diffs of a collection change to the
+// columns they set. An empty proposed value clears the column.
+func collectionChangeUpdates(diffs []models.FieldChange) map[string]interface{} {
+	updates := make(map[string]interface{}, len(diffs))
+	for _, d := range diffs {
+		switch d.Field {
+		case "description", "pre_request_script", "test_script":
+			var value *string
+			if d.Proposed != "" {
+				value = &d.Proposed
+			}
+			updates[d.Field] = value
+		case "variables", "auth":
+			var value datatypes.JSON
+			if d.Proposed != "" {
+				value = datatypes.JSON(d.Proposed)
+			}
+			updates[d.Field] = value
+		}
+	}
+	return updates
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"collectionsservice/internal/models"
+	"testing"
+
+	"gorm.io/datatypes"
+)
+
+func TestCollectionChangeUpdates(t *testing.T) {
+	updates := collectionChangeUpdates([]models.FieldChange{
+		{Field: "description", Proposed: "new"},
+		{Field: "variables", Proposed: `[{"key":"host","value":"b"}]`},
+		{Field: "auth", Proposed: ""},
+		{Field: "test_script", Proposed: ""},
+		{Field: "unknown", Proposed: "x"},
+	})
+
+	if d, ok := updates["description"].(*string); !ok || d == nil || *d != "new" {
+		t.Errorf("description = %v", updates["description"])
+	}
+	if v, ok := updates["variables"].(datatypes.JSON); !ok || string(v) != `[{"key":"host","value":"b"}]` {
+		t.Errorf("variables = %v", updates["variables"])
+	}
+	if a, ok := updates["auth"].(datatypes.JSON); !ok || a != nil {
+		t.Errorf("auth = %v, want cleared", updates["auth"])
+	}
+	if s, ok := updates["test_script"].(*string); !ok || s != nil {
+		t.Errorf("test_script = %v, want cleared", updates["test_script"])
+	}
+	if _, ok := updates["unknown"]; ok {
+		t.Error("unknown field was applied")
+	}
+}
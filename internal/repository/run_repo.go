@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"collectionsservice/internal/models"
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+type RunRepoInterface interface {
+	CreateRun(ctx context.Context, run *models.RequestRun) error
+	GetRun(ctx context.Context, id string) (*models.RequestRun, error)
+	ListRuns(ctx context.Context, filter models.RunFilter) ([]*models.RequestRun, error)
+}
+
+func (r *CollectionRepository) CreateRun(ctx context.Context, run *models.RequestRun) error {
+	if err := r.DB.WithContext(ctx).Omit("Collection").Create(run).Error; err != nil {
+		log.Error().Err(err).Str("collection_id", run.CollectionID).Str("request_id", run.RequestID).Msg("Failed to record request run")
+		return err
+	}
+	return nil
+}
+
+func (r *CollectionRepository) GetRun(ctx context.Context, id string) (*models.RequestRun, error) {
+	var run models.RequestRun
+	if err := r.DB.WithContext(ctx).First(&run, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// ListRuns lists runs newest first, without their request and response
+// bodies.
+func (r *CollectionRepository) ListRuns(ctx context.Context, filter models.RunFilter) ([]*models.RequestRun, error) {
+	q := r.DB.WithContext(ctx).
+		Omit("request_body", "response_body").
+		Where("collection_id = ?", filter.CollectionID)
+	if filter.RequestID != "" {
+		q = q.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.CollectionRunID != "" {
+		q = q.Where("collection_run_id = ?", filter.CollectionRunID)
+	}
+	if filter.Status != "" {
+		q = q.Where("status = ?", filter.Status)
+	}
+	if filter.StatusCodeMin > 0 {
+		q = q.Where("status_code >= ?", filter.StatusCodeMin)
+	}
+	if filter.StatusCodeMax > 0 {
+		q = q.Where("status_code <= ?", filter.StatusCodeMax)
+	}
+	if !filter.Since.IsZero() {
+		q = q.Where("started_at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		q = q.Where("started_at < ?", filter.Until)
+	}
+
+	var runs []*models.RequestRun
+	if err := q.Order("started_at DESC, id DESC").Limit(filter.Limit).Find(&runs).Error; err != nil {
+		log.Error().Err(err).Str("collection_id", filter.CollectionID).Msg("Failed to list request runs")
+		return nil, err
+	}
+	return runs, nil
+}
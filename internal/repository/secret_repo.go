@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"collectionsservice/internal/models"
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// SecretRewriter returns the new value of a headers, variables or auth column
+// and whether it changed.
+type SecretRewriter func(raw []byte) (datatypes.JSON, bool, error)
+
+type SecretRepoInterface interface {
+	RewriteSecrets(ctx context.Context, rewrite SecretRewriter) (requests, collections int, err error)
+}
+
+const secretRewriteBatch = 200
+
+// RewriteSecrets passes every stored headers and variables column through
+// rewrite and saves the ones that changed.
+func (r *CollectionRepository) RewriteSecrets(ctx context.Context, rewrite SecretRewriter) (int, int, error) {
+	var requests, collections int
+
+	var reqBatch []models.Request
+	res := r.DB.WithContext(ctx).
+		Select("id", "http_headers", "graph_ql_headers", "auth").
+		FindInBatches(&reqBatch, secretRewriteBatch, func(tx *gorm.DB, _ int) error {
+			for _, req := range reqBatch {
+				httpHeaders, httpChanged, err := rewrite(req.HTTPHeaders)
+				if err != nil {
+					return err
+				}
+				gqlHeaders, gqlChanged, err := rewrite(req.GraphQLHeaders)
+				if err != nil {
+					return err
+				}
+				auth, authChanged, err := rewrite(req.Auth)
+				if err != nil {
+					return err
+				}
+				if !httpChanged && !gqlChanged && !authChanged {
+					continue
+				}
+				err = r.DB.WithContext(ctx).Model(&models.Request{}).Where("id = ?", req.ID).
+					Updates(map[string]interface{}{
+						"http_headers":     httpHeaders,
+						"graph_ql_headers": gqlHeaders,
+						"auth":             auth,
+					}).Error
+				if err != nil {
+					return err
+				}
+				requests++
+			}
+			return nil
+		})
+	if res.Error != nil {
+		log.Error().Err(res.Error).Msg("Failed to rewrite request secrets")
+		return requests, collections, res.Error
+	}
+
+	var colBatch []models.Collection
+	res = r.DB.WithContext(ctx).
+		Select("id", "variables", "auth").
+		FindInBatches(&colBatch, secretRewriteBatch, func(tx *gorm.DB, _ int) error {
+			for _, col := range colBatch {
+				vars, varsChanged, err := rewrite(col.Variables)
+				if err != nil {
+					return err
+				}
+				auth, authChanged, err := rewrite(col.Auth)
+				if err != nil {
+					return err
+				}
+				if !varsChanged && !authChanged {
+					continue
+				}
+				err = r.DB.WithContext(ctx).Model(&models.Collection{}).Where("id = ?", col.ID).
+					Updates(map[string]interface{}{
+						"variables": vars,
+						"auth":      auth,
+					}).Error
+				if err != nil {
+					return err
+				}
+				collections++
+			}
+			return nil
+		})
+	if res.Error != nil {
+		log.Error().Err(res.Error).Msg("Failed to rewrite collection secrets")
+		return requests, collections, res.Error
+	}
+
+	log.Info().Int("requests", requests).Int("collections", collections).Msg("Secrets rewritten")
+	return requests, collections, nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"collectionsservice/internal/models"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ShareRepoInterface interface {
+	CreateShare(ctx context.Context, share *models.CollectionShare) error
+	ListShares(ctx context.Context, collectionID string) ([]*models.CollectionShare, error)
+	GetShare(ctx context.Context, id string) (*models.CollectionShare, error)
+	RevokeShare(ctx context.Context, id string) error
+	FindShareByHash(ctx context.Context, hash string) (*models.CollectionShare, error)
+}
+
+func (r *CollectionRepository) CreateShare(ctx context.Context, share *models.CollectionShare) error {
+	if err := r.DB.WithContext(ctx).Omit("Collection").Create(share).Error; err != nil {
+		log.Error().Err(err).Str("collection_id", share.CollectionID).Msg("Failed to create collection share")
+		return err
+	}
+	log.Info().Str("share_id", share.ID).Str("collection_id", share.CollectionID).Str("access", string(share.Access)).Msg("Collection shared")
+	return nil
+}
+
+func (r *CollectionRepository) ListShares(ctx context.Context, collectionID string) ([]*models.CollectionShare, error) {
+	var shares []*models.CollectionShare
+	err := r.DB.WithContext(ctx).Where("collection_id = ?", collectionID).Order("created_at DESC").Find(&shares).Error
+	if err != nil {
+		log.Error().Err(err).Str("collection_id", collectionID).Msg("Failed to list collection shares")
+		return nil, err
+	}
+	return shares, nil
+}
+
+func (r *CollectionRepository) GetShare(ctx context.Context, id string) (*models.CollectionShare, error) {
+	var share models.CollectionShare
+	if err := r.DB.WithContext(ctx).First(&share, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
+
+func (r *CollectionRepository) RevokeShare(ctx context.Context, id string) error {
+	res := r.DB.WithContext(ctx).Model(&models.CollectionShare{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if res.Error != nil {
+		log.Error().Err(res.Error).Str("share_id", id).Msg("Failed to revoke collection share")
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("share %s is already revoked", id)
+	}
+	log.Info().Str("share_id", id).Msg("Collection share revoked")
+	return nil
+}
+
+func (r *CollectionRepository) FindShareByHash(ctx context.Context, hash string) (*models.CollectionShare, error) {
+	var share models.CollectionShare
+	err := r.DB.WithContext(ctx).
+		Preload("Collection").
+		Preload("Collection.Requests").
+		First(&share, "hash = ?", hash).Error
+	if err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
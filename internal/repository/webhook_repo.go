@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"collectionsservice/internal/models"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type WebhookRepoInterface interface {
+	CreateWebhook(ctx context.Context, webhook *models.Webhook) error
+	GetWebhook(ctx context.Context, id string) (*models.Webhook, error)
+	ListWebhooks(ctx context.Context, workspaceID string) ([]*models.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *models.Webhook) error
+	DeleteWebhook(ctx context.Context, id string) error
+	CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error
+	ClaimDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*models.WebhookDelivery, error)
+	RecordDeliveryAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) error
+	ListDeliveries(ctx context.Context, webhookID string, status models.DeliveryStatus, limit int) ([]*models.WebhookDelivery, error)
+	RewriteWebhookSecrets(ctx context.Context, rewrite func(string) (string, bool, error)) (int, error)
+}
+
+func (r *CollectionRepository) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	if err := r.DB.WithContext(ctx).Create(webhook).Error; err != nil {
+		log.Error().Err(err).Str("workspace_id", webhook.WorkspaceID).Msg("Failed to create webhook")
+		return err
+	}
+	log.Info().Str("webhook_id", webhook.ID).Str("workspace_id", webhook.WorkspaceID).Msg("Webhook created")
+	return nil
+}
+
+func (r *CollectionRepository) GetWebhook(ctx context.Context, id string) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := r.DB.WithContext(ctx).First(&webhook, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *CollectionRepository) ListWebhooks(ctx context.Context, workspaceID string) ([]*models.Webhook, error) {
+	var webhooks []*models.Webhook
+	err := r.DB.WithContext(ctx).Where("workspace_id = ?", workspaceID).Order("created_at").Find(&webhooks).Error
+	if err != nil {
+		log.Error().Err(err).Str("workspace_id", workspaceID).Msg("Failed to list webhooks")
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (r *CollectionRepository) UpdateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	if err := r.DB.WithContext(ctx).Save(webhook).Error; err != nil {
+		log.Error().Err(err).Str("webhook_id", webhook.ID).Msg("Failed to update webhook")
+		return err
+	}
+	return nil
+}
+
+func (r *CollectionRepository) DeleteWebhook(ctx context.Context, id string) error {
+	res := r.DB.WithContext(ctx).Delete(&models.Webhook{}, "id = ?", id)
+	if res.Error != nil {
+		log.Error().Err(res.Error).Str("webhook_id", id).Msg("Failed to delete webhook")
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	log.Info().Str("webhook_id", id).Msg("Webhook deleted")
+	return nil
+}
+
+func (r *CollectionRepository) CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := r.DB.WithContext(ctx).Omit("Webhook", "Attempts").Create(&deliveries).Error; err != nil {
+		log.Error().Err(err).Str("event", deliveries[0].Event).Msg("Failed to queue webhook deliveries")
+		return err
+	}
+	return nil
+}
+
+// ClaimDeliveries picks pending deliveries that are due and pushes their
+// next attempt back by lease, so no other replica sends them meanwhile.
+// Each claimed delivery is loaded with its webhook.
+func (r *CollectionRepository) ClaimDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Raw(`
+			UPDATE webhook_deliveries SET next_attempt_at = ?
+			WHERE id IN (
+				SELECT id FROM webhook_deliveries
+				WHERE status = ? AND next_attempt_at <= ?
+				ORDER BY next_attempt_at
+				LIMIT ?
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id`, now.Add(lease), models.DeliveryPending, now, limit).
+			Scan(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+		ids := make([]string, len(deliveries))
+		for i, d := range deliveries {
+			ids[i] = d.ID
+		}
+		deliveries = nil
+		return tx.Preload("Webhook").Where("id IN ?", ids).Find(&deliveries).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to claim webhook deliveries")
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// RecordDeliveryAttempt logs attempt and saves the delivery's new state.
+func (r *CollectionRepository) RecordDeliveryAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(attempt).Error; err != nil {
+			return err
+		}
+		return tx.Model(delivery).
+			Select("status", "attempt_count", "next_attempt_at", "last_status_code", "last_error", "delivered_at", "updated_at").
+			Updates(delivery).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Str("delivery_id", delivery.ID).Msg("Failed to record webhook attempt")
+		return err
+	}
+	return nil
+}
+
+// ListDeliveries lists a webhook's deliveries newest first, with every
+// attempt.
+func (r *CollectionRepository) ListDeliveries(ctx context.Context, webhookID string, status models.DeliveryStatus, limit int) ([]*models.WebhookDelivery, error) {
+	q := r.DB.WithContext(ctx).
+		Preload("Attempts", func(db *gorm.DB) *gorm.DB { return db.Order("number") }).
+		Where("webhook_id = ?", webhookID)
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+
+	var deliveries []*models.WebhookDelivery
+	if err := q.Order("created_at DESC").Limit(limit).Find(&deliveries).Error; err != nil {
+		log.Error().Err(err).Str("webhook_id", webhookID).Msg("Failed to list webhook deliveries")
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// RewriteWebhookSecrets passes every webhook secret through rewrite and
+// saves the ones that changed.
+func (r *CollectionRepository) RewriteWebhookSecrets(ctx context.Context, rewrite func(string) (string, bool, error)) (int, error) {
+	var webhooks []*models.Webhook
+	if err := r.DB.WithContext(ctx).Select("id", "secret").Find(&webhooks).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to load webhook secrets")
+		return 0, err
+	}
+
+	updated := 0
+	for _, w := range webhooks {
+		secret, changed, err := rewrite(w.Secret)
+		if err != nil {
+			return updated, err
+		}
+		if !changed {
+			continue
+		}
+		if err := r.DB.WithContext(ctx).Model(&models.Webhook{}).Where("id = ?", w.ID).Update("secret", secret).Error; err != nil {
+			log.Error().Err(err).Str("webhook_id", w.ID).Msg("Failed to rewrite webhook secret")
+			return updated, err
+		}
+		updated++
+	}
+	return updated, nil
+}
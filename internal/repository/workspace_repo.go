@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/models"
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type WorkspaceRepository struct {
+	DB *gorm.DB
+}
+
+type WorkspaceRepoInterface interface {
+	CreateWorkspace(ctx context.Context, ws *models.Workspace) error
+	GetWorkspace(ctx context.Context, id string) (*models.Workspace, error)
+	ListWorkspaces(ctx context.Context) ([]*models.Workspace, error)
+	UpdateWorkspace(ctx context.Context, ws *models.Workspace) error
+	DeleteWorkspace(ctx context.Context, id string, force bool) error
+	CountCollections(ctx context.Context, workspaceID string) (int64, error)
+}
+
+func NewWorkspaceRepository(db *gorm.DB) *WorkspaceRepository {
+	return &WorkspaceRepository{
+		DB: db,
+	}
+}
+
+func (r *WorkspaceRepository) CreateWorkspace(ctx context.Context, ws *models.Workspace) error {
+	if err := r.DB.WithContext(ctx).Create(ws).Error; err != nil {
+		log.Error().Err(err).Str("name", ws.Name).Msg("Failed to create workspace")
+		return err
+	}
+	log.Info().Str("workspace_id", ws.ID).Msg("Workspace created")
+	return nil
+}
+
+func (r *WorkspaceRepository) GetWorkspace(ctx context.Context, id string) (*models.Workspace, error) {
+	var ws models.Workspace
+	if err := r.DB.WithContext(ctx).First(&ws, "id = ?", id).Error; err != nil {
+		log.Error().Err(err).Str("workspace_id", id).Msg("Failed to fetch workspace")
+		return nil, err
+	}
+	return &ws, nil
+}
+
+func (r *WorkspaceRepository) ListWorkspaces(ctx context.Context) ([]*models.Workspace, error) {
+	var workspaces []*models.Workspace
+	if err := r.DB.WithContext(ctx).Order("name").Find(&workspaces).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to list workspaces")
+		return nil, err
+	}
+	return workspaces, nil
+}
+
+func (r *WorkspaceRepository) UpdateWorkspace(ctx context.Context, ws *models.Workspace) error {
+	if err := r.DB.WithContext(ctx).Save(ws).Error; err != nil {
+		log.Error().Err(err).Str("workspace_id", ws.ID).Msg("Failed to update workspace")
+		return err
+	}
+	log.Info().Str("workspace_id", ws.ID).Msg("Workspace updated")
+	return nil
+}
+
+func (r *WorkspaceRepository) DeleteWorkspace(ctx context.Context, id string, force bool) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.Collection{}).Where("workspace_id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 && !force {
+			return apperr.FailedPrecondition("WORKSPACE_NOT_EMPTY", "workspace still owns %d collections", count)
+		}
+		if count > 0 {
+			var collections []models.Collection
+			if err := tx.Select("id", "name").Where("workspace_id = ?", id).Find(&collections).Error; err != nil {
+				return err
+			}
+			for _, col := range collections {
+				if err := emit(ctx, tx, models.EventCollectionDeleted, id, col.ID, map[string]interface{}{"name": col.Name, "workspace_deleted": true}); err != nil {
+					return err
+				}
+			}
+		}
+
+		res := tx.Delete(&models.Workspace{}, "id = ?", id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Str("workspace_id", id).Msg("Failed to delete workspace")
+		return err
+	}
+	log.Info().Str("workspace_id", id).Msg("Workspace deleted")
+	return nil
+}
+
+func (r *WorkspaceRepository) CountCollections(ctx context.Context, workspaceID string) (int64, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).Model(&models.Collection{}).Where("workspace_id = ?", workspaceID).Count(&count).Error
+	return count, err
+}
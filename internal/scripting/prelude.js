@@ -0,0 +1,328 @@
+// The pm API exposed to scripts. __host is provided by the Go runtime.
+var pm = (function (host) {
+	"use strict";
+
+	function AssertionError(message) {
+		this.name = "AssertionError";
+		this.message = message;
+	}
+	AssertionError.prototype = Object.create(Error.prototype);
+	AssertionError.prototype.constructor = AssertionError;
+
+	function show(v) {
+		if (typeof v === "string") return JSON.stringify(v);
+		if (v === undefined) return "undefined";
+		if (typeof v === "function") return "[function]";
+		try {
+			return JSON.stringify(v);
+		} catch (e) {
+			return String(v);
+		}
+	}
+
+	function deepEqual(a, b) {
+		if (a === b) return true;
+		if (typeof a !== "object" || typeof b !== "object" || a === null || b === null) {
+			return a !== a && b !== b;
+		}
+		if (Array.isArray(a) !== Array.isArray(b)) return false;
+		var ka = Object.keys(a), kb = Object.keys(b);
+		if (ka.length !== kb.length) return false;
+		for (var i = 0; i < ka.length; i++) {
+			if (!Object.prototype.hasOwnProperty.call(b, ka[i]) || !deepEqual(a[ka[i]], b[ka[i]])) return false;
+		}
+		return true;
+	}
+
+	function includes(haystack, needle, deep) {
+		if (typeof haystack === "string") return haystack.indexOf(String(needle)) !== -1;
+		if (Array.isArray(haystack)) {
+			for (var i = 0; i < haystack.length; i++) {
+				if (deep ? deepEqual(haystack[i], needle) : haystack[i] === needle) return true;
+			}
+			return false;
+		}
+		if (haystack !== null && typeof haystack === "object" && needle !== null && typeof needle === "object") {
+			for (var k in needle) {
+				if (!deepEqual(haystack[k], needle[k])) return false;
+			}
+			return true;
+		}
+		return false;
+	}
+
+	function typeOf(v) {
+		if (v === null) return "null";
+		if (Array.isArray(v)) return "array";
+		if (v instanceof RegExp) return "regexp";
+		return typeof v;
+	}
+
+	// Assertion implements the commonly used part of chai's expect API.
+	function Assertion(value, flags) {
+		this._value = value;
+		this._flags = flags || {};
+	}
+
+	Assertion.prototype._with = function (flag) {
+		var flags = {};
+		for (var k in this._flags) flags[k] = this._flags[k];
+		flags[flag] = flag === "not" ? !this._flags.not : true;
+		return new Assertion(this._value, flags);
+	};
+
+	Assertion.prototype._assert = function (ok, message, negated) {
+		if (this._flags.not ? ok : !ok) {
+			throw new AssertionError(this._flags.not ? negated : message);
+		}
+		return this;
+	};
+
+	["to", "be", "been", "is", "that", "which", "and", "has", "have", "with", "at", "of", "same", "does"].forEach(function (word) {
+		Object.defineProperty(Assertion.prototype, word, { get: function () { return this; } });
+	});
+	["not", "deep"].forEach(function (flag) {
+		Object.defineProperty(Assertion.prototype, flag, { get: function () { return this._with(flag); } });
+	});
+
+	var checks = {
+		ok: [function (v) { return !!v; }, "to be truthy"],
+		true: [function (v) { return v === true; }, "to be true"],
+		false: [function (v) { return v === false; }, "to be false"],
+		null: [function (v) { return v === null; }, "to be null"],
+		undefined: [function (v) { return v === undefined; }, "to be undefined"],
+		exist: [function (v) { return v !== null && v !== undefined; }, "to exist"],
+		empty: [function (v) {
+			if (typeof v === "string" || Array.isArray(v)) return v.length === 0;
+			if (v !== null && typeof v === "object") return Object.keys(v).length === 0;
+			return false;
+		}, "to be empty"]
+	};
+	Object.keys(checks).forEach(function (name) {
+		var check = checks[name];
+		Object.defineProperty(Assertion.prototype, name, {
+			get: function () {
+				var v = this._value;
+				return this._assert(check[0](v), "expected " + show(v) + " " + check[1], "expected " + show(v) + " not " + check[1]);
+			}
+		});
+	});
+
+	Assertion.prototype.equal = function (expected) {
+		var v = this._value;
+		var ok = this._flags.deep ? deepEqual(v, expected) : v === expected;
+		return this._assert(ok, "expected " + show(v) + " to equal " + show(expected), "expected " + show(v) + " not to equal " + show(expected));
+	};
+	Assertion.prototype.equals = Assertion.prototype.eq = Assertion.prototype.equal;
+
+	Assertion.prototype.eql = function (expected) {
+		var v = this._value;
+		return this._assert(deepEqual(v, expected), "expected " + show(v) + " to deeply equal " + show(expected), "expected " + show(v) + " not to deeply equal " + show(expected));
+	};
+
+	Assertion.prototype.above = function (n) {
+		var v = this._value;
+		return this._assert(v > n, "expected " + show(v) + " to be above " + n, "expected " + show(v) + " to be at most " + n);
+	};
+	Assertion.prototype.gt = Assertion.prototype.greaterThan = Assertion.prototype.above;
+
+	Assertion.prototype.below = function (n) {
+		var v = this._value;
+		return this._assert(v < n, "expected " + show(v) + " to be below " + n, "expected " + show(v) + " to be at least " + n);
+	};
+	Assertion.prototype.lt = Assertion.prototype.lessThan = Assertion.prototype.below;
+
+	Assertion.prototype.least = function (n) {
+		var v = this._value;
+		return this._assert(v >= n, "expected " + show(v) + " to be at least " + n, "expected " + show(v) + " to be below " + n);
+	};
+	Assertion.prototype.gte = Assertion.prototype.least;
+
+	Assertion.prototype.most = function (n) {
+		var v = this._value;
+		return this._assert(v <= n, "expected " + show(v) + " to be at most " + n, "expected " + show(v) + " to be above " + n);
+	};
+	Assertion.prototype.lte = Assertion.prototype.most;
+
+	Assertion.prototype.within = function (lo, hi) {
+		var v = this._value;
+		return this._assert(v >= lo && v <= hi, "expected " + show(v) + " to be within " + lo + ".." + hi, "expected " + show(v) + " not to be within " + lo + ".." + hi);
+	};
+
+	Assertion.prototype.include = function (needle) {
+		var v = this._value;
+		return this._assert(includes(v, needle, this._flags.deep), "expected " + show(v) + " to include " + show(needle), "expected " + show(v) + " not to include " + show(needle));
+	};
+	Assertion.prototype.includes = Assertion.prototype.contain = Assertion.prototype.contains = Assertion.prototype.include;
+
+	Assertion.prototype.property = function (name, value) {
+		var v = this._value;
+		var has = v !== null && v !== undefined && Object.prototype.hasOwnProperty.call(Object(v), name);
+		if (arguments.length < 2) {
+			return this._assert(has, "expected " + show(v) + " to have property " + show(name), "expected " + show(v) + " not to have property " + show(name));
+		}
+		var actual = has ? v[name] : undefined;
+		var ok = has && (this._flags.deep ? deepEqual(actual, value) : actual === value);
+		return this._assert(ok, "expected property " + show(name) + " to equal " + show(value) + " but got " + show(actual), "expected property " + show(name) + " not to equal " + show(value));
+	};
+
+	Assertion.prototype.lengthOf = function (n) {
+		var v = this._value;
+		var length = v === null || v === undefined ? undefined : v.length;
+		return this._assert(length === n, "expected " + show(v) + " to have length " + n + " but got " + length, "expected " + show(v) + " not to have length " + n);
+	};
+	Assertion.prototype.length = Assertion.prototype.lengthOf;
+
+	Assertion.prototype.match = function (re) {
+		var v = this._value;
+		return this._assert(re.test(String(v)), "expected " + show(v) + " to match " + re, "expected " + show(v) + " not to match " + re);
+	};
+
+	Assertion.prototype.oneOf = function (list) {
+		var v = this._value;
+		return this._assert(list.indexOf(v) !== -1, "expected " + show(v) + " to be one of " + show(list), "expected " + show(v) + " not to be one of " + show(list));
+	};
+
+	Assertion.prototype.a = function (type) {
+		var v = this._value;
+		type = String(type).toLowerCase();
+		return this._assert(typeOf(v) === type, "expected " + show(v) + " to be a " + type, "expected " + show(v) + " not to be a " + type);
+	};
+	Assertion.prototype.an = Assertion.prototype.a;
+
+	function Headers(list) {
+		this._list = list || [];
+	}
+	Headers.prototype._index = function (key) {
+		key = String(key).toLowerCase();
+		for (var i = 0; i < this._list.length; i++) {
+			if (this._list[i].key.toLowerCase() === key) return i;
+		}
+		return -1;
+	};
+	Headers.prototype.get = function (key) {
+		var i = this._index(key);
+		return i === -1 ? undefined : this._list[i].value;
+	};
+	Headers.prototype.has = function (key) {
+		return this._index(key) !== -1;
+	};
+	Headers.prototype.add = function (header) {
+		this._list.push({ key: String(header.key), value: String(header.value) });
+	};
+	Headers.prototype.upsert = function (header) {
+		var i = this._index(header.key);
+		if (i === -1) {
+			this.add(header);
+		} else {
+			this._list[i].value = String(header.value);
+		}
+	};
+	Headers.prototype.remove = function (key) {
+		var i;
+		while ((i = this._index(key)) !== -1) this._list.splice(i, 1);
+	};
+	Headers.prototype.toObject = function () {
+		var out = {};
+		this._list.forEach(function (h) { out[h.key] = h.value; });
+		return out;
+	};
+
+	var variables = {
+		get: function (name) { return host.varHas(name) ? host.varGet(name) : undefined; },
+		set: function (name, value) { host.varSet(String(name), typeof value === "string" ? value : show(value)); },
+		has: function (name) { return host.varHas(name); },
+		unset: function (name) { host.varUnset(name); },
+		toObject: function () { return host.varAll(); }
+	};
+
+	var pm = {
+		info: host.info,
+		variables: variables,
+		// Collection variables and variables share one store for the run.
+		collectionVariables: variables,
+		expect: function (value) { return new Assertion(value); },
+		test: function (name, fn) {
+			try {
+				fn();
+				host.record(String(name), true, "");
+			} catch (e) {
+				host.record(String(name), false, e && e.message !== undefined ? String(e.message) : String(e));
+			}
+		}
+	};
+
+	if (host.request) {
+		var req = JSON.parse(host.request);
+		pm.request = {
+			method: req.method,
+			url: req.url,
+			body: req.body,
+			headers: new Headers(req.headers)
+		};
+	}
+
+	if (host.response) {
+		var res = JSON.parse(host.response);
+		var headers = new Headers(res.headers);
+		var expectCode = function (ok, message) {
+			if (!ok) throw new AssertionError(message);
+		};
+		pm.response = {
+			code: res.code,
+			status: res.status,
+			responseTime: res.responseTime,
+			headers: headers,
+			text: function () { return res.body; },
+			json: function () { return JSON.parse(res.body); }
+		};
+		var to = {
+			have: {
+				status: function (code) {
+					expectCode(res.code === code, "expected response to have status " + code + " but got " + res.code);
+				},
+				header: function (key, value) {
+					expectCode(headers.has(key), "expected response to have header " + show(key));
+					if (arguments.length > 1) {
+						expectCode(headers.get(key) === value, "expected header " + show(key) + " to be " + show(value) + " but got " + show(headers.get(key)));
+					}
+				},
+				body: function (text) {
+					expectCode(res.body === text, "expected response body to equal " + show(text));
+				}
+			},
+			be: {}
+		};
+		Object.defineProperty(to.be, "ok", {
+			get: function () { expectCode(res.code >= 200 && res.code < 300, "expected response to be 2xx but got " + res.code); }
+		});
+		Object.defineProperty(to.be, "success", Object.getOwnPropertyDescriptor(to.be, "ok"));
+		pm.response.to = to;
+	}
+
+	return pm;
+})(__host);
+
+var console = (function (host) {
+	function format(args) {
+		return Array.prototype.map.call(args, function (a) {
+			if (typeof a === "string") return a;
+			try {
+				return JSON.stringify(a);
+			} catch (e) {
+				return String(a);
+			}
+		}).join(" ");
+	}
+	return {
+		log: function () { host.log("log", format(arguments)); },
+		info: function () { host.log("info", format(arguments)); },
+		warn: function () { host.log("warn", format(arguments)); },
+		error: function () { host.log("error", format(arguments)); }
+	};
+})(__host);
+
+function __exportRequest() {
+	var r = pm.request;
+	return JSON.stringify({ method: String(r.method), url: String(r.url), body: r.body === undefined || r.body === null ? "" : String(r.body), headers: r.headers._list });
+}
@@ -0,0 +1,275 @@
+// Package scripting runs pre-request and test scripts in a sandboxed
+// JavaScript interpreter with a Postman-like pm API.
+package scripting
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"runtime/metrics"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+//go:embed prelude.js
+var prelude string
+
+var compiledPrelude = goja.MustCompile("prelude.js", prelude, true)
+
+const (
+	maxCallStackSize = 1024
+	maxConsoleLines  = 200
+	// memoryCheckInterval is how often a running script's memory is checked.
+	memoryCheckInterval = 10 * time.Millisecond
+)
+
+// ErrTimeout is returned when a script runs longer than its time limit.
+var ErrTimeout = errors.New("script timed out")
+
+// ErrMemoryLimit is returned when a script is stopped for using too much
+// memory.
+var ErrMemoryLimit = errors.New("script exceeded its memory limit")
+
+// Limits bound what one script may use.
+type Limits struct {
+	Timeout time.Duration
+	// Heap is the heap size, in bytes, past which the script is stopped.
+	// Scripts share the heap with the rest of the process, so it bounds
+	// what all of them together may hold. Zero means no limit.
+	Heap uint64
+}
+
+type Header struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Request is the view of the outgoing request that pre-request scripts can
+// change.
+type Request struct {
+	Method  string   `json:"method"`
+	URL     string   `json:"url"`
+	Headers []Header `json:"headers"`
+	Body    string   `json:"body"`
+}
+
+// Response is what test scripts see of the received response.
+type Response struct {
+	Code         int
+	Headers      http.Header
+	Body         []byte
+	ResponseTime time.Duration
+}
+
+type TestResult struct {
+	Name    string
+	Passed  bool
+	Message string
+}
+
+// Info describes what a script runs for.
+type Info struct {
+	RequestID   string
+	RequestName string
+	EventName   string
+}
+
+// Context carries the state shared by the scripts of one execution.
+// Variables set by a script are visible to every later script and request
+// that uses the same Vars.
+type Context struct {
+	Vars     map[string]string
+	Request  *Request
+	Response *Response
+	Info     Info
+
+	Tests   []TestResult
+	Console []string
+}
+
+// Run executes src against sc. Pre-request scripts get sc.Request, which is
+// updated with their changes; test scripts get sc.Response. Scripts have no
+// access to the network, the file system or timers, and are stopped when
+// they exceed limits.
+func Run(ctx context.Context, src string, sc *Context, limits Limits) error {
+	if strings.TrimSpace(src) == "" {
+		return nil
+	}
+	if sc.Vars == nil {
+		sc.Vars = make(map[string]string)
+	}
+
+	vm := goja.New()
+	vm.SetMaxCallStackSize(maxCallStackSize)
+
+	host, err := newHost(sc)
+	if err != nil {
+		return err
+	}
+	if err := vm.Set("__host", host); err != nil {
+		return err
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, limits.Timeout)
+	defer cancel()
+	stop := context.AfterFunc(runCtx, func() { vm.Interrupt(ErrTimeout) })
+	defer stop()
+	defer watchMemory(vm, limits.Heap)()
+
+	if _, err := vm.RunProgram(compiledPrelude); err != nil {
+		return scriptError(err)
+	}
+	if _, err := vm.RunScript(sc.Info.EventName, src); err != nil {
+		return scriptError(err)
+	}
+
+	if sc.Request != nil {
+		out, err := vm.RunString("__exportRequest()")
+		if err != nil {
+			return scriptError(err)
+		}
+		var req Request
+		if err := json.Unmarshal([]byte(out.String()), &req); err != nil {
+			return fmt.Errorf("script left an invalid request: %w", err)
+		}
+		*sc.Request = req
+	}
+	return nil
+}
+
+// Check reports a syntax error in src without running it.
+func Check(src string) error {
+	if strings.TrimSpace(src) == "" {
+		return nil
+	}
+	if _, err := goja.Compile("", src, true); err != nil {
+		return fmt.Errorf("script does not compile: %w", err)
+	}
+	return nil
+}
+
+func newHost(sc *Context) (map[string]interface{}, error) {
+	host := map[string]interface{}{
+		"info": map[string]interface{}{
+			"requestId":   sc.Info.RequestID,
+			"requestName": sc.Info.RequestName,
+			"eventName":   sc.Info.EventName,
+		},
+		"varGet": func(name string) string { return sc.Vars[name] },
+		"varHas": func(name string) bool {
+			_, ok := sc.Vars[name]
+			return ok
+		},
+		"varSet":   func(name, value string) { sc.Vars[name] = value },
+		"varUnset": func(name string) { delete(sc.Vars, name) },
+		"varAll": func() map[string]interface{} {
+			all := make(map[string]interface{}, len(sc.Vars))
+			for k, v := range sc.Vars {
+				all[k] = v
+			}
+			return all
+		},
+		"record": func(name string, passed bool, message string) {
+			sc.Tests = append(sc.Tests, TestResult{Name: name, Passed: passed, Message: message})
+		},
+		"log": func(level, line string) {
+			if len(sc.Console) >= maxConsoleLines {
+				return
+			}
+			if level != "log" {
+				line = level + ": " + line
+			}
+			sc.Console = append(sc.Console, line)
+		},
+		"request":  "",
+		"response": "",
+	}
+
+	if sc.Request != nil {
+		raw, err := json.Marshal(sc.Request)
+		if err != nil {
+			return nil, err
+		}
+		host["request"] = string(raw)
+	}
+	if sc.Response != nil {
+		var headers []Header
+		for name, values := range sc.Response.Headers {
+			for _, v := range values {
+				headers = append(headers, Header{Key: name, Value: v})
+			}
+		}
+		raw, err := json.Marshal(map[string]interface{}{
+			"code":         sc.Response.Code,
+			"status":       http.StatusText(sc.Response.Code),
+			"headers":      headers,
+			"body":         string(sc.Response.Body),
+			"responseTime": sc.Response.ResponseTime.Milliseconds(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		host["response"] = string(raw)
+	}
+	return host, nil
+}
+
+// watchMemory interrupts vm once the heap is larger than limit bytes, and
+// returns a func that stops watching. The heap also holds garbage, so before
+// interrupting it collects that and checks again.
+func watchMemory(vm *goja.Runtime, limit uint64) func() {
+	if limit == 0 {
+		return func() {}
+	}
+	sample := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	heap := func() uint64 {
+		metrics.Read(sample)
+		return sample[0].Value.Uint64()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(memoryCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if heap() <= limit {
+					continue
+				}
+				runtime.GC()
+				if heap() > limit {
+					vm.Interrupt(ErrMemoryLimit)
+					return
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+func scriptError(err error) error {
+	var interrupted *goja.InterruptedError
+	if errors.As(err, &interrupted) {
+		if v, ok := interrupted.Value().(error); ok {
+			return v
+		}
+	}
+	var overflow *goja.StackOverflowError
+	if errors.As(err, &overflow) {
+		return errors.New("script error: maximum call stack size exceeded")
+	}
+	var exception *goja.Exception
+	if errors.As(err, &exception) {
+		return fmt.Errorf("script error: %s", exception.Value().String())
+	}
+	return fmt.Errorf("script error: %w", err)
+}
@@ -0,0 +1,214 @@
+package scripting
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"runtime"
+	"runtime/metrics"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testLimits = Limits{Timeout: 2 * time.Second}
+
+func TestRunTests(t *testing.T) {
+	resp := &Response{
+		Code:    201,
+		Headers: http.Header{"Content-Type": {"application/json"}},
+		Body:    []byte(`{"id":7,"tags":["a","b"]}`),
+	}
+	tests := []struct {
+		name string
+		src  string
+		want []TestResult
+	}{
+		{
+			name: "status and json",
+			src: `pm.test("created", function () { pm.response.to.have.status(201); });
+pm.test("id", function () { pm.expect(pm.response.json().id).to.equal(7); });`,
+			want: []TestResult{{Name: "created", Passed: true}, {Name: "id", Passed: true}},
+		},
+		{
+			name: "failure message",
+			src:  `pm.test("ok status", function () { pm.response.to.have.status(200); });`,
+			want: []TestResult{{Name: "ok status", Message: "expected response to have status 200 but got 201"}},
+		},
+		{
+			name: "expect chains",
+			src: `pm.test("tags", function () { pm.expect(pm.response.json().tags).to.deep.include("b").and.have.lengthOf(2); });
+pm.test("negated", function () { pm.expect(pm.response.code).not.to.be.below(200); });
+pm.test("header", function () { pm.response.to.have.header("content-type", "application/json"); });
+pm.test("wrong type", function () { pm.expect(pm.response.text()).to.be.a("number"); });`,
+			want: []TestResult{
+				{Name: "tags", Passed: true},
+				{Name: "negated", Passed: true},
+				{Name: "header", Passed: true},
+				{Name: "wrong type", Message: `expected "{\"id\":7,\"tags\":[\"a\",\"b\"]}" to be a number`},
+			},
+		},
+		{
+			name: "thrown error fails only its test",
+			src: `pm.test("throws", function () { throw new Error("boom"); });
+pm.test("after", function () {});`,
+			want: []TestResult{{Name: "throws", Message: "boom"}, {Name: "after", Passed: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := &Context{Response: resp}
+			if err := Run(context.Background(), tt.src, sc, testLimits); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(sc.Tests, tt.want) {
+				t.Errorf("tests = %+v, want %+v", sc.Tests, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunPreRequest(t *testing.T) {
+	sc := &Context{
+		Vars: map[string]string{"host": "api.test", "stale": "x"},
+		Request: &Request{
+			Method:  "GET",
+			URL:     "https://{{host}}/users",
+			Headers: []Header{{Key: "Accept", Value: "*/*"}, {Key: "X-Old", Value: "1"}},
+		},
+		Info: Info{RequestName: "users", EventName: "prerequest"},
+	}
+	src := `
+pm.variables.set("token", "t-" + pm.variables.get("host"));
+pm.variables.set("count", 3);
+pm.variables.unset("stale");
+pm.request.method = "POST";
+pm.request.body = JSON.stringify({ name: pm.info.requestName });
+pm.request.headers.upsert({ key: "accept", value: "application/json" });
+pm.request.headers.add({ key: "Authorization", value: "Bearer " + pm.variables.get("token") });
+pm.request.headers.remove("x-old");
+console.log("event", pm.info.eventName, { has: pm.variables.has("host") });
+console.warn("careful");`
+	if err := Run(context.Background(), src, sc, testLimits); err != nil {
+		t.Fatal(err)
+	}
+
+	wantVars := map[string]string{"host": "api.test", "token": "t-api.test", "count": "3"}
+	if !reflect.DeepEqual(sc.Vars, wantVars) {
+		t.Errorf("vars = %v, want %v", sc.Vars, wantVars)
+	}
+	wantReq := Request{
+		Method:  "POST",
+		URL:     "https://{{host}}/users",
+		Body:    `{"name":"users"}`,
+		Headers: []Header{{Key: "Accept", Value: "application/json"}, {Key: "Authorization", Value: "Bearer t-api.test"}},
+	}
+	if !reflect.DeepEqual(*sc.Request, wantReq) {
+		t.Errorf("request = %+v, want %+v", *sc.Request, wantReq)
+	}
+	wantConsole := []string{`event prerequest {"has":true}`, "warn: careful"}
+	if !reflect.DeepEqual(sc.Console, wantConsole) {
+		t.Errorf("console = %q, want %q", sc.Console, wantConsole)
+	}
+}
+
+func TestRunIsolation(t *testing.T) {
+	sc := &Context{}
+	if err := Run(context.Background(), `leaked = 1; Object.prototype.polluted = true;`, sc, testLimits); err != nil {
+		t.Fatal(err)
+	}
+	src := `pm.test("fresh globals", function () {
+	pm.expect(typeof leaked).to.equal("undefined");
+	pm.expect(({}).polluted).to.be.undefined;
+});
+pm.test("no host access", function () {
+	["require", "process", "setTimeout", "setInterval", "fetch", "XMLHttpRequest"].forEach(function (name) {
+		pm.expect(typeof this[name], name).to.equal("undefined");
+	}, this);
+});`
+	if err := Run(context.Background(), src, sc, testLimits); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range sc.Tests {
+		if !r.Passed {
+			t.Errorf("%s: %s", r.Name, r.Message)
+		}
+	}
+}
+
+func TestRunLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		limits Limits
+		want   error
+		// wantMsg is checked when want is nil.
+		wantMsg string
+	}{
+		{name: "endless loop", src: `for (;;) {}`, limits: Limits{Timeout: 100 * time.Millisecond}, want: ErrTimeout},
+		{
+			name:   "memory exhaustion",
+			src:    `var a = []; for (;;) a.push("x".repeat(1 << 20) + a.length);`,
+			limits: Limits{Timeout: 30 * time.Second, Heap: heapInUse() + 64<<20},
+			want:   ErrMemoryLimit,
+		},
+		{name: "deep recursion", src: `function f() { return f(); } f();`, limits: testLimits, wantMsg: "maximum call stack size exceeded"},
+		{name: "uncaught exception", src: `throw new TypeError("bad")`, limits: testLimits, wantMsg: "script error: TypeError: bad"},
+		{name: "syntax error", src: `pm.test(`, limits: testLimits, wantMsg: "script error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			err := Run(context.Background(), tt.src, &Context{}, tt.limits)
+			if tt.want != nil {
+				if !errors.Is(err, tt.want) {
+					t.Fatalf("error %v, want %v", err, tt.want)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Fatalf("error %v, want %q", err, tt.wantMsg)
+			}
+			if elapsed := time.Since(start); elapsed > 10*time.Second {
+				t.Errorf("took %v to stop", elapsed)
+			}
+		})
+	}
+}
+
+// heapInUse collects garbage and returns what the heap still holds.
+func heapInUse() uint64 {
+	runtime.GC()
+	sample := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	metrics.Read(sample)
+	return sample[0].Value.Uint64()
+}
+
+func TestRunCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	if err := Run(ctx, `for (;;) {}`, &Context{}, Limits{Timeout: time.Minute}); !errors.Is(err, ErrTimeout) {
+		t.Errorf("error %v, want %v", err, ErrTimeout)
+	}
+}
+
+func TestConsoleCap(t *testing.T) {
+	sc := &Context{}
+	if err := Run(context.Background(), `for (var i = 0; i < 1000; i++) console.log(i);`, sc, testLimits); err != nil {
+		t.Fatal(err)
+	}
+	if len(sc.Console) != maxConsoleLines {
+		t.Errorf("kept %d console lines, want %d", len(sc.Console), maxConsoleLines)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	if err := Check(`pm.test("ok", function () {});`); err != nil {
+		t.Errorf("valid script rejected: %v", err)
+	}
+	if err := Check(`pm.test("ok", function () {`); err == nil {
+		t.Error("broken script accepted")
+	}
+	if err := Check("  "); err != nil {
+		t.Errorf("blank script rejected: %v", err)
+	}
+}
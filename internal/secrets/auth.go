@@ -0,0 +1,141 @@
+package secrets
+
+import (
+	"collectionsservice/internal/models"
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/datatypes"
+)
+
+func ParseAuth(raw []byte) (*models.AuthConfig, bool) {
+	if len(raw) == 0 {
+		return nil, false
+	}
+	var a models.AuthConfig
+	if err := json.Unmarshal(raw, &a); err != nil || a.Type == "" {
+		return nil, false
+	}
+	return &a, true
+}
+
+// SealAuth encrypts the credentials of an auth block. A credential sent back
+// as Redacted keeps the value stored in previous.
+func (k *Keyring) SealAuth(raw, previous []byte) (datatypes.JSON, error) {
+	a, ok := ParseAuth(raw)
+	if !ok {
+		return datatypes.JSON(raw), nil
+	}
+	prev, _ := ParseAuth(previous)
+	if prev == nil {
+		prev = &models.AuthConfig{}
+	}
+
+	prevFields := prev.SecretFields()
+	for i, f := range a.SecretFields() {
+		switch {
+		case *f == "":
+		case *f == Redacted:
+			if *prevFields[i] == "" {
+				return nil, fmt.Errorf("auth credential has no stored value to keep; send its plaintext")
+			}
+			*f = *prevFields[i]
+		case IsSealed(*f):
+			if _, err := k.Open(*f); err != nil {
+				return nil, fmt.Errorf("auth credential: %w", err)
+			}
+		default:
+			sealed, err := k.Seal(*f)
+			if err != nil {
+				return nil, err
+			}
+			*f = sealed
+		}
+	}
+	return json.Marshal(a)
+}
+
+// OpenAuth decrypts the credentials of an auth block.
+func (k *Keyring) OpenAuth(raw []byte) (datatypes.JSON, error) {
+	return k.mapAuth(raw, k.Open)
+}
+
+// Rotate re-seals a headers, variables or auth column under the primary key.
+func (k *Keyring) Rotate(raw []byte) (datatypes.JSON, bool, error) {
+	if _, ok := ParseEntries(raw); ok {
+		return k.RotateEntries(raw)
+	}
+	return k.RotateAuth(raw)
+}
+
+func (k *Keyring) RotateAuth(raw []byte) (datatypes.JSON, bool, error) {
+	rotated := false
+	out, err := k.mapAuth(raw, func(v string) (string, error) {
+		if !k.Stale(v) {
+			return v, nil
+		}
+		plain, err := k.Open(v)
+		if err != nil {
+			return "", err
+		}
+		rotated = true
+		return k.Seal(plain)
+	})
+	return out, rotated, err
+}
+
+func (k *Keyring) mapAuth(raw []byte, fn func(string) (string, error)) (datatypes.JSON, error) {
+	a, ok := ParseAuth(raw)
+	if !ok {
+		return datatypes.JSON(raw), nil
+	}
+	changed := false
+	for _, f := range a.SecretFields() {
+		if !IsSealed(*f) {
+			continue
+		}
+		v, err := fn(*f)
+		if err != nil {
+			return nil, fmt.Errorf("auth credential: %w", err)
+		}
+		*f = v
+		changed = true
+	}
+	if !changed {
+		return datatypes.JSON(raw), nil
+	}
+	return json.Marshal(a)
+}
+
+// DisplayAuth returns the auth block of a read with sealed credentials
+// redacted.
+func DisplayAuth(raw []byte) *models.AuthConfig {
+	a, ok := ParseAuth(raw)
+	if !ok {
+		return nil
+	}
+	for _, f := range a.SecretFields() {
+		if IsSealed(*f) {
+			*f = Redacted
+		}
+	}
+	return a
+}
+
+// RedactAuth hides every credential of an auth block.
+func RedactAuth(raw []byte) []byte {
+	a, ok := ParseAuth(raw)
+	if !ok {
+		return raw
+	}
+	for _, f := range a.SecretFields() {
+		if *f != "" {
+			*f = Redacted
+		}
+	}
+	out, err := json.Marshal(a)
+	if err != nil {
+		return raw
+	}
+	return out
+}
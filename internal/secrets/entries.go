@@ -0,0 +1,233 @@
+package secrets
+
+import (
+	"collectionsservice/internal/models"
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/datatypes"
+)
+
+// Redacted replaces secret values in every read. Clients may send it back
+// unchanged in an update to keep the stored value.
+const Redacted = "********"
+
+// Entry is the stored shape of a header or variable.
+type Entry struct {
+	Key    string `json:"key,omitempty"`
+	Value  string `json:"value,omitempty"`
+	Secret bool   `json:"secret,omitempty"`
+}
+
+// SecretFields are the request fields that hold entries.
+var SecretFields = map[string]bool{
+	"http_headers":    true,
+	"graphql_headers": true,
+	"variables":       true,
+}
+
+func ParseEntries(raw []byte) ([]Entry, bool) {
+	if len(raw) == 0 {
+		return nil, false
+	}
+	var entries []Entry
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, false
+	}
+	return entries, true
+}
+
+// SealEntries encrypts every secret entry that is still plaintext. A secret
+// sent back as Redacted keeps the value stored under the same key in
+// previous.
+func (k *Keyring) SealEntries(raw, previous []byte) (datatypes.JSON, error) {
+	entries, ok := ParseEntries(raw)
+	if !ok {
+		return datatypes.JSON(raw), nil
+	}
+	stored := make(map[string]string)
+	if prev, ok := ParseEntries(previous); ok {
+		for _, e := range prev {
+			if e.Secret {
+				stored[e.Key] = e.Value
+			}
+		}
+	}
+
+	changed := false
+	for i, e := range entries {
+		if !e.Secret {
+			continue
+		}
+		switch {
+		case e.Value == Redacted:
+			v, ok := stored[e.Key]
+			if !ok {
+				return nil, fmt.Errorf("secret %q has no stored value to keep; send its plaintext", e.Key)
+			}
+			entries[i].Value = v
+		case IsSealed(e.Value):
+			if _, err := k.Open(e.Value); err != nil {
+				return nil, fmt.Errorf("secret %q: %w", e.Key, err)
+			}
+		default:
+			sealed, err := k.Seal(e.Value)
+			if err != nil {
+				return nil, err
+			}
+			entries[i].Value = sealed
+		}
+		changed = true
+	}
+	if !changed {
+		return datatypes.JSON(raw), nil
+	}
+	return json.Marshal(entries)
+}
+
+// OpenEntries decrypts every sealed secret entry.
+func (k *Keyring) OpenEntries(raw []byte) (datatypes.JSON, error) {
+	return k.mapSealed(raw, k.Open)
+}
+
+// RotateEntries re-seals secrets sealed with a retired key under the primary
+// key. It reports whether anything changed.
+func (k *Keyring) RotateEntries(raw []byte) (datatypes.JSON, bool, error) {
+	rotated := false
+	out, err := k.mapSealed(raw, func(v string) (string, error) {
+		if !k.Stale(v) {
+			return v, nil
+		}
+		plain, err := k.Open(v)
+		if err != nil {
+			return "", err
+		}
+		rotated = true
+		return k.Seal(plain)
+	})
+	return out, rotated, err
+}
+
+func (k *Keyring) mapSealed(raw []byte, fn func(string) (string, error)) (datatypes.JSON, error) {
+	entries, ok := ParseEntries(raw)
+	if !ok {
+		return datatypes.JSON(raw), nil
+	}
+	changed := false
+	for i, e := range entries {
+		if !e.Secret || !IsSealed(e.Value) {
+			continue
+		}
+		v, err := fn(e.Value)
+		if err != nil {
+			return nil, fmt.Errorf("secret %q: %w", e.Key, err)
+		}
+		entries[i].Value = v
+		changed = true
+	}
+	if !changed {
+		return datatypes.JSON(raw), nil
+	}
+	return json.Marshal(entries)
+}
+
+// RedactEntries hides every secret value.
+func RedactEntries(raw []byte) []byte {
+	entries, ok := ParseEntries(raw)
+	if !ok {
+		return raw
+	}
+	changed := false
+	for i, e := range entries {
+		if e.Secret {
+			entries[i].Value = Redacted
+			changed = true
+		}
+	}
+	if !changed {
+		return raw
+	}
+	out, err := json.Marshal(entries)
+	if err != nil {
+		return raw
+	}
+	return out
+}
+
+// RedactField hides secret values in a field rendered as a string, such as a
+// merge or proposal diff.
+func RedactField(field, value string) string {
+	switch {
+	case value == "":
+		return value
+	case field == "auth":
+		return string(RedactAuth([]byte(value)))
+	case SecretFields[field]:
+		return string(RedactEntries([]byte(value)))
+	}
+	return value
+}
+
+// DisplayValue is what a read shows for an entry: sealed secrets are
+// redacted, anything already opened is shown as is.
+func DisplayValue(e Entry) string {
+	if e.Secret && IsSealed(e.Value) {
+		return Redacted
+	}
+	return e.Value
+}
+
+func (k *Keyring) SealRequest(r, previous *models.Request) error {
+	var prevHTTP, prevGraphQL []byte
+	if previous != nil {
+		prevHTTP, prevGraphQL = previous.HTTPHeaders, previous.GraphQLHeaders
+	}
+	var err error
+	if r.HTTPHeaders, err = k.SealEntries(r.HTTPHeaders, prevHTTP); err != nil {
+		return fmt.Errorf("http headers: %w", err)
+	}
+	if r.GraphQLHeaders, err = k.SealEntries(r.GraphQLHeaders, prevGraphQL); err != nil {
+		return fmt.Errorf("graphql headers: %w", err)
+	}
+	var prevAuth []byte
+	if previous != nil {
+		prevAuth = previous.Auth
+	}
+	if r.Auth, err = k.SealAuth(r.Auth, prevAuth); err != nil {
+		return err
+	}
+	return nil
+}
+
+// OpenRequest decrypts a request's secrets in place. Only call it on a copy
+// that is about to be executed or compared, never on one that is stored.
+func (k *Keyring) OpenRequest(r *models.Request) error {
+	var err error
+	if r.HTTPHeaders, err = k.OpenEntries(r.HTTPHeaders); err != nil {
+		return fmt.Errorf("http headers: %w", err)
+	}
+	if r.GraphQLHeaders, err = k.OpenEntries(r.GraphQLHeaders); err != nil {
+		return fmt.Errorf("graphql headers: %w", err)
+	}
+	if r.Auth, err = k.OpenAuth(r.Auth); err != nil {
+		return err
+	}
+	return nil
+}
+
+// OpenCollection decrypts the variables and request secrets of col in place.
+func (k *Keyring) OpenCollection(col *models.Collection) error {
+	var err error
+	if col.Variables, err = k.OpenEntries(col.Variables); err != nil {
+		return fmt.Errorf("variables: %w", err)
+	}
+	if col.Auth, err = k.OpenAuth(col.Auth); err != nil {
+		return err
+	}
+	for i := range col.Requests {
+		if err := k.OpenRequest(&col.Requests[i]); err != nil {
+			return fmt.Errorf("request %q: %w", col.Requests[i].Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package secrets
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSealEntries(t *testing.T) {
+	k := mustKeyring(t, "k1", map[string][]byte{"k1": testKey(1)})
+	stored, err := k.SealEntries([]byte(`[{"key":"Authorization","value":"Bearer abc","secret":true},{"key":"Accept","value":"*/*"}]`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, _ := ParseEntries(stored)
+	if !IsSealed(entries[0].Value) || entries[1].Value != "*/*" {
+		t.Fatalf("stored entries %v: want only the secret sealed", entries)
+	}
+	storedSecret := entries[0].Value
+
+	tests := []struct {
+		name     string
+		raw      string
+		previous []byte
+		// want is the plaintext of the first entry after sealing.
+		want      string
+		wantKept  bool
+		wantError string
+	}{
+		{
+			name:     "redacted keeps the stored value",
+			raw:      `[{"key":"Authorization","value":"********","secret":true}]`,
+			previous: stored,
+			want:     "Bearer abc",
+			wantKept: true,
+		},
+		{
+			name:     "new plaintext replaces it",
+			raw:      `[{"key":"Authorization","value":"Bearer xyz","secret":true}]`,
+			previous: stored,
+			want:     "Bearer xyz",
+		},
+		{
+			name:      "redacted without a stored value",
+			raw:       `[{"key":"X-Api-Key","value":"********","secret":true}]`,
+			previous:  stored,
+			wantError: `secret "X-Api-Key" has no stored value`,
+		},
+		{
+			name:      "redacted on create",
+			raw:       `[{"key":"Authorization","value":"********","secret":true}]`,
+			wantError: "no stored value",
+		},
+		{
+			name:      "foreign ciphertext",
+			raw:       `[{"key":"Authorization","value":"enc:v1:k9:AAAA","secret":true}]`,
+			wantError: `unknown key "k9"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := k.SealEntries([]byte(tt.raw), tt.previous)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("error %v, want %q", err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			entries, _ := ParseEntries(out)
+			if strings.Contains(string(out), tt.want) {
+				t.Errorf("plaintext stored: %s", out)
+			}
+			if got, _ := k.Open(entries[0].Value); got != tt.want {
+				t.Errorf("secret opens to %q, want %q", got, tt.want)
+			}
+			if kept := entries[0].Value == storedSecret; kept != tt.wantKept {
+				t.Errorf("kept stored ciphertext = %v, want %v", kept, tt.wantKept)
+			}
+		})
+	}
+}
+
+func TestSealAuthKeepsRedacted(t *testing.T) {
+	k := mustKeyring(t, "k1", map[string][]byte{"k1": testKey(1)})
+	stored, err := k.SealAuth([]byte(`{"type":"basic","username":"u","password":"p4ss"}`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := k.SealAuth([]byte(`{"type":"basic","username":"renamed","password":"********"}`), stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opened, err := k.OpenAuth(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, _ := ParseAuth(opened)
+	if a.Username != "renamed" || a.Password != "p4ss" {
+		t.Errorf("auth after update = %+v, want the stored password kept", a)
+	}
+
+	if _, err := k.SealAuth([]byte(`{"type":"bearer","token":"********"}`), stored); err == nil {
+		t.Error("redacted token without a stored one was accepted")
+	}
+}
+
+func TestRotateEntries(t *testing.T) {
+	old := mustKeyring(t, "k1", map[string][]byte{"k1": testKey(1)})
+	raw, _ := old.SealEntries([]byte(`[{"key":"a","value":"1","secret":true},{"key":"b","value":"2"}]`), nil)
+
+	k := mustKeyring(t, "k2", map[string][]byte{"k1": testKey(1), "k2": testKey(2)})
+	out, rotated, err := k.RotateEntries(raw)
+	if err != nil || !rotated {
+		t.Fatalf("RotateEntries = %v, %v", rotated, err)
+	}
+	entries, _ := ParseEntries(out)
+	if !strings.HasPrefix(entries[0].Value, "enc:v1:k2:") || entries[1].Value != "2" {
+		t.Errorf("rotated entries %v", entries)
+	}
+	if _, rotated, _ := k.RotateEntries(out); rotated {
+		t.Error("second rotation changed entries")
+	}
+}
+
+func TestRedactEntries(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"secret hidden", `[{"key":"a","value":"x","secret":true},{"key":"b","value":"y"}]`, `[{"key":"a","value":"********","secret":true},{"key":"b","value":"y"}]`},
+		{"nothing secret", `[{"key":"b","value":"y"}]`, `[{"key":"b","value":"y"}]`},
+		{"not entries", `{"a":1}`, `{"a":1}`},
+	}
+	for _, tt := range tests {
+		if got := string(RedactEntries([]byte(tt.raw))); got != tt.want {
+			t.Errorf("%s: RedactEntries = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
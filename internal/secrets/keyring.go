@@ -0,0 +1,189 @@
+// Package secrets encrypts secret header values and variables at rest with
+// AES-GCM and redacts them for display.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// sealedPrefix marks a stored value as ciphertext. The key id follows it so
+// values sealed with a retired key can still be opened after a rotation.
+const sealedPrefix = "enc:v1:"
+
+var ErrNotConfigured = errors.New("secret encryption is not configured: set SECRETS_KEYS or SECRETS_KEY_FILE")
+
+type Keyring struct {
+	primary string
+	aeads   map[string]cipher.AEAD
+}
+
+// NewKeyring builds a keyring from 32-byte AES-256 keys. New values are
+// sealed with the primary key; every key can open values. An empty key set
+// yields a keyring that can only redact.
+func NewKeyring(primary string, keys map[string][]byte) (*Keyring, error) {
+	k := &Keyring{primary: primary, aeads: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("invalid secret key id %q", id)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("secret key %q must be 32 bytes, got %d", id, len(key))
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		k.aeads[id] = aead
+	}
+	if len(k.aeads) > 0 {
+		if _, ok := k.aeads[primary]; !ok {
+			return nil, fmt.Errorf("primary secret key %q is not in the keyring", primary)
+		}
+	}
+	return k, nil
+}
+
+type keyFile struct {
+	Primary string            `json:"primary"`
+	Keys    map[string]string `json:"keys"`
+}
+
+// LoadKeyring reads keys from a JSON key file ({"primary": id, "keys": {id:
+// base64}}) or from a "id:base64,id:base64" list. With a list and no primary
+// id, the last key listed is primary.
+func LoadKeyring(file, list, primary string) (*Keyring, error) {
+	encoded := make(map[string]string)
+	switch {
+	case file != "":
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secret key file: %w", err)
+		}
+		var kf keyFile
+		if err := json.Unmarshal(raw, &kf); err != nil {
+			return nil, fmt.Errorf("failed to parse secret key file: %w", err)
+		}
+		encoded = kf.Keys
+		if primary == "" {
+			primary = kf.Primary
+		}
+	case list != "":
+		var last string
+		for _, pair := range strings.Split(list, ",") {
+			id, key, ok := strings.Cut(strings.TrimSpace(pair), ":")
+			if !ok {
+				return nil, fmt.Errorf("secret key entry %q must look like id:base64key", pair)
+			}
+			encoded[id] = key
+			last = id
+		}
+		if primary == "" {
+			primary = last
+		}
+	}
+
+	keys := make(map[string][]byte, len(encoded))
+	for id, s := range encoded {
+		key, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("secret key %q is not valid base64: %w", id, err)
+		}
+		keys[id] = key
+	}
+	return NewKeyring(primary, keys)
+}
+
+func (k *Keyring) Enabled() bool {
+	return k != nil && len(k.aeads) > 0
+}
+
+func (k *Keyring) Seal(plaintext string) (string, error) {
+	if !k.Enabled() {
+		return "", ErrNotConfigured
+	}
+	aead := k.aeads[k.primary]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return sealedPrefix + k.primary + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+func (k *Keyring) Open(value string) (string, error) {
+	id, payload, err := parseSealed(value)
+	if err != nil {
+		return "", err
+	}
+	if !k.Enabled() {
+		return "", ErrNotConfigured
+	}
+	aead, ok := k.aeads[id]
+	if !ok {
+		return "", fmt.Errorf("secret was sealed with unknown key %q", id)
+	}
+	if len(payload) < aead.NonceSize() {
+		return "", errors.New("sealed secret is truncated")
+	}
+	plain, err := aead.Open(nil, payload[:aead.NonceSize()], payload[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt secret sealed with key %q", id)
+	}
+	return string(plain), nil
+}
+
+// Stale reports whether a sealed value was sealed with a key other than the
+// primary one.
+func (k *Keyring) Stale(value string) bool {
+	id, _, err := parseSealed(value)
+	return err == nil && id != k.primary
+}
+
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, sealedPrefix)
+}
+
+func parseSealed(value string) (string, []byte, error) {
+	rest, ok := strings.CutPrefix(value, sealedPrefix)
+	if !ok {
+		return "", nil, errors.New("value is not a sealed secret")
+	}
+	id, encoded, ok := strings.Cut(rest, ":")
+	if !ok {
+		return "", nil, errors.New("sealed secret has no key id")
+	}
+	payload, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, fmt.Errorf("sealed secret is corrupt: %w", err)
+	}
+	return id, payload, nil
+}
+
+// RotateValue re-seals a value sealed with a retired key under the primary
+// key. It reports whether the value changed.
+func (k *Keyring) RotateValue(value string) (string, bool, error) {
+	if !k.Stale(value) {
+		return value, false, nil
+	}
+	plain, err := k.Open(value)
+	if err != nil {
+		return "", false, err
+	}
+	sealed, err := k.Seal(plain)
+	if err != nil {
+		return "", false, err
+	}
+	return sealed, true, nil
+}
@@ -0,0 +1,177 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testKey(b byte) []byte { return bytes.Repeat([]byte{b}, 32) }
+
+func mustKeyring(t *testing.T, primary string, keys map[string][]byte) *Keyring {
+	t.Helper()
+	k, err := NewKeyring(primary, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func TestSealOpen(t *testing.T) {
+	k := mustKeyring(t, "k1", map[string][]byte{"k1": testKey(1)})
+	for _, plain := range []string{"", "s3cr3t", "ünïcode and spaces", strings.Repeat("x", 4096)} {
+		sealed, err := k.Seal(plain)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !IsSealed(sealed) || !strings.HasPrefix(sealed, "enc:v1:k1:") {
+			t.Errorf("sealed value %q lacks the k1 prefix", sealed)
+		}
+		if plain != "" && strings.Contains(sealed, plain) {
+			t.Errorf("sealed value contains the plaintext")
+		}
+		got, err := k.Open(sealed)
+		if err != nil || got != plain {
+			t.Errorf("Open = %q, %v; want %q", got, err, plain)
+		}
+	}
+
+	a, _ := k.Seal("same")
+	b, _ := k.Seal("same")
+	if a == b {
+		t.Error("sealing twice gave the same ciphertext; the nonce is not random")
+	}
+}
+
+func TestRotation(t *testing.T) {
+	old := mustKeyring(t, "k1", map[string][]byte{"k1": testKey(1)})
+	sealedOld, err := old.Seal("token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rotated := mustKeyring(t, "k2", map[string][]byte{"k1": testKey(1), "k2": testKey(2)})
+	if got, err := rotated.Open(sealedOld); err != nil || got != "token" {
+		t.Fatalf("old key no longer opens: %q, %v", got, err)
+	}
+	if !rotated.Stale(sealedOld) {
+		t.Error("value sealed with the retired key is not stale")
+	}
+
+	sealedNew, err := rotated.Seal("token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(sealedNew, "enc:v1:k2:") || rotated.Stale(sealedNew) {
+		t.Errorf("new value %q is not sealed with the primary key", sealedNew)
+	}
+
+	value, changed, err := rotated.RotateValue(sealedOld)
+	if err != nil || !changed || !strings.HasPrefix(value, "enc:v1:k2:") {
+		t.Fatalf("RotateValue = %q, %v, %v", value, changed, err)
+	}
+	if got, _ := rotated.Open(value); got != "token" {
+		t.Errorf("rotated value opens to %q", got)
+	}
+	if _, changed, _ := rotated.RotateValue(sealedNew); changed {
+		t.Error("value already under the primary key was rotated")
+	}
+}
+
+func TestOpenRejects(t *testing.T) {
+	k := mustKeyring(t, "k1", map[string][]byte{"k1": testKey(1)})
+	sealed, _ := k.Seal("token")
+	payload := strings.TrimPrefix(sealed, "enc:v1:k1:")
+	raw, _ := base64.RawStdEncoding.DecodeString(payload)
+	raw[len(raw)-1] ^= 1
+	tampered := "enc:v1:k1:" + base64.RawStdEncoding.EncodeToString(raw)
+
+	other := mustKeyring(t, "k1", map[string][]byte{"k1": testKey(9)})
+
+	tests := []struct {
+		name  string
+		k     *Keyring
+		value string
+		want  string
+	}{
+		{"plaintext", k, "token", "not a sealed secret"},
+		{"no key id", k, "enc:v1:" + payload, "no key id"},
+		{"unknown key", k, "enc:v1:k7:" + payload, `unknown key "k7"`},
+		{"corrupt base64", k, "enc:v1:k1:!!!", "corrupt"},
+		{"truncated", k, "enc:v1:k1:AAAA", "truncated"},
+		{"tampered ciphertext", k, tampered, "failed to decrypt"},
+		{"wrong key material", other, sealed, "failed to decrypt"},
+		{"no keys configured", mustKeyring(t, "", nil), sealed, "not configured"},
+	}
+	for _, tt := range tests {
+		got, err := tt.k.Open(tt.value)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Open = %q, %v; want error containing %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestNewKeyringRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		primary string
+		keys    map[string][]byte
+	}{
+		{"short key", "k1", map[string][]byte{"k1": testKey(1)[:16]}},
+		{"empty id", "", map[string][]byte{"": testKey(1)}},
+		{"colon in id", "a:b", map[string][]byte{"a:b": testKey(1)}},
+		{"primary missing", "k2", map[string][]byte{"k1": testKey(1)}},
+	}
+	for _, tt := range tests {
+		if _, err := NewKeyring(tt.primary, tt.keys); err == nil {
+			t.Errorf("%s: NewKeyring accepted the keys", tt.name)
+		}
+	}
+
+	k := mustKeyring(t, "", nil)
+	if k.Enabled() {
+		t.Error("empty keyring is enabled")
+	}
+	if _, err := k.Seal("x"); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("Seal without keys: %v, want ErrNotConfigured", err)
+	}
+}
+
+func TestLoadKeyring(t *testing.T) {
+	k1 := base64.StdEncoding.EncodeToString(testKey(1))
+	k2 := base64.StdEncoding.EncodeToString(testKey(2))
+	file := filepath.Join(t.TempDir(), "keys.json")
+	if err := os.WriteFile(file, []byte(`{"primary":"k1","keys":{"k1":"`+k1+`","k2":"`+k2+`"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		file, list  string
+		primary     string
+		wantPrimary string
+		wantErr     bool
+	}{
+		{name: "file", file: file, wantPrimary: "k1"},
+		{name: "file with primary override", file: file, primary: "k2", wantPrimary: "k2"},
+		{name: "list takes the last key", list: "k1:" + k1 + ", k2:" + k2, wantPrimary: "k2"},
+		{name: "list with primary", list: "k1:" + k1 + ",k2:" + k2, primary: "k1", wantPrimary: "k1"},
+		{name: "entry without id", list: k1, wantErr: true},
+		{name: "bad base64", list: "k1:not base64", wantErr: true},
+		{name: "missing file", file: filepath.Join(t.TempDir(), "none.json"), wantErr: true},
+	}
+	for _, tt := range tests {
+		k, err := LoadKeyring(tt.file, tt.list, tt.primary)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && k.primary != tt.wantPrimary {
+			t.Errorf("%s: primary %q, want %q", tt.name, k.primary, tt.wantPrimary)
+		}
+	}
+}
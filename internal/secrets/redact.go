@@ -0,0 +1,69 @@
+package secrets
+
+import (
+	"collectionsservice/internal/models"
+	"sort"
+	"strings"
+)
+
+// Redactor replaces known secret values wherever they appear in text, such
+// as a URL or body a secret variable was interpolated into.
+type Redactor struct {
+	replacer *strings.Replacer
+}
+
+// CollectionRedactor collects the secret variables, headers and credentials
+// of an opened collection and its requests.
+func CollectionRedactor(col *models.Collection) *Redactor {
+	var values []string
+	addEntries := func(raw []byte) {
+		entries, _ := ParseEntries(raw)
+		for _, e := range entries {
+			if e.Secret {
+				values = append(values, e.Value)
+			}
+		}
+	}
+	addAuth := func(raw []byte) {
+		if a, ok := ParseAuth(raw); ok {
+			for _, f := range a.SecretFields() {
+				values = append(values, *f)
+			}
+		}
+	}
+
+	addEntries(col.Variables)
+	addAuth(col.Auth)
+	for _, r := range col.Requests {
+		addEntries(r.HTTPHeaders)
+		addEntries(r.GraphQLHeaders)
+		addAuth(r.Auth)
+	}
+	return NewRedactor(values...)
+}
+
+func NewRedactor(values ...string) *Redactor {
+	// Longer values go first so a secret containing another is replaced
+	// whole.
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	var pairs []string
+	seen := make(map[string]bool)
+	for _, v := range values {
+		if v == "" || v == Redacted || IsSealed(v) || seen[v] {
+			continue
+		}
+		seen[v] = true
+		pairs = append(pairs, v, Redacted)
+	}
+	if len(pairs) == 0 {
+		return &Redactor{}
+	}
+	return &Redactor{replacer: strings.NewReplacer(pairs...)}
+}
+
+func (r *Redactor) Redact(s string) string {
+	if r == nil || r.replacer == nil {
+		return s
+	}
+	return r.replacer.Replace(s)
+}
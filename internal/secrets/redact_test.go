@@ -0,0 +1,53 @@
+package secrets
+
+import (
+	"collectionsservice/internal/models"
+	"testing"
+
+	"gorm.io/datatypes"
+)
+
+func TestRedactor(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		in     string
+		want   string
+	}{
+		{"single value", []string{"abc123"}, "token=abc123&x=1", "token=********&x=1"},
+		{"every occurrence", []string{"abc"}, "abc/abc", "********/********"},
+		{"longest first", []string{"abc", "abcdef"}, "key abcdef and abc", "key ******** and ********"},
+		{"longest first whatever the order", []string{"abcdef", "abc"}, "abcdef", "********"},
+		{"overlap at the end", []string{"pass", "password1"}, "password1 pass", "******** ********"},
+		{"empty and sealed values ignored", []string{"", "enc:v1:k1:AAAA", Redacted}, "enc:v1:k1:AAAA", "enc:v1:k1:AAAA"},
+		{"no values", nil, "plain", "plain"},
+	}
+	for _, tt := range tests {
+		if got := NewRedactor(tt.values...).Redact(tt.in); got != tt.want {
+			t.Errorf("%s: Redact(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+	}
+
+	var nilRedactor *Redactor
+	if got := nilRedactor.Redact("abc"); got != "abc" {
+		t.Errorf("nil redactor changed the text to %q", got)
+	}
+}
+
+func TestCollectionRedactor(t *testing.T) {
+	col := &models.Collection{
+		Variables: datatypes.JSON(`[{"key":"apiKey","value":"var-secret","secret":true},{"key":"host","value":"example.com"}]`),
+		Auth:      datatypes.JSON(`{"type":"bearer","token":"col-token"}`),
+		Requests: []models.Request{{
+			HTTPHeaders: datatypes.JSON(`[{"key":"X-Key","value":"hdr-secret","secret":true}]`),
+			Auth:        datatypes.JSON(`{"type":"basic","username":"user","password":"req-pass"}`),
+		}},
+	}
+	r := CollectionRedactor(col)
+
+	in := "https://example.com/?k=var-secret user:req-pass Bearer col-token X-Key: hdr-secret"
+	want := "https://example.com/?k=******** user:******** Bearer ******** X-Key: ********"
+	if got := r.Redact(in); got != want {
+		t.Errorf("Redact = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/auth"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/rbac"
+	"collectionsservice/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// BootstrapSubject owns the API key configured through AUTH_BOOTSTRAP_API_KEY.
+const BootstrapSubject = "admin"
+
+type AuthService struct {
+	Repo  repository.APIKeyRepoInterface
+	Authz *rbac.Authorizer
+	proto.AuthServiceServer
+}
+
+func NewAuthService(repo repository.APIKeyRepoInterface, authz *rbac.Authorizer) *AuthService {
+	return &AuthService{
+		Repo:  repo,
+		Authz: authz,
+	}
+}
+
+func (s *AuthService) CreateAPIKey(ctx context.Context, req *proto.CreateAPIKeyRequest) (*proto.CreateAPIKeyResponse, error) {
+	if req.GetName() == "" {
+		return nil, apperr.Invalid("name", "API key name cannot be empty")
+	}
+	caller := auth.Subject(ctx, "")
+	subject := req.GetSubject()
+	if subject == "" {
+		subject = caller
+	}
+	if subject == "" {
+		return nil, apperr.Invalid("subject", "API key subject cannot be empty")
+	}
+	if subject != caller && !s.Authz.IsAdmin(ctx) {
+		return nil, apperr.PermissionDenied("ADMIN_REQUIRED", "only admins can create API keys for other subjects")
+	}
+	if req.GetExpiresInSeconds() < 0 {
+		return nil, apperr.Invalid("expires_in_seconds", "expires_in_seconds cannot be negative")
+	}
+
+	plaintext, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	key := &models.APIKey{
+		ID:      uuid.New().String(),
+		Name:    req.GetName(),
+		Subject: subject,
+		Prefix:  prefix,
+		Hash:    hash,
+	}
+	if req.GetExpiresInSeconds() > 0 {
+		expires := time.Now().Add(time.Duration(req.GetExpiresInSeconds()) * time.Second)
+		key.ExpiresAt = &expires
+	}
+
+	if err := s.Repo.CreateAPIKey(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to store API key: %w", err)
+	}
+
+	return &proto.CreateAPIKeyResponse{
+		Key:    convertAPIKeyToProto(key),
+		Secret: plaintext,
+	}, nil
+}
+
+func (s *AuthService) ListAPIKeys(ctx context.Context, req *proto.ListAPIKeysRequest) (*proto.ListAPIKeysResponse, error) {
+	keys, err := s.Repo.ListAPIKeys(ctx, auth.Subject(ctx, ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	resp := &proto.ListAPIKeysResponse{}
+	for _, k := range keys {
+		resp.Keys = append(resp.Keys, convertAPIKeyToProto(k))
+	}
+	return resp, nil
+}
+
+func (s *AuthService) RevokeAPIKey(ctx context.Context, req *proto.RevokeAPIKeyRequest) (*proto.APIKeyInfo, error) {
+	key, err := s.Repo.GetAPIKey(ctx, req.GetId())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperr.NotFound("API key", req.GetId())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subject := auth.Subject(ctx, ""); subject != key.Subject && !s.Authz.IsAdmin(ctx) {
+		return nil, apperr.NotFound("API key", req.GetId())
+	}
+
+	if err := s.Repo.RevokeAPIKey(ctx, key.ID); err != nil {
+		return nil, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	key, err = s.Repo.GetAPIKey(ctx, key.ID)
+	if err != nil {
+		return nil, err
+	}
+	return convertAPIKeyToProto(key), nil
+}
+
+// EnsureBootstrapKey stores the operator-supplied bootstrap key so the first
+// real keys can be created through the API.
+func (s *AuthService) EnsureBootstrapKey(ctx context.Context, plaintext string) error {
+	if !auth.IsAPIKey(plaintext) {
+		return fmt.Errorf("bootstrap API key must start with %q", auth.APIKeyPrefix)
+	}
+
+	hash := auth.HashToken(plaintext)
+	if _, err := s.Repo.FindAPIKeyByHash(ctx, hash); err == nil {
+		return nil
+	}
+
+	key := &models.APIKey{
+		ID:      uuid.New().String(),
+		Name:    "bootstrap",
+		Subject: BootstrapSubject,
+		Prefix:  plaintext[:len(auth.APIKeyPrefix)+6],
+		Hash:    hash,
+	}
+	if err := s.Repo.CreateAPIKey(ctx, key); err != nil {
+		return err
+	}
+	log.Info().Str("key_id", key.ID).Msg("Bootstrap API key registered")
+	return nil
+}
+
+func convertAPIKeyToProto(k *models.APIKey) *proto.APIKeyInfo {
+	info := &proto.APIKeyInfo{
+		Id:        k.ID,
+		Name:      k.Name,
+		Subject:   k.Subject,
+		Prefix:    k.Prefix,
+		CreatedAt: timestamppb.New(k.CreatedAt),
+	}
+	if k.ExpiresAt != nil {
+		info.ExpiresAt = timestamppb.New(*k.ExpiresAt)
+	}
+	if k.RevokedAt != nil {
+		info.RevokedAt = timestamppb.New(*k.RevokedAt)
+	}
+	if k.LastUsedAt != nil {
+		info.LastUsedAt = timestamppb.New(*k.LastUsedAt)
+	}
+	return info
+}
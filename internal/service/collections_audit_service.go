@@ -0,0 +1,168 @@
+package service
+
+import (
+	"bytes"
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/rbac"
+	"collectionsservice/internal/utils"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+	// auditExportBatch is how many events each export chunk holds.
+	auditExportBatch = 500
+)
+
+var auditCSVHeader = []string{
+	"id", "occurred_at", "method", "subject", "auth_method", "api_key_id",
+	"workspace_id", "collection_id", "peer_address", "forwarded_for",
+	"outcome", "code", "error", "duration_ms", "payload", "before", "after",
+}
+
+func (s *CollectionService) ListAuditEvents(ctx context.Context, req *proto.ListAuditEventsRequest) (*proto.ListAuditEventsResponse, error) {
+	filter, err := s.auditFilter(ctx, req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	filter.Limit = int(req.GetLimit())
+	switch {
+	case filter.Limit <= 0:
+		filter.Limit = defaultAuditLimit
+	case filter.Limit > maxAuditLimit:
+		filter.Limit = maxAuditLimit
+	}
+
+	events, err := s.Repo.ListAuditEvents(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	resp := &proto.ListAuditEventsResponse{}
+	for _, e := range events {
+		resp.Events = append(resp.Events, utils.ConvertAuditEventToProto(e))
+	}
+	return resp, nil
+}
+
+// ExportAuditEvents pages through the matching events newest first and
+// sends each page as one chunk.
+func (s *CollectionService) ExportAuditEvents(req *proto.ExportAuditEventsRequest, stream grpc.ServerStreamingServer[proto.AuditExportChunk]) error {
+	ctx := stream.Context()
+	filter, err := s.auditFilter(ctx, req.GetFilter())
+	if err != nil {
+		return err
+	}
+	filter.Limit = auditExportBatch
+
+	format := req.GetFormat()
+	header := format == proto.AuditExportFormat_AUDIT_EXPORT_FORMAT_CSV
+	for {
+		events, err := s.Repo.ListAuditEvents(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list audit events: %w", err)
+		}
+
+		var buf bytes.Buffer
+		if format == proto.AuditExportFormat_AUDIT_EXPORT_FORMAT_CSV {
+			err = writeAuditCSV(&buf, events, header)
+			header = false
+		} else {
+			err = writeAuditJSONLines(&buf, events)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to encode audit events: %w", err)
+		}
+		if buf.Len() > 0 {
+			if err := stream.Send(&proto.AuditExportChunk{Data: buf.Bytes()}); err != nil {
+				return err
+			}
+		}
+
+		if len(events) < auditExportBatch {
+			return nil
+		}
+		filter.Before = events[len(events)-1]
+	}
+}
+
+// auditFilter scopes f to the caller's workspace, which they must own, or
+// to every workspace for admins.
+func (s *CollectionService) auditFilter(ctx context.Context, f *proto.AuditEventFilter) (models.AuditFilter, error) {
+	filter := models.AuditFilter{
+		Subject:      f.GetSubject(),
+		Method:       f.GetMethod(),
+		CollectionID: f.GetCollectionId(),
+		Outcome:      utils.ConvertProtoAuditOutcome(f.GetOutcome()),
+	}
+	if f.GetSince() != nil {
+		filter.Since = f.GetSince().AsTime()
+	}
+	if f.GetUntil() != nil {
+		filter.Until = f.GetUntil().AsTime()
+	}
+
+	if f.GetAllWorkspaces() {
+		if !s.Authz.IsAdmin(ctx) {
+			return filter, apperr.PermissionDenied("ADMIN_REQUIRED", "only admins can read the audit log of every workspace")
+		}
+		return filter, nil
+	}
+	workspaceID, err := s.scopedWorkspace(ctx, rbac.PermManage)
+	if err != nil {
+		return filter, err
+	}
+	filter.WorkspaceID = workspaceID
+	return filter, nil
+}
+
+func writeAuditJSONLines(buf *bytes.Buffer, events []*models.AuditEvent) error {
+	opts := protojson.MarshalOptions{UseProtoNames: true}
+	for _, e := range events {
+		line, err := opts.Marshal(utils.ConvertAuditEventToProto(e))
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return nil
+}
+
+func writeAuditCSV(buf *bytes.Buffer, events []*models.AuditEvent, header bool) error {
+	w := csv.NewWriter(buf)
+	if header {
+		if err := w.Write(auditCSVHeader); err != nil {
+			return err
+		}
+	}
+	for _, e := range events {
+		var collectionID, errMsg string
+		if e.CollectionID != nil {
+			collectionID = *e.CollectionID
+		}
+		if e.Error != nil {
+			errMsg = *e.Error
+		}
+		if err := w.Write([]string{
+			e.ID, e.CreatedAt.UTC().Format(time.RFC3339Nano), e.Method, e.Subject, e.AuthMethod, e.APIKeyID,
+			e.WorkspaceID, collectionID, e.PeerAddress, e.ForwardedFor,
+			string(e.Outcome), e.Code, errMsg, strconv.FormatInt(e.DurationMs, 10),
+			string(e.Payload), string(e.Before), string(e.After),
+		}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
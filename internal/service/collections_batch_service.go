@@ -0,0 +1,129 @@
+package service
+
+import (
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/utils"
+	"collectionsservice/internal/workspace"
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (s *CollectionService) BatchAddRequestsToCollection(ctx context.Context, req *proto.BatchAddRequestsToCollectionRequest) (*proto.BatchRequestsResponse, error) {
+	if s.Repo == nil {
+		return nil, fmt.Errorf("repository is not initialized")
+	}
+
+	workspaceID, err := workspace.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetCollectionId() != "" {
+		err = s.ensureWritable(ctx, req.GetCollectionId())
+	} else {
+		err = s.ensureWritableByName(ctx, req.GetCollectionName())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	reqModels, err := utils.ConvertProtoRequests(req.GetRequests(), req.GetCollectionId())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to convert batch request input")
+		return nil, fmt.Errorf("failed to convert request input: %w", err)
+	}
+	if err := s.sealRequests(reqModels, nil); err != nil {
+		return nil, err
+	}
+
+	if req.GetCollectionId() != "" {
+		err = s.Repo.AddRequestsToCollectionByID(ctx, req.GetCollectionId(), reqModels)
+	} else {
+		err = s.Repo.AddRequestToCollection(ctx, workspaceID, req.GetCollectionName(), reqModels)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("collection_name", req.GetCollectionName()).Str("collection_id", req.GetCollectionId()).Msg("Failed to batch add requests")
+		return nil, fmt.Errorf("failed to add requests to collection: %w", err)
+	}
+
+	ids := make([]string, 0, len(reqModels))
+	for _, r := range reqModels {
+		ids = append(ids, r.ID)
+	}
+
+	return &proto.BatchRequestsResponse{
+		Success:    true,
+		Message:    fmt.Sprintf("%d requests added to collection", len(ids)),
+		RequestIds: ids,
+	}, nil
+}
+
+func (s *CollectionService) BatchUpdateRequestsInCollection(ctx context.Context, req *proto.BatchUpdateRequestsInCollectionRequest) (*proto.BatchRequestsResponse, error) {
+	if s.Repo == nil {
+		return nil, fmt.Errorf("repository is not initialized")
+	}
+	if err := s.ensureWritable(ctx, req.GetCollectionId()); err != nil {
+		return nil, err
+	}
+
+	inputs := make([]models.Request, 0, len(req.GetRequests()))
+	ids := make([]string, 0, len(req.GetRequests()))
+	for _, in := range req.GetRequests() {
+		input := utils.ConvertProtoUpdateRequest(in)
+		input.ID = in.GetRequestId()
+		var err error
+		if input.Auth, err = utils.ConvertProtoAuth(in.GetAuth()); err != nil {
+			return nil, err
+		}
+		if input.Assertions, err = utils.ConvertProtoAssertions(in.GetAssertions()); err != nil {
+			return nil, err
+		}
+		if input.Captures, err = utils.ConvertProtoCaptures(in.GetCaptures()); err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, *input)
+		ids = append(ids, input.ID)
+	}
+
+	stored, err := s.storedRequests(ctx, req.GetCollectionId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sealRequests(inputs, stored); err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.UpdateRequestsInCollection(ctx, req.GetCollectionId(), inputs); err != nil {
+		log.Error().Err(err).Str("collection_id", req.GetCollectionId()).Msg("Failed to batch update requests")
+		return nil, fmt.Errorf("failed to update requests in collection: %w", err)
+	}
+
+	return &proto.BatchRequestsResponse{
+		Success:    true,
+		Message:    fmt.Sprintf("%d requests updated", len(ids)),
+		RequestIds: ids,
+	}, nil
+}
+
+func (s *CollectionService) BatchDeleteRequestsFromCollection(ctx context.Context, req *proto.BatchDeleteRequestsFromCollectionRequest) (*proto.BatchRequestsResponse, error) {
+	if s.Repo == nil {
+		return nil, fmt.Errorf("repository is not initialized")
+	}
+	if err := s.ensureWritable(ctx, req.GetCollectionId()); err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.RemoveRequestsFromCollection(ctx, req.GetCollectionId(), req.GetRequestIds()); err != nil {
+		log.Error().Err(err).Str("collection_id", req.GetCollectionId()).Msg("Failed to batch delete requests")
+		return nil, fmt.Errorf("failed to remove requests from collection: %w", err)
+	}
+
+	return &proto.BatchRequestsResponse{
+		Success:    true,
+		Message:    fmt.Sprintf("%d requests removed from collection", len(req.GetRequestIds())),
+		RequestIds: req.GetRequestIds(),
+	}, nil
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/executor"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/rbac"
+	"collectionsservice/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var errExampleNotFound = apperr.NotFound("example", "")
+
+func (s *CollectionService) CreateExample(ctx context.Context, req *proto.CreateExampleRequest) (*proto.ExampleResponse, error) {
+	if req.GetName() == "" {
+		return nil, apperr.Invalid("name", "example name cannot be empty")
+	}
+	if err := checkStatusCode(req.GetStatusCode()); err != nil {
+		return nil, err
+	}
+
+	col, err := s.scopedCollection(ctx, req.GetCollectionId(), true, rbac.PermWrite)
+	if err != nil {
+		return nil, err
+	}
+	if !hasRequest(col, req.GetRequestId()) {
+		return nil, apperr.NotFound("request", req.GetRequestId())
+	}
+
+	example := &models.Example{
+		ID:         uuid.New().String(),
+		RequestID:  req.GetRequestId(),
+		Name:       req.GetName(),
+		StatusCode: int(req.GetStatusCode()),
+		Body:       req.GetBody(),
+	}
+	if example.Headers, err = utils.ConvertProtoExampleHeaders(req.GetHeaders()); err != nil {
+		return nil, err
+	}
+	if err := utils.ConvertProtoExampleVariant(req.GetRequest(), example); err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.CreateExample(ctx, example); err != nil {
+		return nil, fmt.Errorf("failed to create example: %w", err)
+	}
+	return utils.ConvertExampleToProto(example), nil
+}
+
+func (s *CollectionService) GetExample(ctx context.Context, req *proto.GetExampleRequest) (*proto.ExampleResponse, error) {
+	example, err := s.scopedExample(ctx, req.GetCollectionId(), req.GetId(), rbac.PermRead)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertExampleToProto(example), nil
+}
+
+func (s *CollectionService) ListExamples(ctx context.Context, req *proto.ListExamplesRequest) (*proto.ListExamplesResponse, error) {
+	if _, err := s.scopedCollection(ctx, req.GetCollectionId(), false, rbac.PermRead); err != nil {
+		return nil, err
+	}
+
+	examples, err := s.Repo.ListExamples(ctx, req.GetCollectionId(), req.GetRequestId())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list examples: %w", err)
+	}
+
+	resp := &proto.ListExamplesResponse{}
+	for _, e := range examples {
+		resp.Examples = append(resp.Examples, utils.ConvertExampleToProto(e))
+	}
+	return resp, nil
+}
+
+func (s *CollectionService) UpdateExample(ctx context.Context, req *proto.UpdateExampleRequest) (*proto.ExampleResponse, error) {
+	example, err := s.scopedExample(ctx, req.GetCollectionId(), req.GetId(), rbac.PermWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetName() != "" {
+		example.Name = req.GetName()
+	}
+	if req.GetStatusCode() != 0 {
+		if err := checkStatusCode(req.GetStatusCode()); err != nil {
+			return nil, err
+		}
+		example.StatusCode = int(req.GetStatusCode())
+	}
+	if len(req.GetHeaders()) > 0 {
+		if example.Headers, err = utils.ConvertProtoExampleHeaders(req.GetHeaders()); err != nil {
+			return nil, err
+		}
+	}
+	if req.GetBody() != "" {
+		example.Body = req.GetBody()
+	}
+	if req.GetRequest() != nil {
+		if err := utils.ConvertProtoExampleVariant(req.GetRequest(), example); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.Repo.UpdateExample(ctx, example); err != nil {
+		return nil, fmt.Errorf("failed to update example: %w", err)
+	}
+	return utils.ConvertExampleToProto(example), nil
+}
+
+func (s *CollectionService) DeleteExample(ctx context.Context, req *proto.DeleteExampleRequest) (*proto.DeleteResponse, error) {
+	if _, err := s.scopedExample(ctx, req.GetCollectionId(), req.GetId(), rbac.PermWrite); err != nil {
+		return nil, err
+	}
+	if err := s.Repo.DeleteExample(ctx, req.GetId()); err != nil {
+		return nil, fmt.Errorf("failed to delete example: %w", err)
+	}
+	return &proto.DeleteResponse{
+		Success: true,
+		Message: "Example deleted successfully",
+	}, nil
+}
+
+// saveExample stores an executed response as an example of r. Only the
+// response is kept: the sent request may hold opened secrets.
+func (s *CollectionService) saveExample(ctx context.Context, r *models.Request, name string, result *executor.Result) (string, error) {
+	example := &models.Example{
+		ID:         uuid.New().String(),
+		RequestID:  r.ID,
+		Name:       name,
+		StatusCode: result.StatusCode,
+		Headers:    utils.ConvertHTTPHeaders(result.Headers),
+		Body:       string(result.Body),
+	}
+	if err := s.Repo.CreateExample(ctx, example); err != nil {
+		return "", fmt.Errorf("failed to save example: %w", err)
+	}
+	return example.ID, nil
+}
+
+// scopedExample loads an example after checking that it belongs to a
+// collection the caller holds perm on.
+func (s *CollectionService) scopedExample(ctx context.Context, collectionID, id string, perm rbac.Permission) (*models.Example, error) {
+	if _, err := s.scopedCollection(ctx, collectionID, false, perm); err != nil {
+		return nil, err
+	}
+	example, err := s.Repo.GetExample(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errExampleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if example.Request.CollectionID != collectionID {
+		return nil, errExampleNotFound
+	}
+	return example, nil
+}
+
+func hasRequest(col *models.Collection, requestID string) bool {
+	for _, r := range col.Requests {
+		if r.ID == requestID {
+			return true
+		}
+	}
+	return false
+}
+
+func checkStatusCode(code int32) error {
+	if code < 100 || code > 599 {
+		return apperr.Invalid("status_code", "status code %d must be between 100 and 599", code)
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/auth"
+	"collectionsservice/internal/executor"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/rbac"
+	"collectionsservice/internal/secrets"
+	"collectionsservice/internal/utils"
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/status"
+	"gorm.io/datatypes"
+)
+
+func (s *CollectionService) ExecuteRequest(ctx context.Context, req *proto.ExecuteRequestRequest) (*proto.ExecuteRequestResponse, error) {
+	col, err := s.runnableCollection(ctx, req.GetCollectionId(), req.GetShareToken())
+	if err != nil {
+		return nil, err
+	}
+
+	var target *models.Request
+	for i := range col.Requests {
+		if col.Requests[i].ID == req.GetRequestId() {
+			target = &col.Requests[i]
+		}
+	}
+	if target == nil {
+		return nil, apperr.NotFound("request", req.GetRequestId())
+	}
+	if req.GetSaveAsExample() != "" {
+		if req.GetShareToken() != "" {
+			return nil, apperr.PermissionDenied("SHARE_TOKEN_SCOPE", "examples cannot be saved through a share token")
+		}
+		if err := s.Authz.CheckCollection(ctx, col, rbac.PermWrite); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.Secrets.OpenCollection(col); err != nil {
+		return nil, openSecretError(err)
+	}
+
+	startedAt := time.Now()
+	outcome := s.Executor.Run(ctx, col, target, collectionVariables(col, req.GetVariables()))
+	if outcome.Err != nil {
+		log.Warn().Err(outcome.Err).Str("request_id", target.ID).Msg("Request execution failed")
+	}
+	redactor := secrets.CollectionRedactor(col)
+	runID := s.recordRun(ctx, target, outcome, redactor, auth.Subject(ctx, ""), nil, startedAt)
+
+	captured := make(map[string]string)
+	collectionCaptures(outcome, captured)
+
+	if s.mayReveal(ctx, col, req.GetShareToken()) {
+		redactor = nil
+	}
+	resp := utils.ConvertOutcomeToProto(outcome, redactor)
+	resp.RunId = runID
+	resp.SavedVariables = s.saveCaptures(ctx, col, req.GetShareToken() != "", captured)
+	if req.GetSaveAsExample() != "" && outcome.Result != nil {
+		if resp.ExampleId, err = s.saveExample(ctx, target, req.GetSaveAsExample(), outcome.Result); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func (s *CollectionService) RunCollection(ctx context.Context, req *proto.RunCollectionRequest) (*proto.RunCollectionResponse, error) {
+	col, err := s.runnableCollection(ctx, req.GetCollectionId(), req.GetShareToken())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Secrets.OpenCollection(col); err != nil {
+		return nil, openSecretError(err)
+	}
+
+	reveal := s.mayReveal(ctx, col, req.GetShareToken())
+	resp, captured, err := s.runRequests(ctx, col, collectionVariables(col, req.GetVariables()), req.GetStopOnFailure(), reveal, auth.Subject(ctx, ""))
+	if err != nil {
+		return nil, err
+	}
+	resp.SavedVariables = s.saveCaptures(ctx, col, req.GetShareToken() != "", captured)
+
+	log.Info().Str("collection_id", col.ID).Int("requests", len(resp.Results)).
+		Int32("tests_failed", resp.TestsFailed).Msg("Collection run finished")
+	return resp, nil
+}
+
+// runRequests runs every request of an opened collection in order, recording
+// each in run history. Secrets are hidden in the results unless reveal is
+// set. It returns the collection-scoped values captured along the way, for
+// the caller to save if it may.
+func (s *CollectionService) runRequests(ctx context.Context, col *models.Collection, vars map[string]string, stopOnFailure, reveal bool, triggeredBy string) (*proto.RunCollectionResponse, map[string]string, error) {
+	runID := uuid.New().String()
+	resp := &proto.RunCollectionResponse{CollectionRunId: runID}
+	redactor := secrets.CollectionRedactor(col)
+	shown := redactor
+	if reveal {
+		shown = nil
+	}
+	captured := make(map[string]string)
+	for i := range col.Requests {
+		r := &col.Requests[i]
+		startedAt := time.Now()
+		outcome := s.Executor.Run(ctx, col, r, vars)
+		collectionCaptures(outcome, captured)
+
+		result := utils.ConvertOutcomeToProto(outcome, shown)
+		result.RunId = s.recordRun(ctx, r, outcome, redactor, triggeredBy, &runID, startedAt)
+		resp.Results = append(resp.Results, &proto.RequestRunResult{
+			RequestId: r.ID,
+			Name:      r.Name,
+			Response:  result,
+		})
+		for _, a := range outcome.Assertions {
+			if a.Passed {
+				resp.AssertionsPassed++
+			} else {
+				resp.AssertionsFailed++
+			}
+		}
+		for _, t := range outcome.Tests {
+			if t.Passed {
+				resp.TestsPassed++
+			} else {
+				resp.TestsFailed++
+			}
+		}
+		if outcome.Err != nil || outcome.ScriptErr != nil {
+			resp.RequestsFailed++
+		}
+		if stopOnFailure && outcome.Failed() {
+			break
+		}
+		if ctx.Err() != nil {
+			return nil, nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	return resp, captured, nil
+}
+
+// saveCaptures writes collection-scoped captures back to the collection's
+// variables, keeping each variable's secret flag. Values captured through a
+// share token, by callers who may not edit the collection, or on a protected
+// collection only last for the run.
+func (s *CollectionService) saveCaptures(ctx context.Context, col *models.Collection, shared bool, captured map[string]string) []string {
+	if len(captured) == 0 || shared || col.Protected {
+		return nil
+	}
+	if err := s.Authz.CheckCollection(ctx, col, rbac.PermWrite); err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(captured))
+	for name := range captured {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	err := s.Repo.UpdateVariables(ctx, col.ID, func(stored datatypes.JSON) (datatypes.JSON, error) {
+		entries, _ := secrets.ParseEntries(stored)
+		known := make(map[string]bool, len(entries))
+		for i := range entries {
+			if v, ok := captured[entries[i].Key]; ok {
+				entries[i].Value = v
+				known[entries[i].Key] = true
+			}
+		}
+		for _, name := range names {
+			if !known[name] {
+				entries = append(entries, secrets.Entry{Key: name, Value: captured[name]})
+			}
+		}
+		raw, err := json.Marshal(entries)
+		if err != nil {
+			return nil, err
+		}
+		return s.sealVariables(datatypes.JSON(raw), stored)
+	})
+	if err != nil {
+		log.Warn().Err(err).Str("collection_id", col.ID).Msg("Failed to save captured variables")
+		return nil
+	}
+	return names
+}
+
+// collectionCaptures adds the collection-scoped values captured in o to
+// captured. Later captures of the same variable win.
+func collectionCaptures(o *executor.Outcome, captured map[string]string) {
+	for _, c := range o.Captures {
+		if c.Captured && c.Capture.Scope == models.CaptureScopeCollection {
+			captured[c.Capture.Variable] = c.Value
+		}
+	}
+}
+
+// runnableCollection loads a collection with its requests for execution,
+// either for a caller with the run permission or through a run-access share.
+func (s *CollectionService) runnableCollection(ctx context.Context, collectionID, shareToken string) (*models.Collection, error) {
+	if shareToken == "" {
+		return s.scopedCollection(ctx, collectionID, true, rbac.PermRun)
+	}
+
+	share, err := s.resolveShare(ctx, shareToken)
+	if err != nil {
+		return nil, err
+	}
+	if share.Access != models.ShareAccessRun {
+		return nil, apperr.PermissionDenied("SHARE_TOKEN_SCOPE", "share token does not grant run access")
+	}
+	if collectionID != "" && collectionID != share.CollectionID {
+		return nil, apperr.PermissionDenied("SHARE_TOKEN_SCOPE", "share token was issued for another collection")
+	}
+	return &share.Collection, nil
+}
+
+// mayReveal reports whether the caller may see the secrets of col in
+// execution results. Callers using a share token never may.
+func (s *CollectionService) mayReveal(ctx context.Context, col *models.Collection, shareToken string) bool {
+	return shareToken == "" && s.Authz.CheckCollection(ctx, col, rbac.PermReveal) == nil
+}
+
+// collectionVariables merges opened collection variables with per-execution
+// overrides.
+func collectionVariables(col *models.Collection, overrides map[string]string) map[string]string {
+	vars := make(map[string]string)
+	entries, _ := secrets.ParseEntries(col.Variables)
+	for _, e := range entries {
+		vars[e.Key] = e.Value
+	}
+	for k, v := range overrides {
+		vars[k] = v
+	}
+	return vars
+}
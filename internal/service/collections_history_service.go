@@ -0,0 +1,100 @@
+package service
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/executor"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/rbac"
+	"collectionsservice/internal/secrets"
+	"collectionsservice/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultRunHistoryLimit = 100
+	maxRunHistoryLimit     = 1000
+)
+
+var errRunNotFound = apperr.NotFound("run", "")
+
+func (s *CollectionService) ListRunHistory(ctx context.Context, req *proto.ListRunHistoryRequest) (*proto.ListRunHistoryResponse, error) {
+	if _, err := s.scopedCollection(ctx, req.GetCollectionId(), false, rbac.PermRead); err != nil {
+		return nil, err
+	}
+
+	filter := models.RunFilter{
+		CollectionID:    req.GetCollectionId(),
+		RequestID:       req.GetRequestId(),
+		CollectionRunID: req.GetCollectionRunId(),
+		Status:          utils.ConvertProtoRunStatus(req.GetStatus()),
+		StatusCodeMin:   int(req.GetStatusCodeMin()),
+		StatusCodeMax:   int(req.GetStatusCodeMax()),
+		Limit:           int(req.GetLimit()),
+	}
+	if req.GetSince() != nil {
+		filter.Since = req.GetSince().AsTime()
+	}
+	if req.GetUntil() != nil {
+		filter.Until = req.GetUntil().AsTime()
+	}
+	switch {
+	case filter.Limit <= 0:
+		filter.Limit = defaultRunHistoryLimit
+	case filter.Limit > maxRunHistoryLimit:
+		filter.Limit = maxRunHistoryLimit
+	}
+
+	runs, err := s.Repo.ListRuns(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list run history: %w", err)
+	}
+
+	resp := &proto.ListRunHistoryResponse{}
+	for _, run := range runs {
+		resp.Runs = append(resp.Runs, utils.ConvertRunToProto(run))
+	}
+	return resp, nil
+}
+
+func (s *CollectionService) GetRun(ctx context.Context, req *proto.GetRunRequest) (*proto.RunRecord, error) {
+	if _, err := s.scopedCollection(ctx, req.GetCollectionId(), false, rbac.PermRead); err != nil {
+		return nil, err
+	}
+
+	run, err := s.Repo.GetRun(ctx, req.GetId())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errRunNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if run.CollectionID != req.GetCollectionId() {
+		return nil, errRunNotFound
+	}
+	return utils.ConvertRunToProto(run), nil
+}
+
+// recordRun stores o in the run history and returns the record's ID. The
+// record is written even if ctx was cancelled, and a failure to write it is
+// logged rather than failing the execution.
+func (s *CollectionService) recordRun(ctx context.Context, r *models.Request, o *executor.Outcome, redactor *secrets.Redactor, triggeredBy string, collectionRunID *string, startedAt time.Time) string {
+	run := utils.ConvertOutcomeToRun(o, r, redactor, s.RunBodyLimit)
+	run.ID = uuid.New().String()
+	run.CollectionRunID = collectionRunID
+	run.TriggeredBy = triggeredBy
+	run.StartedAt = startedAt
+
+	if err := s.Repo.CreateRun(context.WithoutCancel(ctx), run); err != nil {
+		log.Warn().Err(err).Str("request_id", r.ID).Msg("Failed to record run history")
+		return ""
+	}
+	return run.ID
+}
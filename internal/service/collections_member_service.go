@@ -0,0 +1,74 @@
+package service
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/rbac"
+	"collectionsservice/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func (s *CollectionService) GrantCollectionRole(ctx context.Context, req *proto.GrantCollectionRoleRequest) (*proto.Member, error) {
+	if req.GetSubject() == "" {
+		return nil, apperr.Invalid("subject", "member subject cannot be empty")
+	}
+	role, err := rbac.ParseRole(req.GetRole())
+	if err != nil {
+		return nil, apperr.Invalid("role", "%v", err)
+	}
+
+	if _, err := s.scopedCollection(ctx, req.GetCollectionId(), false, rbac.PermManage); err != nil {
+		return nil, err
+	}
+
+	member := &models.CollectionMember{
+		CollectionID: req.GetCollectionId(),
+		Subject:      req.GetSubject(),
+		Role:         role,
+	}
+	if err := s.Members.GrantCollectionRole(ctx, member); err != nil {
+		return nil, fmt.Errorf("failed to grant collection role: %w", err)
+	}
+	return utils.ConvertMemberToProto(member.Subject, member.Role, member.CreatedAt), nil
+}
+
+func (s *CollectionService) RevokeCollectionRole(ctx context.Context, req *proto.RevokeCollectionRoleRequest) (*proto.DeleteResponse, error) {
+	if _, err := s.scopedCollection(ctx, req.GetCollectionId(), false, rbac.PermManage); err != nil {
+		return nil, err
+	}
+
+	err := s.Members.RevokeCollectionRole(ctx, req.GetCollectionId(), req.GetSubject())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperr.NotFound("collection member", req.GetSubject())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke collection role: %w", err)
+	}
+
+	return &proto.DeleteResponse{
+		Success: true,
+		Message: "Collection role revoked successfully",
+	}, nil
+}
+
+func (s *CollectionService) ListCollectionMembers(ctx context.Context, req *proto.ListCollectionMembersRequest) (*proto.ListMembersResponse, error) {
+	if _, err := s.scopedCollection(ctx, req.GetCollectionId(), false, rbac.PermRead); err != nil {
+		return nil, err
+	}
+
+	members, err := s.Members.ListCollectionMembers(ctx, req.GetCollectionId())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collection members: %w", err)
+	}
+
+	resp := &proto.ListMembersResponse{}
+	for _, m := range members {
+		resp.Members = append(resp.Members, utils.ConvertMemberToProto(m.Subject, m.Role, m.CreatedAt))
+	}
+	return resp, nil
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/merge"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/rbac"
+	"collectionsservice/internal/utils"
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (s *CollectionService) PreviewMergeCollections(ctx context.Context, req *proto.PreviewMergeCollectionsRequest) (*proto.PreviewMergeCollectionsResponse, error) {
+	plan, err := s.buildMergePlan(ctx, req.GetSourceCollectionId(), req.GetTargetCollectionId(), req.GetMatchBy())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.PreviewMergeCollectionsResponse{
+		PreviewToken: plan.Token(),
+	}
+	matchBy := mergeMatchBy(req.GetMatchBy())
+	for _, r := range plan.Added {
+		resp.Added = append(resp.Added, &proto.MergeRequestRef{
+			Key:             merge.Key(r, matchBy),
+			SourceRequestId: r.ID,
+			Name:            r.Name,
+		})
+	}
+	for _, r := range plan.Skipped {
+		resp.Skipped = append(resp.Skipped, &proto.MergeRequestRef{
+			Key:             merge.Key(r, matchBy),
+			SourceRequestId: r.ID,
+			Name:            r.Name,
+		})
+	}
+	for _, c := range plan.Conflicts {
+		resp.Conflicts = append(resp.Conflicts, utils.ConvertMergeConflictToProto(c))
+	}
+
+	return resp, nil
+}
+
+func (s *CollectionService) MergeCollections(ctx context.Context, req *proto.MergeCollectionsRequest) (*proto.MergeCollectionsResponse, error) {
+	plan, err := s.buildMergePlan(ctx, req.GetSourceCollectionId(), req.GetTargetCollectionId(), req.GetMatchBy())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureWritable(ctx, req.GetTargetCollectionId()); err != nil {
+		return nil, err
+	}
+
+	if req.GetPreviewToken() != "" && req.GetPreviewToken() != plan.Token() {
+		return nil, apperr.Aborted("MERGE_PREVIEW_STALE", "collections changed since the merge preview was taken, preview again")
+	}
+
+	resolutions := make(map[string]merge.Resolution, len(req.GetResolutions()))
+	for _, r := range req.GetResolutions() {
+		resolutions[r.GetKey()] = mergeResolution(r.GetResolution())
+	}
+
+	changes, err := plan.Resolve(req.GetTargetCollectionId(), resolutions, mergeResolution(req.GetDefaultResolution()))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sealRequests(changes.Creates, nil); err != nil {
+		return nil, err
+	}
+	if err := s.sealRequests(changes.Updates, nil); err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.ApplyMerge(ctx, req.GetTargetCollectionId(), changes.Creates, changes.Updates); err != nil {
+		log.Error().Err(err).Str("collection_id", req.GetTargetCollectionId()).Msg("Failed to apply merge")
+		return nil, fmt.Errorf("failed to merge collections: %w", err)
+	}
+
+	target, err := s.Repo.GetByIDWithRequests(ctx, req.GetTargetCollectionId())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch merged collection: %w", err)
+	}
+
+	return &proto.MergeCollectionsResponse{
+		CreatedCount: int32(len(changes.Creates)),
+		UpdatedCount: int32(len(changes.Updates)),
+		SkippedCount: int32(len(plan.Skipped)),
+		Collection:   utils.ConvertModelCollectionToProto(target),
+	}, nil
+}
+
+func (s *CollectionService) buildMergePlan(ctx context.Context, sourceID, targetID string, matchBy proto.MergeMatchBy) (*merge.Plan, error) {
+	if s.Repo == nil {
+		return nil, fmt.Errorf("repository is not initialized")
+	}
+	if sourceID == "" || targetID == "" {
+		return nil, apperr.Invalid("source_collection_id", "source and target collection ids are required")
+	}
+	if sourceID == targetID {
+		return nil, apperr.Invalid("target_collection_id", "cannot merge a collection into itself")
+	}
+
+	source, err := s.scopedCollection(ctx, sourceID, true, rbac.PermRead)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch source collection: %w", err)
+	}
+	target, err := s.scopedCollection(ctx, targetID, true, rbac.PermRead)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch target collection: %w", err)
+	}
+
+	// Secrets are compared in plaintext: the same value sealed twice never
+	// has the same ciphertext. MergeCollections seals them again.
+	if err := s.Secrets.OpenCollection(source); err != nil {
+		return nil, openSecretError(err)
+	}
+	if err := s.Secrets.OpenCollection(target); err != nil {
+		return nil, openSecretError(err)
+	}
+
+	return merge.BuildPlan(source, target, mergeMatchBy(matchBy)), nil
+}
+
+func mergeMatchBy(m proto.MergeMatchBy) merge.MatchBy {
+	if m == proto.MergeMatchBy_MERGE_MATCH_BY_METHOD_URL {
+		return merge.MatchByMethodURL
+	}
+	return merge.MatchByName
+}
+
+func mergeResolution(r proto.MergeResolution) merge.Resolution {
+	switch r {
+	case proto.MergeResolution_MERGE_RESOLUTION_OURS:
+		return merge.ResolutionOurs
+	case proto.MergeResolution_MERGE_RESOLUTION_THEIRS:
+		return merge.ResolutionTheirs
+	case proto.MergeResolution_MERGE_RESOLUTION_KEEP_BOTH:
+		return merge.ResolutionKeepBoth
+	}
+	return merge.ResolutionUnspecified
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/auth"
+	"collectionsservice/internal/models"
+	"collectionsservice/internal/monitor"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/rbac"
+	"collectionsservice/internal/secrets"
+	"collectionsservice/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var errMonitorNotFound = apperr.NotFound("monitor", "")
+
+func (s *CollectionService) CreateMonitor(ctx context.Context, req *proto.CreateMonitorRequest) (*proto.MonitorResponse, error) {
+	if req.GetName() == "" {
+		return nil, apperr.Invalid("name", "monitor name cannot be empty")
+	}
+	timezone := req.GetTimezone()
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if err := monitor.Check(req.GetCron(), timezone); err != nil {
+		return nil, apperr.Invalid("cron", "%v", err)
+	}
+	if err := checkNotifyURL(req.GetNotifyUrl()); err != nil {
+		return nil, err
+	}
+	vars, err := utils.ConvertProtoMonitorVariables(req.GetVariables())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.scopedCollection(ctx, req.GetCollectionId(), false, rbac.PermWrite); err != nil {
+		return nil, err
+	}
+
+	m := &models.Monitor{
+		ID:           uuid.New().String(),
+		CollectionID: req.GetCollectionId(),
+		Name:         req.GetName(),
+		Cron:         req.GetCron(),
+		Timezone:     timezone,
+		Enabled:      !req.GetDisabled(),
+		Variables:    vars,
+		CreatedBy:    auth.Subject(ctx, ""),
+	}
+	if req.GetNotifyUrl() != "" {
+		m.NotifyURL = &req.NotifyUrl
+	}
+	if err := scheduleMonitor(m); err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.CreateMonitor(ctx, m); err != nil {
+		return nil, fmt.Errorf("failed to create monitor: %w", err)
+	}
+	return utils.ConvertMonitorToProto(m), nil
+}
+
+func (s *CollectionService) GetMonitor(ctx context.Context, req *proto.GetMonitorRequest) (*proto.MonitorResponse, error) {
+	m, err := s.scopedMonitor(ctx, req.GetCollectionId(), req.GetId(), rbac.PermRead)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertMonitorToProto(m), nil
+}
+
+func (s *CollectionService) ListMonitors(ctx context.Context, req *proto.ListMonitorsRequest) (*proto.ListMonitorsResponse, error) {
+	if _, err := s.scopedCollection(ctx, req.GetCollectionId(), false, rbac.PermRead); err != nil {
+		return nil, err
+	}
+
+	monitors, err := s.Repo.ListMonitors(ctx, req.GetCollectionId())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list monitors: %w", err)
+	}
+
+	resp := &proto.ListMonitorsResponse{}
+	for _, m := range monitors {
+		resp.Monitors = append(resp.Monitors, utils.ConvertMonitorToProto(m))
+	}
+	return resp, nil
+}
+
+func (s *CollectionService) UpdateMonitor(ctx context.Context, req *proto.UpdateMonitorRequest) (*proto.MonitorResponse, error) {
+	m, err := s.scopedMonitor(ctx, req.GetCollectionId(), req.GetId(), rbac.PermWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetName() != "" {
+		m.Name = req.GetName()
+	}
+	if req.GetCron() != "" {
+		m.Cron = req.GetCron()
+	}
+	if req.GetTimezone() != "" {
+		m.Timezone = req.GetTimezone()
+	}
+	if err := monitor.Check(m.Cron, m.Timezone); err != nil {
+		return nil, apperr.Invalid("cron", "%v", err)
+	}
+	if len(req.GetVariables()) > 0 {
+		if m.Variables, err = utils.ConvertProtoMonitorVariables(req.GetVariables()); err != nil {
+			return nil, err
+		}
+	}
+	if req.GetNotifyUrl() != "" {
+		if err := checkNotifyURL(req.GetNotifyUrl()); err != nil {
+			return nil, err
+		}
+		m.NotifyURL = &req.NotifyUrl
+	}
+	if err := scheduleMonitor(m); err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.UpdateMonitor(ctx, m); err != nil {
+		return nil, fmt.Errorf("failed to update monitor: %w", err)
+	}
+	return utils.ConvertMonitorToProto(m), nil
+}
+
+func (s *CollectionService) SetMonitorEnabled(ctx context.Context, req *proto.SetMonitorEnabledRequest) (*proto.MonitorResponse, error) {
+	m, err := s.scopedMonitor(ctx, req.GetCollectionId(), req.GetId(), rbac.PermWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Enabled = req.GetEnabled()
+	if err := scheduleMonitor(m); err != nil {
+		return nil, err
+	}
+	if err := s.Repo.UpdateMonitor(ctx, m); err != nil {
+		return nil, fmt.Errorf("failed to update monitor: %w", err)
+	}
+	return utils.ConvertMonitorToProto(m), nil
+}
+
+func (s *CollectionService) DeleteMonitor(ctx context.Context, req *proto.DeleteMonitorRequest) (*proto.DeleteResponse, error) {
+	if _, err := s.scopedMonitor(ctx, req.GetCollectionId(), req.GetId(), rbac.PermWrite); err != nil {
+		return nil, err
+	}
+	if err := s.Repo.DeleteMonitor(ctx, req.GetId()); err != nil {
+		return nil, fmt.Errorf("failed to delete monitor: %w", err)
+	}
+	return &proto.DeleteResponse{
+		Success: true,
+		Message: "Monitor deleted successfully",
+	}, nil
+}
+
+// RunMonitor runs the collection of m for the scheduler. Runs are recorded
+// in run history as triggered by the monitor; captured values are not saved
+// back to the collection.
+func (s *CollectionService) RunMonitor(ctx context.Context, m *models.Monitor) (*monitor.Summary, error) {
+	col, err := s.Repo.GetByIDWithRequests(ctx, m.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch collection: %w", err)
+	}
+	if err := s.Secrets.OpenCollection(col); err != nil {
+		return nil, err
+	}
+
+	overrides := make(map[string]string)
+	entries, _ := secrets.ParseEntries(m.Variables)
+	for _, e := range entries {
+		overrides[e.Key] = e.Value
+	}
+
+	resp, _, err := s.runRequests(ctx, col, collectionVariables(col, overrides), false, false, "monitor:"+m.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &monitor.Summary{
+		CollectionRunID:  resp.GetCollectionRunId(),
+		Requests:         len(resp.GetResults()),
+		RequestsFailed:   int(resp.GetRequestsFailed()),
+		TestsFailed:      int(resp.GetTestsFailed()),
+		AssertionsFailed: int(resp.GetAssertionsFailed()),
+	}, nil
+}
+
+// scopedMonitor loads a monitor after checking that it belongs to a
+// collection the caller holds perm on.
+func (s *CollectionService) scopedMonitor(ctx context.Context, collectionID, id string, perm rbac.Permission) (*models.Monitor, error) {
+	if _, err := s.scopedCollection(ctx, collectionID, false, perm); err != nil {
+		return nil, err
+	}
+	m, err := s.Repo.GetMonitor(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errMonitorNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if m.CollectionID != collectionID {
+		return nil, errMonitorNotFound
+	}
+	return m, nil
+}
+
+// scheduleMonitor sets the next run of m from now, or clears it while m is
+// disabled.
+func scheduleMonitor(m *models.Monitor) error {
+	if !m.Enabled {
+		m.NextRunAt = nil
+		return nil
+	}
+	next, err := monitor.Next(m.Cron, m.Timezone, time.Now())
+	if err != nil {
+		return apperr.Invalid("cron", "%v", err)
+	}
+	m.NextRunAt = &next
+	return nil
+}
+
+func checkNotifyURL(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return apperr.Invalid("notify_url", "notify url must be an http or https URL")
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/auth"
+	"collectionsservice/internal/merge"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/rbac"
+	"collectionsservice/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+func (s *CollectionService) ForkCollection(ctx context.Context, req *proto.ForkCollectionRequest) (*proto.CollectionResponse, error) {
+	if req.GetCollectionId() == "" {
+		return nil, apperr.Invalid("collection_id", "collection id cannot be empty")
+	}
+
+	upstream, err := s.scopedCollection(ctx, req.GetCollectionId(), true, rbac.PermRead)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch collection to fork: %w", err)
+	}
+
+	if _, err := s.scopedWorkspace(ctx, rbac.PermWrite); err != nil {
+		return nil, err
+	}
+
+	name := req.GetName()
+	if name == "" {
+		taken, err := s.Repo.CollectionNames(ctx, upstream.WorkspaceID)
+		if err != nil {
+			return nil, err
+		}
+		name = forkName(upstream.Name, taken)
+	}
+
+	fork := &models.Collection{
+		ID:          uuid.New().String(),
+		WorkspaceID: upstream.WorkspaceID,
+		Name:        name,
+		Description: upstream.Description,
+		Variables:   upstream.Variables,
+		Auth:        upstream.Auth,
+
+		PreRequestScript: upstream.PreRequestScript,
+		TestScript:       upstream.TestScript,
+		ForkedFromID:     &upstream.ID,
+	}
+
+	baseIDs := make([]string, 0, len(upstream.Requests))
+	for _, r := range upstream.Requests {
+		origin := r.ID
+		baseIDs = append(baseIDs, origin)
+
+		r.ID = uuid.New().String()
+		r.CollectionID = fork.ID
+		r.ForkedFromRequestID = &origin
+		fork.Requests = append(fork.Requests, r)
+	}
+	base, err := json.Marshal(baseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record fork base: %w", err)
+	}
+	fork.ForkBaseRequestIDs = datatypes.JSON(base)
+
+	if err := s.Repo.ForkCollection(ctx, fork); err != nil {
+		return nil, err
+	}
+
+	log.Info().Str("collection_id", fork.ID).Str("author", auth.Subject(ctx, req.GetAuthor())).Msg("Collection forked")
+	return utils.ConvertModelCollectionToProto(fork), nil
+}
+
+// forkName picks the default name of a fork of upstream: "<upstream> (fork)",
+// or "<upstream> (fork n)" counting from 2 when that is taken.
+func forkName(upstream string, taken []string) string {
+	names := make(map[string]bool, len(taken))
+	for _, n := range taken {
+		names[n] = true
+	}
+	name := upstream + " (fork)"
+	for n := 2; names[name]; n++ {
+		name = fmt.Sprintf("%s (fork %d)", upstream, n)
+	}
+	return name
+}
+
+func (s *CollectionService) SetCollectionProtected(ctx context.Context, req *proto.SetCollectionProtectedRequest) (*proto.CollectionResponse, error) {
+	if _, err := s.scopedCollection(ctx, req.GetCollectionId(), false, rbac.PermManage); err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.SetProtected(ctx, req.GetCollectionId(), req.GetProtected()); err != nil {
+		log.Error().Err(err).Str("collection_id", req.GetCollectionId()).Msg("Failed to set collection protection")
+		return nil, err
+	}
+
+	col, err := s.Repo.GetByIDWithRequests(ctx, req.GetCollectionId())
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertModelCollectionToProto(col), nil
+}
+
+func (s *CollectionService) OpenChangeProposal(ctx context.Context, req *proto.OpenChangeProposalRequest) (*proto.ChangeProposalResponse, error) {
+	if req.GetTitle() == "" {
+		return nil, apperr.Invalid("title", "proposal title cannot be empty")
+	}
+	author := auth.Subject(ctx, req.GetAuthor())
+	if author == "" {
+		return nil, apperr.Invalid("author", "proposal author cannot be empty")
+	}
+
+	fork, err := s.scopedCollection(ctx, req.GetForkCollectionId(), true, rbac.PermWrite)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch fork: %w", err)
+	}
+	if fork.ForkedFromID == nil {
+		return nil, apperr.FailedPrecondition("NOT_A_FORK", "collection %s is not a fork", fork.ID)
+	}
+
+	changes, err := s.forkChanges(ctx, fork)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, apperr.FailedPrecondition("NO_CHANGES", "fork has no changes compared to its upstream collection")
+	}
+
+	raw, err := json.Marshal(changes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode proposal changes: %w", err)
+	}
+
+	proposal := &models.ChangeProposal{
+		ID:                   uuid.New().String(),
+		ForkCollectionID:     fork.ID,
+		UpstreamCollectionID: *fork.ForkedFromID,
+		Title:                req.GetTitle(),
+		Author:               author,
+		Status:               models.ProposalStatusOpen,
+		Changes:              datatypes.JSON(raw),
+	}
+	if req.GetDescription() != "" {
+		proposal.Description = &req.Description
+	}
+
+	if err := s.Repo.CreateProposal(ctx, proposal); err != nil {
+		return nil, fmt.Errorf("failed to open change proposal: %w", err)
+	}
+
+	return utils.ConvertProposalToProto(proposal)
+}
+
+func (s *CollectionService) GetChangeProposal(ctx context.Context, req *proto.GetChangeProposalRequest) (*proto.ChangeProposalResponse, error) {
+	proposal, err := s.scopedProposal(ctx, req.GetId(), rbac.PermRead)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertProposalToProto(proposal)
+}
+
+func (s *CollectionService) ListChangeProposals(ctx context.Context, req *proto.ListChangeProposalsRequest) (*proto.ListChangeProposalsResponse, error) {
+	var status models.ProposalStatus
+	if req.GetStatus() != proto.ProposalStatus_PROPOSAL_STATUS_UNSPECIFIED {
+		status = utils.ConvertProtoProposalStatus(req.GetStatus())
+	}
+
+	var workspaceID string
+	var err error
+	if req.GetUpstreamCollectionId() != "" {
+		var upstream *models.Collection
+		upstream, err = s.scopedCollection(ctx, req.GetUpstreamCollectionId(), false, rbac.PermRead)
+		if upstream != nil {
+			workspaceID = upstream.WorkspaceID
+		}
+	} else {
+		workspaceID, err = s.scopedWorkspace(ctx, rbac.PermRead)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	proposals, err := s.Repo.ListProposals(ctx, workspaceID, req.GetUpstreamCollectionId(), status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list change proposals: %w", err)
+	}
+
+	resp := &proto.ListChangeProposalsResponse{}
+	for _, p := range proposals {
+		pp, err := utils.ConvertProposalToProto(p)
+		if err != nil {
+			return nil, err
+		}
+		resp.Proposals = append(resp.Proposals, pp)
+	}
+	return resp, nil
+}
+
+func (s *CollectionService) CommentOnChangeProposal(ctx context.Context, req *proto.CommentOnChangeProposalRequest) (*proto.ChangeProposalResponse, error) {
+	author := auth.Subject(ctx, req.GetAuthor())
+	if author == "" {
+		return nil, apperr.Invalid("author", "comment author cannot be empty")
+	}
+	if req.GetBody() == "" {
+		return nil, apperr.Invalid("body", "comment body cannot be empty")
+	}
+
+	if _, err := s.scopedProposal(ctx, req.GetProposalId(), rbac.PermRead); err != nil {
+		return nil, err
+	}
+
+	if err := s.addProposalComment(ctx, req.GetProposalId(), author, req.GetBody()); err != nil {
+		return nil, err
+	}
+
+	return s.GetChangeProposal(ctx, &proto.GetChangeProposalRequest{Id: req.GetProposalId()})
+}
+
+func (s *CollectionService) ReviewChangeProposal(ctx context.Context, req *proto.ReviewChangeProposalRequest) (*proto.ChangeProposalResponse, error) {
+	reviewer := auth.Subject(ctx, req.GetReviewer())
+	if reviewer == "" {
+		return nil, apperr.Invalid("reviewer", "reviewer cannot be empty")
+	}
+
+	proposal, err := s.scopedProposal(ctx, req.GetProposalId(), rbac.PermManage)
+	if err != nil {
+		return nil, err
+	}
+	if proposal.Status != models.ProposalStatusOpen {
+		return nil, apperr.FailedPrecondition("PROPOSAL_NOT_OPEN", "change proposal %s is already %s", proposal.ID, proposal.Status)
+	}
+
+	switch req.GetDecision() {
+	case proto.ProposalDecision_PROPOSAL_DECISION_APPROVE:
+		if reviewer == proposal.Author {
+			return nil, apperr.PermissionDenied("SELF_APPROVAL", "a change proposal cannot be approved by its author")
+		}
+
+		var changes []models.ProposalChange
+		if err := json.Unmarshal(proposal.Changes, &changes); err != nil {
+			return nil, fmt.Errorf("failed to decode proposal changes: %w", err)
+		}
+		for i := range changes {
+			if changes[i].Type == models.ProposalChangeAdded {
+				changes[i].Request.ID = uuid.New().String()
+			}
+		}
+
+		if err := s.Repo.ApplyProposal(ctx, proposal.ID, reviewer, changes); err != nil {
+			return nil, fmt.Errorf("failed to apply change proposal: %w", err)
+		}
+	case proto.ProposalDecision_PROPOSAL_DECISION_REJECT:
+		if err := s.Repo.RejectProposal(ctx, proposal.ID, reviewer); err != nil {
+			return nil, fmt.Errorf("failed to reject change proposal: %w", err)
+		}
+	default:
+		return nil, apperr.Invalid("decision", "review decision must be APPROVE or REJECT")
+	}
+
+	if req.GetComment() != "" {
+		if err := s.addProposalComment(ctx, proposal.ID, reviewer, req.GetComment()); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetChangeProposal(ctx, &proto.GetChangeProposalRequest{Id: proposal.ID})
+}
+
+func (s *CollectionService) forkChanges(ctx context.Context, fork *models.Collection) ([]models.ProposalChange, error) {
+	upstream, err := s.Repo.GetByIDWithRequests(ctx, *fork.ForkedFromID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch upstream collection: %w", err)
+	}
+	return merge.ForkChanges(fork, upstream)
+}
+
+func (s *CollectionService) addProposalComment(ctx context.Context, proposalID, author, body string) error {
+	comment := &models.ProposalComment{
+		ID:         uuid.New().String(),
+		ProposalID: proposalID,
+		Author:     author,
+		Body:       body,
+	}
+	if err := s.Repo.AddProposalComment(ctx, comment); err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+	return nil
+}
+
+func (s *CollectionService) scopedProposal(ctx context.Context, id string, perm rbac.Permission) (*models.ChangeProposal, error) {
+	proposal, err := s.Repo.GetProposal(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperr.NotFound("change proposal", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.scopedCollection(ctx, proposal.UpstreamCollectionID, false, perm); err != nil {
+		if errors.Is(err, errCollectionNotFound) {
+			return nil, apperr.NotFound("change proposal", id)
+		}
+		return nil, err
+	}
+	return proposal, nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"collectionsservice/internal/auth"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/rbac"
+	"collectionsservice/internal/repository"
+	"collectionsservice/internal/workspace"
+	"context"
+	"testing"
+)
+
+func TestForkName(t *testing.T) {
+	tests := []struct {
+		name  string
+		taken []string
+		want  string
+	}{
+		{"first fork", []string{"api"}, "api (fork)"},
+		{"second fork", []string{"api", "api (fork)"}, "api (fork 2)"},
+		{"gap is reused", []string{"api (fork)", "api (fork 3)"}, "api (fork 2)"},
+		{"several taken", []string{"api (fork)", "api (fork 2)", "api (fork 3)"}, "api (fork 4)"},
+		{"other collection's forks", []string{"api", "web (fork)"}, "api (fork)"},
+	}
+	for _, tt := range tests {
+		if got := forkName("api", tt.taken); got != tt.want {
+			t.Errorf("%s: forkName = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// forkRepo keeps collection names per workspace and rejects a fork whose
+// name is taken, as the unique (workspace_id, name) index does.
+type forkRepo struct {
+	repository.CollectionRepoInterface
+	upstream *models.Collection
+	names    []string
+}
+
+func (r *forkRepo) GetByIDWithRequests(context.Context, string) (*models.Collection, error) {
+	return r.upstream, nil
+}
+
+func (r *forkRepo) CollectionNames(context.Context, string) ([]string, error) {
+	return r.names, nil
+}
+
+func (r *forkRepo) ForkCollection(_ context.Context, fork *models.Collection) error {
+	for _, n := range r.names {
+		if n == fork.Name {
+			return repository.ErrCollectionNameTaken
+		}
+	}
+	r.names = append(r.names, fork.Name)
+	return nil
+}
+
+func TestForkCollectionDefaultName(t *testing.T) {
+	upstream := &models.Collection{ID: "c1", WorkspaceID: models.DefaultWorkspaceID, Name: "api"}
+	repo := &forkRepo{upstream: upstream, names: []string{"api"}}
+	s := &CollectionService{Repo: repo, Authz: rbac.NewAuthorizer(nil, nil)}
+	ctx := workspace.NewContext(auth.NewContext(context.Background(), &auth.Identity{Subject: "anonymous", Method: auth.MethodAnonymous}), models.DefaultWorkspaceID)
+
+	for _, want := range []string{"api (fork)", "api (fork 2)", "api (fork 3)"} {
+		resp, err := s.ForkCollection(ctx, &proto.ForkCollectionRequest{CollectionId: "c1"})
+		if err != nil {
+			t.Fatalf("fork %q: %v", want, err)
+		}
+		if resp.GetName() != want {
+			t.Errorf("fork named %q, want %q", resp.GetName(), want)
+		}
+	}
+
+	if _, err := s.ForkCollection(ctx, &proto.ForkCollectionRequest{CollectionId: "c1", Name: "api (fork)"}); err != repository.ErrCollectionNameTaken {
+		t.Errorf("explicit taken name: error %v, want %v", err, repository.ErrCollectionNameTaken)
+	}
+}
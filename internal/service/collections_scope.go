@@ -0,0 +1,94 @@
+package service
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/models"
+	"collectionsservice/internal/rbac"
+	"collectionsservice/internal/workspace"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+var errCollectionNotFound = apperr.NotFound("collection", "")
+
+// scopedCollection loads a collection, hides it unless it belongs to the
+// caller's workspace and checks that the caller holds perm on it.
+func (s *CollectionService) scopedCollection(ctx context.Context, id string, withRequests bool, perm rbac.Permission) (*models.Collection, error) {
+	workspaceID, err := workspace.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var col *models.Collection
+	if withRequests {
+		col, err = s.Repo.GetByIDWithRequests(ctx, id)
+	} else {
+		col, err = s.Repo.GetByID(ctx, id)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) || err == nil && col.WorkspaceID != workspaceID {
+		return nil, apperr.NotFound("collection", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Authz.CheckCollection(ctx, col, perm); err != nil {
+		return nil, err
+	}
+	return col, nil
+}
+
+func (s *CollectionService) scopedCollectionByName(ctx context.Context, name string, perm rbac.Permission) (*models.Collection, error) {
+	workspaceID, err := workspace.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	col, err := s.Repo.GetCollectionByName(ctx, workspaceID, name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperr.NotFound("collection", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Authz.CheckCollection(ctx, col, perm); err != nil {
+		return nil, err
+	}
+	return col, nil
+}
+
+// scopedWorkspace returns the caller's workspace after checking that they
+// hold perm on it.
+func (s *CollectionService) scopedWorkspace(ctx context.Context, perm rbac.Permission) (string, error) {
+	workspaceID, err := workspace.FromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err := s.Authz.CheckWorkspace(ctx, workspaceID, perm); err != nil {
+		return "", err
+	}
+	return workspaceID, nil
+}
+
+func (s *CollectionService) ensureWritable(ctx context.Context, collectionID string) error {
+	col, err := s.scopedCollection(ctx, collectionID, false, rbac.PermWrite)
+	if err != nil {
+		return err
+	}
+	return checkProtected(col)
+}
+
+func (s *CollectionService) ensureWritableByName(ctx context.Context, name string) error {
+	col, err := s.scopedCollectionByName(ctx, name, rbac.PermWrite)
+	if err != nil {
+		return err
+	}
+	return checkProtected(col)
+}
+
+func checkProtected(col *models.Collection) error {
+	if col.Protected {
+		return apperr.FailedPrecondition("COLLECTION_PROTECTED", "collection %q is protected: changes must go through a change proposal", col.Name)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/secrets"
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/datatypes"
+)
+
+func (s *CollectionService) ReencryptSecrets(ctx context.Context, req *proto.ReencryptSecretsRequest) (*proto.ReencryptSecretsResponse, error) {
+	if !s.Authz.IsAdmin(ctx) {
+		return nil, apperr.PermissionDenied("ADMIN_REQUIRED", "only admins can re-encrypt secrets")
+	}
+	if !s.Secrets.Enabled() {
+		return nil, openSecretError(secrets.ErrNotConfigured)
+	}
+
+	requests, collections, err := s.Repo.RewriteSecrets(ctx, s.Secrets.Rotate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-encrypt secrets: %w", err)
+	}
+	webhooks, err := s.Repo.RewriteWebhookSecrets(ctx, s.Secrets.RotateValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-encrypt webhook secrets: %w", err)
+	}
+
+	return &proto.ReencryptSecretsResponse{
+		RequestsUpdated:    int32(requests),
+		CollectionsUpdated: int32(collections),
+		WebhooksUpdated:    int32(webhooks),
+	}, nil
+}
+
+// sealRequests encrypts the secret headers of new or updated requests.
+// previous holds the stored requests by id so redacted values can be kept.
+func (s *CollectionService) sealRequests(reqs []models.Request, previous map[string]*models.Request) error {
+	for i := range reqs {
+		if err := s.Secrets.SealRequest(&reqs[i], previous[reqs[i].ID]); err != nil {
+			return secretError("requests", fmt.Errorf("request %q: %w", reqs[i].Name, err))
+		}
+	}
+	return nil
+}
+
+func (s *CollectionService) sealVariables(vars, previous datatypes.JSON) (datatypes.JSON, error) {
+	sealed, err := s.Secrets.SealEntries(vars, previous)
+	if err != nil {
+		return nil, secretError("variables", fmt.Errorf("variables: %w", err))
+	}
+	return sealed, nil
+}
+
+func (s *CollectionService) sealAuth(auth, previous datatypes.JSON) (datatypes.JSON, error) {
+	sealed, err := s.Secrets.SealAuth(auth, previous)
+	if err != nil {
+		return nil, secretError("auth", err)
+	}
+	return sealed, nil
+}
+
+func (s *CollectionService) storedRequests(ctx context.Context, collectionID string) (map[string]*models.Request, error) {
+	col, err := s.Repo.GetByIDWithRequests(ctx, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch collection: %w", err)
+	}
+	byID := make(map[string]*models.Request, len(col.Requests))
+	for i := range col.Requests {
+		byID[col.Requests[i].ID] = &col.Requests[i]
+	}
+	return byID, nil
+}
+
+// secretError reports a secret in field that could not be sealed.
+func secretError(field string, err error) error {
+	if errors.Is(err, secrets.ErrNotConfigured) {
+		return apperr.FailedPrecondition("SECRETS_NOT_CONFIGURED", "%v", err)
+	}
+	return apperr.Invalid(field, "%v", err)
+}
+
+// openSecretError reports stored secrets that could not be opened, such as
+// ones sealed with a key that is no longer configured.
+func openSecretError(err error) error {
+	if errors.Is(err, secrets.ErrNotConfigured) {
+		return apperr.FailedPrecondition("SECRETS_NOT_CONFIGURED", "%v", err)
+	}
+	return apperr.FailedPrecondition("SECRET_UNREADABLE", "%v", err)
+}
@@ -1,21 +1,30 @@
 package service
 
 import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/executor"
 	"collectionsservice/internal/models"
 	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/rbac"
 	"collectionsservice/internal/repository"
+	"collectionsservice/internal/secrets"
 	"collectionsservice/internal/utils"
+	"collectionsservice/internal/workspace"
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
-	"gorm.io/datatypes"
 )
 
 type CollectionService struct {
-	Repo repository.CollectionRepoInterface
+	Repo     repository.CollectionRepoInterface
+	Members  repository.MemberRepoInterface
+	Authz    *rbac.Authorizer
+	Secrets  *secrets.Keyring
+	Executor *executor.Executor
+	// RunBodyLimit caps the bodies kept in run history, in bytes.
+	RunBodyLimit int
 	proto.CollectionServiceServer
 }
 
@@ -29,21 +38,58 @@ type CollectionServiceInterface interface {
 	DeleteRequestFromCollection(ctx context.Context, collectionID, requestID string) (*proto.DeleteResponse, error)
 }
 
-func NewCollectionService(repo repository.CollectionRepoInterface) *CollectionService {
+func NewCollectionService(repo repository.CollectionRepoInterface, members repository.MemberRepoInterface, authz *rbac.Authorizer, keyring *secrets.Keyring, exec *executor.Executor, runBodyLimit int) *CollectionService {
 	return &CollectionService{
-		Repo: repo,
+		Repo:         repo,
+		Members:      members,
+		Authz:        authz,
+		Secrets:      keyring,
+		Executor:     exec,
+		RunBodyLimit: runBodyLimit,
 	}
 }
 
 func (s *CollectionService) CreateCollection(ctx context.Context, req *proto.CreateCollectionRequest) (*proto.CreateCollectionResponse, error) {
 	if req.GetName() == "" {
-		return nil, errors.New("collection name cannot be empty")
+		return nil, apperr.Invalid("name", "collection name cannot be empty")
+	}
+
+	workspaceID, err := s.scopedWorkspace(ctx, rbac.PermWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	vars, err := utils.ConvertProtoVariables(req.GetVariables())
+	if err != nil {
+		return nil, err
+	}
+	if vars, err = s.sealVariables(vars, nil); err != nil {
+		return nil, err
+	}
+	auth, err := utils.ConvertProtoAuth(req.GetAuth())
+	if err != nil {
+		return nil, err
+	}
+	if auth, err = s.sealAuth(auth, nil); err != nil {
+		return nil, err
+	}
+	if err := utils.CheckScripts(req.GetPreRequestScript(), req.GetTestScript()); err != nil {
+		return nil, err
 	}
 
 	collection := models.Collection{
 		ID:          uuid.New().String(),
+		WorkspaceID: workspaceID,
 		Name:        req.GetName(),
 		Description: &req.Description,
+		Variables:   vars,
+		Auth:        auth,
+	}
+	if req.GetPreRequestScript() != "" {
+		collection.PreRequestScript = &req.PreRequestScript
+	}
+	if req.GetTestScript() != "" {
+		collection.TestScript = &req.TestScript
 	}
 
 	id, err := s.Repo.CreateCollection(ctx, collection)
@@ -64,19 +110,31 @@ func (s *CollectionService) AddRequestToCollection(ctx context.Context, req *pro
 		return nil, fmt.Errorf("repository is not initialized")
 	}
 
+	workspaceID, err := workspace.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureWritableByName(ctx, req.CollectionName); err != nil {
+		return nil, err
+	}
+
 	reqModel, err := utils.ConvertProtoRequests([]*proto.CollectionRequestInput{req.Request}, req.CollectionName)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to convert request input")
 		return nil, fmt.Errorf("failed to convert request input: %w", err)
 	}
+	if err := s.sealRequests(reqModel, nil); err != nil {
+		return nil, err
+	}
 
-	err = s.Repo.AddRequestToCollection(ctx, req.CollectionName, reqModel)
+	err = s.Repo.AddRequestToCollection(ctx, workspaceID, req.CollectionName, reqModel)
 	if err != nil {
 		log.Error().Err(err).Str("collection_name", req.CollectionName).Msg("Failed to add request to collection")
 		return nil, fmt.Errorf("failed to add request to collection: %w", err)
 	}
 
-	updatedCollection, err := s.Repo.GetCollectionByName(ctx, req.CollectionName)
+	updatedCollection, err := s.Repo.GetCollectionByName(ctx, workspaceID, req.CollectionName)
 	if err != nil {
 		log.Error().Err(err).Str("collection_name", req.CollectionName).Msg("Failed to fetch updated collection")
 		return nil, fmt.Errorf("failed to fetch updated collection: %w", err)
@@ -87,17 +145,72 @@ func (s *CollectionService) AddRequestToCollection(ctx context.Context, req *pro
 	return protoCollection, nil
 }
 
+func (s *CollectionService) AddRequestToCollectionByID(ctx context.Context, req *proto.AddRequestToCollectionByIDRequest) (*proto.CollectionResponse, error) {
+	if s.Repo == nil {
+		return nil, fmt.Errorf("repository is not initialized")
+	}
+
+	if err := s.ensureWritable(ctx, req.CollectionId); err != nil {
+		return nil, err
+	}
+
+	reqModel, err := utils.ConvertProtoRequests([]*proto.CollectionRequestInput{req.Request}, req.CollectionId)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to convert request input")
+		return nil, fmt.Errorf("failed to convert request input: %w", err)
+	}
+	if err := s.sealRequests(reqModel, nil); err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.AddRequestsToCollectionByID(ctx, req.CollectionId, reqModel); err != nil {
+		log.Error().Err(err).Str("collection_id", req.CollectionId).Msg("Failed to add request to collection")
+		return nil, fmt.Errorf("failed to add request to collection: %w", err)
+	}
+
+	updatedCollection, err := s.Repo.GetByIDWithRequests(ctx, req.CollectionId)
+	if err != nil {
+		log.Error().Err(err).Str("collection_id", req.CollectionId).Msg("Failed to fetch updated collection")
+		return nil, fmt.Errorf("failed to fetch updated collection: %w", err)
+	}
+
+	return utils.ConvertModelCollectionToProto(updatedCollection), nil
+}
+
 func (s *CollectionService) ListCollectionsAndRequests(ctx context.Context, req *proto.ListCollectionsRequest) (*proto.ListCollectionsResponse, error) {
 	if s.Repo == nil {
 		return nil, fmt.Errorf("repository is not initialized")
 	}
 
-	collections, err := s.Repo.ListCollectionsAndRequests(ctx)
+	workspaceID, err := workspace.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	collections, err := s.Repo.ListCollectionsAndRequests(ctx, workspaceID)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to list collections and requests")
 		return nil, fmt.Errorf("failed to list collections and requests: %w", err)
 	}
 
+	collections, err = s.Authz.FilterCollections(ctx, workspaceID, collections, rbac.PermRead)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetRevealSecrets() {
+		// Collections the caller may not reveal stay redacted.
+		revealable, err := s.Authz.FilterCollections(ctx, workspaceID, collections, rbac.PermReveal)
+		if err != nil {
+			return nil, err
+		}
+		for _, col := range revealable {
+			if err := s.Secrets.OpenCollection(col); err != nil {
+				return nil, apperr.FailedPrecondition("SECRET_UNREADABLE", "failed to reveal secrets of collection %s: %v", col.ID, err)
+			}
+		}
+	}
+
 	// no logs here; normal processing
 
 	var protoCollections []*proto.CollectionResponse
@@ -111,18 +224,28 @@ func (s *CollectionService) ListCollectionsAndRequests(ctx context.Context, req
 		if col.Description != nil {
 			pCol.Description = *col.Description
 		}
+		pCol.Variables = utils.ConvertVariablesToProto(col.Variables)
+		pCol.Auth = utils.ConvertAuthToProto(col.Auth)
+		if col.PreRequestScript != nil {
+			pCol.PreRequestScript = *col.PreRequestScript
+		}
+		if col.TestScript != nil {
+			pCol.TestScript = *col.TestScript
+		}
 
 		for _, r := range col.Requests {
 			var pReq *proto.CollectionRequest
+			preRequestScript, testScript := "", ""
+			if r.PreRequestScript != nil {
+				preRequestScript = *r.PreRequestScript
+			}
+			if r.TestScript != nil {
+				testScript = *r.TestScript
+			}
 
 			switch r.Kind {
 			case models.RequestKindHTTP:
-				method := proto.HTTPMethod_GET
-				if r.HTTPMethod != nil {
-					if val, ok := proto.HTTPMethod_value[*r.HTTPMethod]; ok {
-						method = proto.HTTPMethod(val)
-					}
-				}
+				method := utils.ConvertHTTPMethodToProto(r.HTTPMethod)
 				url := ""
 				if r.HTTPURL != nil {
 					url = *r.HTTPURL
@@ -130,9 +253,16 @@ func (s *CollectionService) ListCollectionsAndRequests(ctx context.Context, req
 				pReq = &proto.CollectionRequest{
 					Request: &proto.CollectionRequest_HttpRequest{
 						HttpRequest: &proto.HTTPRequest{
-							Name:   r.Name,
-							Method: method,
-							Url:    url,
+							Name:    r.Name,
+							Method:  method,
+							Url:     url,
+							Headers: utils.ConvertHeadersToProto(r.HTTPHeaders),
+							Auth:    utils.ConvertAuthToProto(r.Auth),
+
+							PreRequestScript: preRequestScript,
+							TestScript:       testScript,
+							Assertions:       utils.ConvertAssertionsToProto(r.Assertions),
+							Captures:         utils.ConvertCapturesToProto(r.Captures),
 						},
 					},
 				}
@@ -152,6 +282,13 @@ func (s *CollectionService) ListCollectionsAndRequests(ctx context.Context, req
 							Name:     r.Name,
 							Endpoint: endpoint,
 							Query:    query,
+							Headers:  utils.ConvertHeadersToProto(r.GraphQLHeaders),
+							Auth:     utils.ConvertAuthToProto(r.Auth),
+
+							PreRequestScript: preRequestScript,
+							TestScript:       testScript,
+							Assertions:       utils.ConvertAssertionsToProto(r.Assertions),
+							Captures:         utils.ConvertCapturesToProto(r.Captures),
 						},
 					},
 				}
@@ -173,11 +310,14 @@ func (s *CollectionService) ListCollectionsAndRequests(ctx context.Context, req
 }
 
 func (s *CollectionService) UpdateCollection(ctx context.Context, req *proto.UpdateCollectionRequest) (*proto.CollectionResponse, error) {
-	existing, err := s.Repo.GetByID(ctx, req.Id)
+	existing, err := s.scopedCollection(ctx, req.Id, false, rbac.PermWrite)
 	if err != nil {
 		log.Error().Err(err).Str("collection_id", req.Id).Msg("Failed to get collection by ID")
 		return nil, err
 	}
+	if err := checkProtected(existing); err != nil {
+		return nil, err
+	}
 
 	if req.Name != "" {
 		existing.Name = req.Name
@@ -185,54 +325,88 @@ func (s *CollectionService) UpdateCollection(ctx context.Context, req *proto.Upd
 	if req.Description != "" {
 		existing.Description = &req.Description
 	}
+	if len(req.Variables) > 0 {
+		vars, err := utils.ConvertProtoVariables(req.Variables)
+		if err != nil {
+			return nil, err
+		}
+		if existing.Variables, err = s.sealVariables(vars, existing.Variables); err != nil {
+			return nil, err
+		}
+	}
+	if req.Auth != nil {
+		auth, err := utils.ConvertProtoAuth(req.Auth)
+		if err != nil {
+			return nil, err
+		}
+		if existing.Auth, err = s.sealAuth(auth, existing.Auth); err != nil {
+			return nil, err
+		}
+	}
+	if err := utils.CheckScripts(req.PreRequestScript, req.TestScript); err != nil {
+		return nil, err
+	}
+	if req.PreRequestScript != "" {
+		existing.PreRequestScript = &req.PreRequestScript
+	}
+	if req.TestScript != "" {
+		existing.TestScript = &req.TestScript
+	}
 
 	updated, err := s.Repo.Update(ctx, existing)
 	if err != nil {
 		log.Error().Err(err).Str("collection_id", req.Id).Msg("Failed to update collection")
 		return nil, err
 	}
-	return &proto.CollectionResponse{
-		Id:          updated.ID,
-		Name:        updated.Name,
-		Description: *updated.Description,
-	}, nil
-}
-
-func (s *CollectionService) UpdateRequestInCollection(ctx context.Context, req *proto.UpdateRequestInCollectionRequest) (*proto.UpdateRequestInCollectionResponse, error) {
-	input := &models.Request{}
-
-	if req.Name != "" {
-		input.Name = req.Name
-	}
-	if req.Kind != proto.RequestKind(0) {
-		input.Kind = models.RequestKind(req.Kind.String())
+	resp := &proto.CollectionResponse{
+		Id:        updated.ID,
+		Name:      updated.Name,
+		Variables: utils.ConvertVariablesToProto(updated.Variables),
+		Auth:      utils.ConvertAuthToProto(updated.Auth),
 	}
-	if req.HttpMethod != "" {
-		input.HTTPMethod = &req.HttpMethod
+	if updated.Description != nil {
+		resp.Description = *updated.Description
 	}
-	if req.HttpUrl != "" {
-		input.HTTPURL = &req.HttpUrl
+	if updated.PreRequestScript != nil {
+		resp.PreRequestScript = *updated.PreRequestScript
 	}
-	if req.HttpHeaders != "" {
-		input.HTTPHeaders = datatypes.JSON([]byte(req.HttpHeaders))
+	if updated.TestScript != nil {
+		resp.TestScript = *updated.TestScript
 	}
-	if req.HttpQueryParams != "" {
-		input.HTTPQueryParams = datatypes.JSON([]byte(req.HttpQueryParams))
+	return resp, nil
+}
+
+func (s *CollectionService) UpdateRequestInCollection(ctx context.Context, req *proto.UpdateRequestInCollectionRequest) (*proto.UpdateRequestInCollectionResponse, error) {
+	if err := s.ensureWritable(ctx, req.CollectionId); err != nil {
+		return nil, err
 	}
-	if req.HttpBody != "" {
-		input.HTTPBody = &req.HttpBody
+
+	if err := utils.CheckScripts(req.PreRequestScript, req.TestScript); err != nil {
+		return nil, err
 	}
-	if req.GraphqlEndpoint != "" {
-		input.GraphQLEndpoint = &req.GraphqlEndpoint
+	input := utils.ConvertProtoUpdateRequest(req)
+	input.ID = req.RequestId
+	auth, err := utils.ConvertProtoAuth(req.Auth)
+	if err != nil {
+		return nil, err
 	}
-	if req.GraphqlQuery != "" {
-		input.GraphQLQuery = &req.GraphqlQuery
+	input.Auth = auth
+	if input.Assertions, err = utils.ConvertProtoAssertions(req.Assertions); err != nil {
+		return nil, err
 	}
-	if req.GraphqlVariables != "" {
-		input.GraphQLVariables = datatypes.JSON([]byte(req.GraphqlVariables))
+	if input.Captures, err = utils.ConvertProtoCaptures(req.Captures); err != nil {
+		return nil, err
 	}
-	if req.GraphqlHeaders != "" {
-		input.GraphQLHeaders = datatypes.JSON([]byte(req.GraphqlHeaders))
+	if len(input.HTTPHeaders) > 0 || len(input.GraphQLHeaders) > 0 || len(input.Auth) > 0 {
+		stored, err := s.storedRequests(ctx, req.CollectionId)
+		if err != nil {
+			return nil, err
+		}
+		sealed := []models.Request{*input}
+		if err := s.sealRequests(sealed, stored); err != nil {
+			return nil, err
+		}
+		input = &sealed[0]
 	}
 
 	collectionAndRequests, err := s.Repo.UpdateRequestInCollection(ctx, req.CollectionId, req.RequestId, input)
@@ -248,13 +422,13 @@ func (s *CollectionService) UpdateRequestInCollection(ctx context.Context, req *
 }
 
 func (s *CollectionService) DeleteCollection(ctx context.Context, req *proto.DeleteCollectionRequest) (*proto.DeleteResponse, error) {
-	err := s.Repo.DeleteCollection(req.Id)
+	err := s.ensureWritable(ctx, req.Id)
+	if err == nil {
+		err = s.Repo.DeleteCollection(ctx, req.Id)
+	}
 	if err != nil {
 		log.Error().Err(err).Str("collection_id", req.Id).Msg("Failed to delete collection")
-		return &proto.DeleteResponse{
-			Success: false,
-			Message: fmt.Sprintf("Failed to delete collection: %v", err),
-		}, nil
+		return nil, err
 	}
 
 	return &proto.DeleteResponse{
@@ -264,13 +438,13 @@ func (s *CollectionService) DeleteCollection(ctx context.Context, req *proto.Del
 }
 
 func (s *CollectionService) DeleteRequestFromCollection(ctx context.Context, req *proto.DeleteRequestFromCollectionRequest) (*proto.DeleteResponse, error) {
-	err := s.Repo.RemoveRequestFromCollection(req.CollectionId, req.RequestId)
+	err := s.ensureWritable(ctx, req.CollectionId)
+	if err == nil {
+		err = s.Repo.RemoveRequestFromCollection(ctx, req.CollectionId, req.RequestId)
+	}
 	if err != nil {
 		log.Error().Err(err).Str("request_id", req.RequestId).Str("collection_id", req.CollectionId).Msg("Failed to remove request from collection")
-		return &proto.DeleteResponse{
-			Success: false,
-			Message: fmt.Sprintf("Failed to remove request: %v", err),
-		}, nil
+		return nil, err
 	}
 
 	return &proto.DeleteResponse{
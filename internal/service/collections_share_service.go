@@ -0,0 +1,135 @@
+package service
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/auth"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/rbac"
+	"collectionsservice/internal/utils"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultShareTTL = 7 * 24 * time.Hour
+	maxShareTTL     = 90 * 24 * time.Hour
+)
+
+var errInvalidShareToken = &apperr.Error{
+	Kind:         apperr.KindNotFound,
+	Reason:       "SHARE_TOKEN_INVALID",
+	Message:      "share token is invalid, expired or revoked",
+	ResourceType: "share token",
+}
+
+func (s *CollectionService) ShareCollection(ctx context.Context, req *proto.ShareCollectionRequest) (*proto.ShareCollectionResponse, error) {
+	if req.GetExpiresInSeconds() < 0 {
+		return nil, apperr.Invalid("expires_in_seconds", "expires_in_seconds cannot be negative")
+	}
+	ttl := defaultShareTTL
+	if req.GetExpiresInSeconds() > 0 {
+		ttl = time.Duration(req.GetExpiresInSeconds()) * time.Second
+	}
+	if ttl > maxShareTTL {
+		return nil, apperr.Invalid("expires_in_seconds", "shares can last at most %d days", int(maxShareTTL.Hours()/24))
+	}
+
+	if _, err := s.scopedCollection(ctx, req.GetCollectionId(), false, rbac.PermManage); err != nil {
+		return nil, err
+	}
+
+	plaintext, prefix, hash, err := auth.GenerateShareToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate share token: %w", err)
+	}
+
+	share := &models.CollectionShare{
+		ID:           uuid.New().String(),
+		CollectionID: req.GetCollectionId(),
+		Access:       utils.ConvertProtoShareAccess(req.GetAccess()),
+		Prefix:       prefix,
+		Hash:         hash,
+		CreatedBy:    auth.Subject(ctx, ""),
+		ExpiresAt:    time.Now().Add(ttl),
+	}
+	if err := s.Repo.CreateShare(ctx, share); err != nil {
+		return nil, fmt.Errorf("failed to share collection: %w", err)
+	}
+
+	return &proto.ShareCollectionResponse{
+		Share: utils.ConvertShareToProto(share),
+		Token: plaintext,
+	}, nil
+}
+
+func (s *CollectionService) ListCollectionShares(ctx context.Context, req *proto.ListCollectionSharesRequest) (*proto.ListCollectionSharesResponse, error) {
+	if _, err := s.scopedCollection(ctx, req.GetCollectionId(), false, rbac.PermManage); err != nil {
+		return nil, err
+	}
+
+	shares, err := s.Repo.ListShares(ctx, req.GetCollectionId())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collection shares: %w", err)
+	}
+
+	resp := &proto.ListCollectionSharesResponse{}
+	for _, sh := range shares {
+		resp.Shares = append(resp.Shares, utils.ConvertShareToProto(sh))
+	}
+	return resp, nil
+}
+
+func (s *CollectionService) RevokeCollectionShare(ctx context.Context, req *proto.RevokeCollectionShareRequest) (*proto.ShareInfo, error) {
+	share, err := s.Repo.GetShare(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.scopedCollection(ctx, share.CollectionID, false, rbac.PermManage); err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.RevokeShare(ctx, share.ID); err != nil {
+		return nil, fmt.Errorf("failed to revoke collection share: %w", err)
+	}
+
+	share, err = s.Repo.GetShare(ctx, share.ID)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertShareToProto(share), nil
+}
+
+func (s *CollectionService) GetSharedCollection(ctx context.Context, req *proto.GetSharedCollectionRequest) (*proto.SharedCollectionResponse, error) {
+	share, err := s.resolveShare(ctx, req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.SharedCollectionResponse{
+		Collection: utils.ConvertModelCollectionToProto(&share.Collection),
+		Access:     utils.ConvertShareAccessToProto(share.Access),
+		ExpiresAt:  timestamppb.New(share.ExpiresAt),
+	}, nil
+}
+
+// resolveShare looks up a live share by its plaintext token, loading the
+// shared collection with its requests.
+func (s *CollectionService) resolveShare(ctx context.Context, token string) (*models.CollectionShare, error) {
+	if !auth.IsShareToken(token) {
+		return nil, errInvalidShareToken
+	}
+
+	share, err := s.Repo.FindShareByHash(ctx, auth.HashToken(token))
+	if err != nil {
+		return nil, errInvalidShareToken
+	}
+	if share.RevokedAt != nil || time.Now().After(share.ExpiresAt) {
+		return nil, errInvalidShareToken
+	}
+	return share, nil
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/rbac"
+	"collectionsservice/internal/utils"
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const (
+	// watchPollInterval is how often a watch checks for new changes.
+	watchPollInterval = time.Second
+	watchBatchSize    = 100
+	// watchAccessTTL is how long a watch trusts a read check on a
+	// collection, so revoked access stops the feed soon after.
+	watchAccessTTL = time.Minute
+)
+
+// WatchCollections streams changes from the outbox after they are published,
+// so the feed follows the relay and is the same on every replica.
+func (s *CollectionService) WatchCollections(req *proto.WatchCollectionsRequest, stream grpc.ServerStreamingServer[proto.CollectionChange]) error {
+	ctx := stream.Context()
+
+	filter := models.ChangeFilter{CollectionID: req.GetCollectionId(), Limit: watchBatchSize}
+	switch {
+	case req.GetAllWorkspaces():
+		if req.GetCollectionId() != "" {
+			return apperr.Invalid("all_workspaces", "collection_id and all_workspaces cannot both be set")
+		}
+		if !s.Authz.IsAdmin(ctx) {
+			return apperr.PermissionDenied("ADMIN_REQUIRED", "only admins can watch every workspace")
+		}
+	case req.GetCollectionId() != "":
+		if _, err := s.scopedCollection(ctx, req.GetCollectionId(), false, rbac.PermRead); err != nil {
+			return err
+		}
+	default:
+		workspaceID, err := s.scopedWorkspace(ctx, rbac.PermRead)
+		if err != nil {
+			return err
+		}
+		filter.WorkspaceID = workspaceID
+	}
+
+	after, err := s.watchStart(ctx, req.GetCursor())
+	if err != nil {
+		return err
+	}
+	filter.After = after
+
+	access := &watchAccess{s: s, all: req.GetAllWorkspaces(), checked: make(map[string]accessCheck)}
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+	for {
+		events, err := s.Repo.ListChanges(ctx, filter)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return status.Error(codes.Unavailable, "failed to read changes")
+		}
+		for _, e := range events {
+			allowed, err := access.allowed(ctx, e)
+			if err != nil {
+				return err
+			}
+			if allowed {
+				if err := stream.Send(utils.ConvertChangeToProto(e)); err != nil {
+					return err
+				}
+			}
+			filter.After = *e.Position
+		}
+		if len(events) == watchBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchStart returns the position to stream after: the cursor, or the
+// latest change when there is none.
+func (s *CollectionService) watchStart(ctx context.Context, cursor string) (int64, error) {
+	oldest, latest, err := s.Repo.ChangePositions(ctx)
+	if err != nil {
+		return 0, status.Error(codes.Unavailable, "failed to read changes")
+	}
+	if cursor == "" {
+		return latest, nil
+	}
+	after, err := strconv.ParseInt(cursor, 10, 64)
+	if err != nil || after < 0 {
+		return 0, apperr.Invalid("cursor", "invalid cursor")
+	}
+	if oldest > 0 && after < oldest-1 {
+		return 0, status.Error(codes.OutOfRange, "cursor has expired; reload and watch without a cursor")
+	}
+	return after, nil
+}
+
+type accessCheck struct {
+	allowed bool
+	at      time.Time
+}
+
+// watchAccess remembers which collections the watcher may read.
+type watchAccess struct {
+	s       *CollectionService
+	all     bool
+	checked map[string]accessCheck
+}
+
+func (a *watchAccess) allowed(ctx context.Context, e *models.OutboxEvent) (bool, error) {
+	if a.all {
+		return true, nil
+	}
+	if c, ok := a.checked[e.CollectionID]; ok && time.Since(c.at) < watchAccessTTL {
+		return c.allowed, nil
+	}
+
+	col, err := a.s.Repo.GetByID(ctx, e.CollectionID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		// Deleted collections fall back to the workspace role.
+		err = a.s.Authz.CheckWorkspace(ctx, e.WorkspaceID, rbac.PermRead)
+	case err != nil:
+		return false, status.Error(codes.Unavailable, "failed to check collection access")
+	default:
+		err = a.s.Authz.CheckCollection(ctx, col, rbac.PermRead)
+	}
+	if err != nil && status.Code(err) != codes.PermissionDenied {
+		return false, err
+	}
+
+	a.checked[e.CollectionID] = accessCheck{allowed: err == nil, at: time.Now()}
+	return err == nil, nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"collectionsservice/internal/repository"
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// positionsRepo serves only the change positions a watch starts from.
+type positionsRepo struct {
+	repository.CollectionRepoInterface
+	oldest, latest int64
+	err            error
+}
+
+func (r *positionsRepo) ChangePositions(context.Context) (int64, int64, error) {
+	return r.oldest, r.latest, r.err
+}
+
+func TestWatchStart(t *testing.T) {
+	tests := []struct {
+		name           string
+		oldest, latest int64
+		err            error
+		cursor         string
+		want           int64
+		wantCode       codes.Code
+	}{
+		{name: "no cursor starts at the latest change", oldest: 10, latest: 20, want: 20},
+		{name: "no cursor and no changes", want: 0},
+		{name: "cursor within retention", oldest: 10, latest: 20, cursor: "15", want: 15},
+		{name: "cursor at the latest change", oldest: 10, latest: 20, cursor: "20", want: 20},
+		{name: "cursor just before the oldest change", oldest: 10, latest: 20, cursor: "9", want: 9},
+		{name: "cursor before retention", oldest: 10, latest: 20, cursor: "8", wantCode: codes.OutOfRange},
+		{name: "cursor zero after purge", oldest: 10, latest: 20, cursor: "0", wantCode: codes.OutOfRange},
+		{name: "cursor with nothing kept", cursor: "5", want: 5},
+		{name: "malformed cursor", oldest: 1, latest: 2, cursor: "abc", wantCode: codes.InvalidArgument},
+		{name: "negative cursor", oldest: 1, latest: 2, cursor: "-1", wantCode: codes.InvalidArgument},
+		{name: "store unavailable", err: errors.New("connection refused"), cursor: "5", wantCode: codes.Unavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &CollectionService{Repo: &positionsRepo{oldest: tt.oldest, latest: tt.latest, err: tt.err}}
+			got, err := s.watchStart(context.Background(), tt.cursor)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %s (%v), want %s", code, err, tt.wantCode)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("start = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
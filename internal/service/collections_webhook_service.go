@@ -0,0 +1,225 @@
+package service
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/auth"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/rbac"
+	"collectionsservice/internal/utils"
+	"collectionsservice/internal/webhook"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+var errWebhookNotFound = apperr.NotFound("webhook", "")
+
+const (
+	defaultDeliveryLimit = 100
+	maxDeliveryLimit     = 1000
+)
+
+func (s *CollectionService) CreateWebhook(ctx context.Context, req *proto.CreateWebhookRequest) (*proto.WebhookResponse, error) {
+	if err := checkWebhookURL(req.GetUrl()); err != nil {
+		return nil, err
+	}
+	events, err := webhookEvents(req.GetEvents())
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceID, err := s.scopedWorkspace(ctx, rbac.PermManage)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetCollectionId() != "" {
+		if _, err := s.scopedCollection(ctx, req.GetCollectionId(), false, rbac.PermRead); err != nil {
+			return nil, err
+		}
+	}
+
+	secret := req.GetSecret()
+	if secret == "" {
+		if secret, err = webhookSecret(); err != nil {
+			return nil, err
+		}
+	}
+	sealed, err := s.Secrets.Seal(secret)
+	if err != nil {
+		return nil, secretError("secret", err)
+	}
+
+	w := &models.Webhook{
+		ID:          uuid.New().String(),
+		WorkspaceID: workspaceID,
+		URL:         req.GetUrl(),
+		Secret:      sealed,
+		Events:      events,
+		Enabled:     !req.GetDisabled(),
+		CreatedBy:   auth.Subject(ctx, ""),
+	}
+	if req.GetCollectionId() != "" {
+		w.CollectionID = &req.CollectionId
+	}
+
+	if err := s.Repo.CreateWebhook(ctx, w); err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	resp := utils.ConvertWebhookToProto(w)
+	resp.Secret = secret
+	return resp, nil
+}
+
+func (s *CollectionService) ListWebhooks(ctx context.Context, req *proto.ListWebhooksRequest) (*proto.ListWebhooksResponse, error) {
+	workspaceID, err := s.scopedWorkspace(ctx, rbac.PermManage)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.Repo.ListWebhooks(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	resp := &proto.ListWebhooksResponse{}
+	for _, w := range webhooks {
+		resp.Webhooks = append(resp.Webhooks, utils.ConvertWebhookToProto(w))
+	}
+	return resp, nil
+}
+
+func (s *CollectionService) UpdateWebhook(ctx context.Context, req *proto.UpdateWebhookRequest) (*proto.WebhookResponse, error) {
+	w, err := s.scopedWebhook(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetUrl() != "" {
+		if err := checkWebhookURL(req.GetUrl()); err != nil {
+			return nil, err
+		}
+		w.URL = req.GetUrl()
+	}
+	if len(req.GetEvents()) > 0 {
+		if w.Events, err = webhookEvents(req.GetEvents()); err != nil {
+			return nil, err
+		}
+	}
+	if req.GetSecret() != "" {
+		if w.Secret, err = s.Secrets.Seal(req.GetSecret()); err != nil {
+			return nil, secretError("secret", err)
+		}
+	}
+
+	if err := s.Repo.UpdateWebhook(ctx, w); err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return utils.ConvertWebhookToProto(w), nil
+}
+
+func (s *CollectionService) SetWebhookEnabled(ctx context.Context, req *proto.SetWebhookEnabledRequest) (*proto.WebhookResponse, error) {
+	w, err := s.scopedWebhook(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	w.Enabled = req.GetEnabled()
+	if err := s.Repo.UpdateWebhook(ctx, w); err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return utils.ConvertWebhookToProto(w), nil
+}
+
+func (s *CollectionService) DeleteWebhook(ctx context.Context, req *proto.DeleteWebhookRequest) (*proto.DeleteResponse, error) {
+	if _, err := s.scopedWebhook(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	if err := s.Repo.DeleteWebhook(ctx, req.GetId()); err != nil {
+		return nil, fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return &proto.DeleteResponse{
+		Success: true,
+		Message: "Webhook deleted successfully",
+	}, nil
+}
+
+func (s *CollectionService) ListWebhookDeliveries(ctx context.Context, req *proto.ListWebhookDeliveriesRequest) (*proto.ListWebhookDeliveriesResponse, error) {
+	if _, err := s.scopedWebhook(ctx, req.GetWebhookId()); err != nil {
+		return nil, err
+	}
+
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultDeliveryLimit
+	}
+	if limit > maxDeliveryLimit {
+		limit = maxDeliveryLimit
+	}
+
+	deliveries, err := s.Repo.ListDeliveries(ctx, req.GetWebhookId(), utils.ConvertProtoDeliveryStatus(req.GetStatus()), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	resp := &proto.ListWebhookDeliveriesResponse{}
+	for _, d := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, utils.ConvertDeliveryToProto(d))
+	}
+	return resp, nil
+}
+
+// scopedWebhook loads a webhook of the caller's workspace after checking
+// that they may manage it.
+func (s *CollectionService) scopedWebhook(ctx context.Context, id string) (*models.Webhook, error) {
+	workspaceID, err := s.scopedWorkspace(ctx, rbac.PermManage)
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.Repo.GetWebhook(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if w.WorkspaceID != workspaceID {
+		return nil, errWebhookNotFound
+	}
+	return w, nil
+}
+
+func checkWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return apperr.Invalid("url", "webhook url must be an http or https URL")
+	}
+	return nil
+}
+
+func webhookEvents(names []string) (datatypes.JSON, error) {
+	events := make([]webhook.Event, 0, len(names))
+	for _, name := range names {
+		if !webhook.Known(webhook.Event(name)) {
+			return nil, apperr.Invalid("events", "unknown webhook event %q", name)
+		}
+		events = append(events, webhook.Event(name))
+	}
+	return json.Marshal(events)
+}
+
+func webhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + base64.RawURLEncoding.EncodeToString(buf), nil
+}
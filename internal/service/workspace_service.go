@@ -0,0 +1,233 @@
+package service
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/auth"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/rbac"
+	"collectionsservice/internal/repository"
+	"collectionsservice/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+type WorkspaceService struct {
+	Repo    repository.WorkspaceRepoInterface
+	Members repository.MemberRepoInterface
+	Authz   *rbac.Authorizer
+	proto.WorkspaceServiceServer
+}
+
+func NewWorkspaceService(repo repository.WorkspaceRepoInterface, members repository.MemberRepoInterface, authz *rbac.Authorizer) *WorkspaceService {
+	return &WorkspaceService{
+		Repo:    repo,
+		Members: members,
+		Authz:   authz,
+	}
+}
+
+func (s *WorkspaceService) CreateWorkspace(ctx context.Context, req *proto.CreateWorkspaceRequest) (*proto.WorkspaceResponse, error) {
+	if req.GetName() == "" {
+		return nil, apperr.Invalid("name", "workspace name cannot be empty")
+	}
+
+	ws := &models.Workspace{
+		ID:   uuid.New().String(),
+		Name: req.GetName(),
+	}
+	if req.GetDescription() != "" {
+		ws.Description = &req.Description
+	}
+
+	if err := s.Repo.CreateWorkspace(ctx, ws); err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+
+	if subject := auth.Subject(ctx, ""); subject != "" {
+		owner := &models.WorkspaceMember{
+			WorkspaceID: ws.ID,
+			Subject:     subject,
+			Role:        models.RoleOwner,
+		}
+		if err := s.Members.GrantWorkspaceRole(ctx, owner); err != nil {
+			return nil, fmt.Errorf("failed to make creator the workspace owner: %w", err)
+		}
+	}
+	return s.toProto(ctx, ws)
+}
+
+func (s *WorkspaceService) GetWorkspace(ctx context.Context, req *proto.GetWorkspaceRequest) (*proto.WorkspaceResponse, error) {
+	if err := s.Authz.CheckWorkspace(ctx, req.GetId(), rbac.PermRead); err != nil {
+		return nil, err
+	}
+
+	ws, err := s.Repo.GetWorkspace(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return s.toProto(ctx, ws)
+}
+
+func (s *WorkspaceService) ListWorkspaces(ctx context.Context, req *proto.ListWorkspacesRequest) (*proto.ListWorkspacesResponse, error) {
+	workspaces, err := s.Repo.ListWorkspaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	var visible map[string]bool
+	if !s.Authz.IsAdmin(ctx) {
+		ids, err := s.Members.ListWorkspaceIDsForSubject(ctx, auth.Subject(ctx, ""))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list workspace memberships: %w", err)
+		}
+		visible = make(map[string]bool, len(ids))
+		for _, id := range ids {
+			visible[id] = true
+		}
+	}
+
+	resp := &proto.ListWorkspacesResponse{}
+	for _, ws := range workspaces {
+		if visible != nil && !visible[ws.ID] {
+			continue
+		}
+		pws, err := s.toProto(ctx, ws)
+		if err != nil {
+			return nil, err
+		}
+		resp.Workspaces = append(resp.Workspaces, pws)
+	}
+	return resp, nil
+}
+
+func (s *WorkspaceService) UpdateWorkspace(ctx context.Context, req *proto.UpdateWorkspaceRequest) (*proto.WorkspaceResponse, error) {
+	if err := s.Authz.CheckWorkspace(ctx, req.GetId(), rbac.PermManage); err != nil {
+		return nil, err
+	}
+
+	ws, err := s.Repo.GetWorkspace(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetName() != "" {
+		ws.Name = req.GetName()
+	}
+	if req.GetDescription() != "" {
+		ws.Description = &req.Description
+	}
+
+	if err := s.Repo.UpdateWorkspace(ctx, ws); err != nil {
+		return nil, fmt.Errorf("failed to update workspace: %w", err)
+	}
+	return s.toProto(ctx, ws)
+}
+
+func (s *WorkspaceService) DeleteWorkspace(ctx context.Context, req *proto.DeleteWorkspaceRequest) (*proto.DeleteResponse, error) {
+	if req.GetId() == models.DefaultWorkspaceID {
+		return nil, apperr.FailedPrecondition("DEFAULT_WORKSPACE", "the default workspace cannot be deleted")
+	}
+	if err := s.Authz.CheckWorkspace(ctx, req.GetId(), rbac.PermManage); err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.DeleteWorkspace(ctx, req.GetId(), req.GetForce()); err != nil {
+		log.Error().Err(err).Str("workspace_id", req.GetId()).Msg("Failed to delete workspace")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NotFound("workspace", req.GetId())
+		}
+		return nil, err
+	}
+
+	return &proto.DeleteResponse{
+		Success: true,
+		Message: "Workspace deleted successfully",
+	}, nil
+}
+
+func (s *WorkspaceService) GrantWorkspaceRole(ctx context.Context, req *proto.GrantWorkspaceRoleRequest) (*proto.Member, error) {
+	if req.GetSubject() == "" {
+		return nil, apperr.Invalid("subject", "member subject cannot be empty")
+	}
+	role, err := rbac.ParseRole(req.GetRole())
+	if err != nil {
+		return nil, apperr.Invalid("role", "%v", err)
+	}
+	if err := s.Authz.CheckWorkspace(ctx, req.GetWorkspaceId(), rbac.PermManage); err != nil {
+		return nil, err
+	}
+	if _, err := s.Repo.GetWorkspace(ctx, req.GetWorkspaceId()); err != nil {
+		return nil, err
+	}
+
+	member := &models.WorkspaceMember{
+		WorkspaceID: req.GetWorkspaceId(),
+		Subject:     req.GetSubject(),
+		Role:        role,
+	}
+	if err := s.Members.GrantWorkspaceRole(ctx, member); err != nil {
+		return nil, fmt.Errorf("failed to grant workspace role: %w", err)
+	}
+	return utils.ConvertMemberToProto(member.Subject, member.Role, member.CreatedAt), nil
+}
+
+func (s *WorkspaceService) RevokeWorkspaceRole(ctx context.Context, req *proto.RevokeWorkspaceRoleRequest) (*proto.DeleteResponse, error) {
+	if err := s.Authz.CheckWorkspace(ctx, req.GetWorkspaceId(), rbac.PermManage); err != nil {
+		return nil, err
+	}
+
+	err := s.Members.RevokeWorkspaceRole(ctx, req.GetWorkspaceId(), req.GetSubject())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperr.NotFound("workspace member", req.GetSubject())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke workspace role: %w", err)
+	}
+
+	return &proto.DeleteResponse{
+		Success: true,
+		Message: "Workspace role revoked successfully",
+	}, nil
+}
+
+func (s *WorkspaceService) ListWorkspaceMembers(ctx context.Context, req *proto.ListWorkspaceMembersRequest) (*proto.ListMembersResponse, error) {
+	if err := s.Authz.CheckWorkspace(ctx, req.GetWorkspaceId(), rbac.PermRead); err != nil {
+		return nil, err
+	}
+
+	members, err := s.Members.ListWorkspaceMembers(ctx, req.GetWorkspaceId())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspace members: %w", err)
+	}
+
+	resp := &proto.ListMembersResponse{}
+	for _, m := range members {
+		resp.Members = append(resp.Members, utils.ConvertMemberToProto(m.Subject, m.Role, m.CreatedAt))
+	}
+	return resp, nil
+}
+
+func (s *WorkspaceService) toProto(ctx context.Context, ws *models.Workspace) (*proto.WorkspaceResponse, error) {
+	count, err := s.Repo.CountCollections(ctx, ws.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count workspace collections: %w", err)
+	}
+
+	pws := &proto.WorkspaceResponse{
+		Id:              ws.ID,
+		Name:            ws.Name,
+		CollectionCount: int32(count),
+		CreatedAt:       timestamppb.New(ws.CreatedAt),
+	}
+	if ws.Description != nil {
+		pws.Description = *ws.Description
+	}
+	return pws, nil
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/executor"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/datatypes"
+)
+
+var assertionTypes = map[proto.AssertionType]models.AssertionType{
+	proto.AssertionType_ASSERTION_TYPE_STATUS_EQUALS:       models.AssertStatusEquals,
+	proto.AssertionType_ASSERTION_TYPE_STATUS_IN_RANGE:     models.AssertStatusInRange,
+	proto.AssertionType_ASSERTION_TYPE_HEADER_PRESENT:      models.AssertHeaderPresent,
+	proto.AssertionType_ASSERTION_TYPE_HEADER_EQUALS:       models.AssertHeaderEquals,
+	proto.AssertionType_ASSERTION_TYPE_JSONPATH_EQUALS:     models.AssertJSONPathEquals,
+	proto.AssertionType_ASSERTION_TYPE_JSONPATH_CONTAINS:   models.AssertJSONPathContains,
+	proto.AssertionType_ASSERTION_TYPE_JSONPATH_MATCHES:    models.AssertJSONPathMatches,
+	proto.AssertionType_ASSERTION_TYPE_RESPONSE_TIME_BELOW: models.AssertResponseTimeBelow,
+	proto.AssertionType_ASSERTION_TYPE_JSON_SCHEMA:         models.AssertJSONSchema,
+}
+
+// ConvertProtoAssertions validates assertions and encodes them for storage.
+func ConvertProtoAssertions(list []*proto.Assertion) (datatypes.JSON, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+	assertions := make([]models.Assertion, 0, len(list))
+	for i, a := range list {
+		t, ok := assertionTypes[a.GetType()]
+		if !ok {
+			return nil, apperr.Invalid(fmt.Sprintf("assertions[%d].type", i), "assertion %d: unknown assertion type %v", i, a.GetType())
+		}
+		m := models.Assertion{
+			Type:   t,
+			Target: a.GetTarget(),
+			Value:  a.GetValue(),
+			Number: a.GetNumber(),
+			Min:    a.GetMin(),
+			Max:    a.GetMax(),
+		}
+		if err := executor.CheckAssertion(m); err != nil {
+			return nil, apperr.Invalid(fmt.Sprintf("assertions[%d]", i), "assertion %d: %v", i, err)
+		}
+		assertions = append(assertions, m)
+	}
+	raw, err := json.Marshal(assertions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal assertions: %w", err)
+	}
+	return datatypes.JSON(raw), nil
+}
+
+func ConvertAssertionsToProto(raw []byte) []*proto.Assertion {
+	list, _ := executor.ParseAssertions(raw)
+	var out []*proto.Assertion
+	for _, a := range list {
+		out = append(out, &proto.Assertion{
+			Type:   protoAssertionType(a.Type),
+			Target: a.Target,
+			Value:  a.Value,
+			Number: a.Number,
+			Min:    a.Min,
+			Max:    a.Max,
+		})
+	}
+	return out
+}
+
+func ConvertAssertionResultToProto(r executor.AssertionResult) *proto.AssertionResult {
+	return &proto.AssertionResult{
+		Index:   int32(r.Index),
+		Type:    protoAssertionType(r.Assertion.Type),
+		Target:  r.Assertion.Target,
+		Passed:  r.Passed,
+		Message: r.Message,
+	}
+}
+
+func protoAssertionType(t models.AssertionType) proto.AssertionType {
+	for p, m := range assertionTypes {
+		if m == t {
+			return p
+		}
+	}
+	return proto.AssertionType_ASSERTION_TYPE_UNSPECIFIED
+}
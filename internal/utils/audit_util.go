@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var auditOutcomes = map[proto.AuditOutcome]models.AuditOutcome{
+	proto.AuditOutcome_AUDIT_OUTCOME_SUCCEEDED: models.AuditSucceeded,
+	proto.AuditOutcome_AUDIT_OUTCOME_FAILED:    models.AuditFailed,
+	proto.AuditOutcome_AUDIT_OUTCOME_DENIED:    models.AuditDenied,
+}
+
+// ConvertProtoAuditOutcome maps an outcome filter; UNSPECIFIED matches any
+// event.
+func ConvertProtoAuditOutcome(o proto.AuditOutcome) models.AuditOutcome {
+	return auditOutcomes[o]
+}
+
+func protoAuditOutcome(o models.AuditOutcome) proto.AuditOutcome {
+	for p, m := range auditOutcomes {
+		if m == o {
+			return p
+		}
+	}
+	return proto.AuditOutcome_AUDIT_OUTCOME_UNSPECIFIED
+}
+
+func ConvertAuditEventToProto(e *models.AuditEvent) *proto.AuditEvent {
+	if e == nil {
+		return nil
+	}
+	return &proto.AuditEvent{
+		Id:           e.ID,
+		Method:       e.Method,
+		Subject:      e.Subject,
+		AuthMethod:   e.AuthMethod,
+		ApiKeyId:     e.APIKeyID,
+		WorkspaceId:  e.WorkspaceID,
+		CollectionId: deref(e.CollectionID),
+		PeerAddress:  e.PeerAddress,
+		ForwardedFor: e.ForwardedFor,
+		Payload:      string(e.Payload),
+		Outcome:      protoAuditOutcome(e.Outcome),
+		Code:         e.Code,
+		Error:        deref(e.Error),
+		Before:       string(e.Before),
+		After:        string(e.After),
+		DurationMs:   e.DurationMs,
+		OccurredAt:   timestamppb.New(e.CreatedAt),
+	}
+}
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/secrets"
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/datatypes"
+)
+
+var authTypes = map[proto.AuthType]models.AuthType{
+	proto.AuthType_AUTH_TYPE_INHERIT:   models.AuthInherit,
+	proto.AuthType_AUTH_TYPE_NONE:      models.AuthNone,
+	proto.AuthType_AUTH_TYPE_BASIC:     models.AuthBasic,
+	proto.AuthType_AUTH_TYPE_BEARER:    models.AuthBearer,
+	proto.AuthType_AUTH_TYPE_API_KEY:   models.AuthAPIKey,
+	proto.AuthType_AUTH_TYPE_OAUTH2:    models.AuthOAuth2,
+	proto.AuthType_AUTH_TYPE_AWS_SIGV4: models.AuthAWSSigV4,
+	proto.AuthType_AUTH_TYPE_DIGEST:    models.AuthDigest,
+}
+
+// ConvertProtoAuth validates an auth block and encodes it for storage. A nil
+// or unspecified block stores nothing, which means inherit.
+func ConvertProtoAuth(a *proto.AuthConfig) (datatypes.JSON, error) {
+	if a == nil || a.GetType() == proto.AuthType_AUTH_TYPE_UNSPECIFIED {
+		return nil, nil
+	}
+	t, ok := authTypes[a.GetType()]
+	if !ok {
+		return nil, apperr.Invalid("auth.type", "unknown auth type %v", a.GetType())
+	}
+
+	cfg := models.AuthConfig{Type: t}
+	switch t {
+	case models.AuthBasic, models.AuthDigest:
+		if a.GetUsername() == "" {
+			return nil, apperr.Invalid("auth.username", "auth username cannot be empty")
+		}
+		cfg.Username, cfg.Password = a.GetUsername(), a.GetPassword()
+	case models.AuthBearer:
+		if a.GetToken() == "" {
+			return nil, apperr.Invalid("auth.token", "bearer token cannot be empty")
+		}
+		cfg.Token = a.GetToken()
+	case models.AuthAPIKey:
+		if a.GetApiKeyName() == "" || a.GetApiKeyValue() == "" {
+			return nil, apperr.Invalid("auth.api_key_value", "API key name and value are required")
+		}
+		cfg.APIKeyName, cfg.APIKeyValue = a.GetApiKeyName(), a.GetApiKeyValue()
+		cfg.APIKeyIn = models.APIKeyInHeader
+		if a.GetApiKeyIn() == proto.APIKeyLocation_API_KEY_LOCATION_QUERY {
+			cfg.APIKeyIn = models.APIKeyInQuery
+		}
+	case models.AuthOAuth2:
+		if a.GetTokenUrl() == "" || a.GetClientId() == "" {
+			return nil, apperr.Invalid("auth.token_url", "OAuth 2.0 token_url and client_id are required")
+		}
+		cfg.TokenURL, cfg.ClientID, cfg.ClientSecret = a.GetTokenUrl(), a.GetClientId(), a.GetClientSecret()
+		cfg.Scopes = a.GetScopes()
+		cfg.OAuth2Grant = models.OAuth2ClientCredentials
+		if a.GetOauth2Grant() == proto.OAuth2Grant_OAUTH2_GRANT_PASSWORD {
+			if a.GetUsername() == "" {
+				return nil, apperr.Invalid("auth.username", "OAuth 2.0 password grant requires a username")
+			}
+			cfg.OAuth2Grant = models.OAuth2Password
+			cfg.Username, cfg.Password = a.GetUsername(), a.GetPassword()
+		}
+	case models.AuthAWSSigV4:
+		if a.GetAwsAccessKeyId() == "" || a.GetAwsSecretAccessKey() == "" || a.GetAwsRegion() == "" || a.GetAwsService() == "" {
+			return nil, apperr.Invalid("auth", "AWS SigV4 requires an access key id, secret access key, region and service")
+		}
+		cfg.AWSAccessKeyID, cfg.AWSSecretAccessKey, cfg.AWSSessionToken = a.GetAwsAccessKeyId(), a.GetAwsSecretAccessKey(), a.GetAwsSessionToken()
+		cfg.AWSRegion, cfg.AWSService = a.GetAwsRegion(), a.GetAwsService()
+	}
+
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal auth: %w", err)
+	}
+	return datatypes.JSON(raw), nil
+}
+
+// ConvertAuthToProto renders a stored auth block for a read. Sealed
+// credentials are redacted.
+func ConvertAuthToProto(raw []byte) *proto.AuthConfig {
+	a := secrets.DisplayAuth(raw)
+	if a == nil {
+		return nil
+	}
+
+	pa := &proto.AuthConfig{
+		Username:           a.Username,
+		Password:           a.Password,
+		Token:              a.Token,
+		ApiKeyName:         a.APIKeyName,
+		ApiKeyValue:        a.APIKeyValue,
+		TokenUrl:           a.TokenURL,
+		ClientId:           a.ClientID,
+		ClientSecret:       a.ClientSecret,
+		Scopes:             a.Scopes,
+		AwsAccessKeyId:     a.AWSAccessKeyID,
+		AwsSecretAccessKey: a.AWSSecretAccessKey,
+		AwsSessionToken:    a.AWSSessionToken,
+		AwsRegion:          a.AWSRegion,
+		AwsService:         a.AWSService,
+	}
+	for pt, t := range authTypes {
+		if t == a.Type {
+			pa.Type = pt
+		}
+	}
+	if a.APIKeyIn == models.APIKeyInQuery {
+		pa.ApiKeyIn = proto.APIKeyLocation_API_KEY_LOCATION_QUERY
+	}
+	if a.OAuth2Grant == models.OAuth2Password {
+		pa.Oauth2Grant = proto.OAuth2Grant_OAUTH2_GRANT_PASSWORD
+	}
+	return pa
+}
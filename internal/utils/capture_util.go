@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/executor"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/datatypes"
+)
+
+var captureSources = map[proto.CaptureSource]models.CaptureSource{
+	proto.CaptureSource_CAPTURE_SOURCE_JSONPATH: models.CaptureJSONPath,
+	proto.CaptureSource_CAPTURE_SOURCE_HEADER:   models.CaptureHeader,
+	proto.CaptureSource_CAPTURE_SOURCE_REGEX:    models.CaptureRegex,
+	proto.CaptureSource_CAPTURE_SOURCE_COOKIE:   models.CaptureCookie,
+}
+
+// ConvertProtoCaptures validates captures and encodes them for storage.
+func ConvertProtoCaptures(list []*proto.Capture) (datatypes.JSON, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+	captures := make([]models.Capture, 0, len(list))
+	for i, c := range list {
+		source, ok := captureSources[c.GetSource()]
+		if !ok {
+			return nil, apperr.Invalid(fmt.Sprintf("captures[%d].source", i), "capture %d: unknown capture source %v", i, c.GetSource())
+		}
+		m := models.Capture{
+			Source:     source,
+			Expression: c.GetExpression(),
+			Variable:   c.GetVariable(),
+			Scope:      models.CaptureScopeRun,
+		}
+		if c.GetScope() == proto.CaptureScope_CAPTURE_SCOPE_COLLECTION {
+			m.Scope = models.CaptureScopeCollection
+		}
+		if err := executor.CheckCapture(m); err != nil {
+			return nil, apperr.Invalid(fmt.Sprintf("captures[%d]", i), "capture %d: %v", i, err)
+		}
+		captures = append(captures, m)
+	}
+	raw, err := json.Marshal(captures)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal captures: %w", err)
+	}
+	return datatypes.JSON(raw), nil
+}
+
+func ConvertCapturesToProto(raw []byte) []*proto.Capture {
+	list, _ := executor.ParseCaptures(raw)
+	var out []*proto.Capture
+	for _, c := range list {
+		out = append(out, &proto.Capture{
+			Source:     protoCaptureSource(c.Source),
+			Expression: c.Expression,
+			Variable:   c.Variable,
+			Scope:      protoCaptureScope(c.Scope),
+		})
+	}
+	return out
+}
+
+func ConvertCaptureResultToProto(r executor.CaptureResult) *proto.CaptureResult {
+	return &proto.CaptureResult{
+		Variable: r.Capture.Variable,
+		Scope:    protoCaptureScope(r.Capture.Scope),
+		Captured: r.Captured,
+		Message:  r.Message,
+	}
+}
+
+func protoCaptureSource(s models.CaptureSource) proto.CaptureSource {
+	for p, m := range captureSources {
+		if m == s {
+			return p
+		}
+	}
+	return proto.CaptureSource_CAPTURE_SOURCE_UNSPECIFIED
+}
+
+func protoCaptureScope(s models.CaptureScope) proto.CaptureScope {
+	if s == models.CaptureScopeCollection {
+		return proto.CaptureScope_CAPTURE_SCOPE_COLLECTION
+	}
+	return proto.CaptureScope_CAPTURE_SCOPE_RUN
+}
@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/secrets"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/datatypes"
+)
+
+// ConvertProtoExampleHeaders encodes example headers as stored entries.
+// Examples are documentation served as-is, so their headers cannot be secret.
+func ConvertProtoExampleHeaders(headers []*proto.HeaderInput) (datatypes.JSON, error) {
+	if len(headers) == 0 {
+		return nil, nil
+	}
+	entries := make([]secrets.Entry, 0, len(headers))
+	for i, h := range headers {
+		if h.GetKey() == "" {
+			return nil, apperr.Invalid(fmt.Sprintf("headers[%d].key", i), "header name cannot be empty")
+		}
+		if h.GetSecret() {
+			return nil, apperr.Invalid(fmt.Sprintf("headers[%d].secret", i), "example header %q cannot be secret", h.GetKey())
+		}
+		entries = append(entries, secrets.Entry{Key: h.GetKey(), Value: h.GetValue()})
+	}
+	raw, err := json.Marshal(entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal headers: %w", err)
+	}
+	return datatypes.JSON(raw), nil
+}
+
+// ConvertHTTPHeaders encodes response headers as stored entries.
+func ConvertHTTPHeaders(h http.Header) datatypes.JSON {
+	var entries []secrets.Entry
+	for name, values := range h {
+		for _, v := range values {
+			entries = append(entries, secrets.Entry{Key: name, Value: v})
+		}
+	}
+	raw, _ := json.Marshal(entries)
+	return datatypes.JSON(raw)
+}
+
+// ConvertProtoExampleVariant sets the request variant of e. A nil variant
+// clears it.
+func ConvertProtoExampleVariant(v *proto.ExampleRequestVariant, e *models.Example) error {
+	e.RequestPath, e.RequestQuery, e.RequestHeaders, e.RequestBody = nil, nil, nil, nil
+	if v == nil {
+		return nil
+	}
+	if v.GetPath() != "" {
+		e.RequestPath = &v.Path
+	}
+	if v.GetBody() != "" {
+		e.RequestBody = &v.Body
+	}
+	if len(v.GetQueryParams()) > 0 {
+		query, err := json.Marshal(v.GetQueryParams())
+		if err != nil {
+			return fmt.Errorf("failed to marshal query params: %w", err)
+		}
+		e.RequestQuery = datatypes.JSON(query)
+	}
+	headers, err := ConvertProtoExampleHeaders(v.GetHeaders())
+	if err != nil {
+		return err
+	}
+	e.RequestHeaders = headers
+	return nil
+}
+
+func ConvertExampleToProto(e *models.Example) *proto.ExampleResponse {
+	if e == nil {
+		return nil
+	}
+	resp := &proto.ExampleResponse{
+		Id:         e.ID,
+		RequestId:  e.RequestID,
+		Name:       e.Name,
+		StatusCode: int32(e.StatusCode),
+		Headers:    ConvertHeadersToProto(e.Headers),
+		Body:       e.Body,
+		CreatedAt:  timestamppb.New(e.CreatedAt),
+		UpdatedAt:  timestamppb.New(e.UpdatedAt),
+	}
+	if e.RequestPath != nil || e.RequestBody != nil || len(e.RequestQuery) > 0 || len(e.RequestHeaders) > 0 {
+		variant := &proto.ExampleRequestVariant{
+			Path:    deref(e.RequestPath),
+			Body:    deref(e.RequestBody),
+			Headers: ConvertHeadersToProto(e.RequestHeaders),
+		}
+		entries, _ := secrets.ParseEntries(e.RequestQuery)
+		for _, q := range entries {
+			variant.QueryParams = append(variant.QueryParams, &proto.QueryParamInput{Key: q.Key, Value: q.Value})
+		}
+		resp.Request = variant
+	}
+	return resp
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"collectionsservice/internal/executor"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/secrets"
+)
+
+// ConvertOutcomeToProto builds the response of an execution. Values known
+// to redactor are hidden in errors, console output and test messages; a nil
+// redactor shows them.
+func ConvertOutcomeToProto(o *executor.Outcome, redactor *secrets.Redactor) *proto.ExecuteRequestResponse {
+	if o == nil {
+		return nil
+	}
+	resp := &proto.ExecuteRequestResponse{}
+	for _, line := range o.Console {
+		resp.Console = append(resp.Console, redactor.Redact(line))
+	}
+	if o.Err != nil {
+		resp.Error = redactor.Redact(o.Err.Error())
+	}
+	if o.ScriptErr != nil {
+		resp.ScriptError = redactor.Redact(o.ScriptErr.Error())
+	}
+	for _, a := range o.Assertions {
+		resp.Assertions = append(resp.Assertions, ConvertAssertionResultToProto(a))
+	}
+	for _, c := range o.Captures {
+		resp.Captures = append(resp.Captures, ConvertCaptureResultToProto(c))
+	}
+	for _, t := range o.Tests {
+		resp.Tests = append(resp.Tests, &proto.ScriptTestResult{
+			Name:    t.Name,
+			Passed:  t.Passed,
+			Message: redactor.Redact(t.Message),
+		})
+	}
+
+	if r := o.Result; r != nil {
+		resp.StatusCode = int32(r.StatusCode)
+		resp.Body = string(r.Body)
+		resp.DurationMs = r.Duration.Milliseconds()
+		for name, values := range r.Headers {
+			for _, v := range values {
+				resp.Headers = append(resp.Headers, &proto.HeaderInput{Key: name, Value: v})
+			}
+		}
+	}
+	return resp
+}
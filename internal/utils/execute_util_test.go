@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"collectionsservice/internal/executor"
+	"collectionsservice/internal/secrets"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConvertOutcomeToProtoRedacts(t *testing.T) {
+	const secret = "s3cr3t-token"
+	o := &executor.Outcome{
+		Err:       errors.New("dial https://api.example.com?key=" + secret + ": refused"),
+		ScriptErr: errors.New("ReferenceError near " + secret),
+		Console:   []string{"token is " + secret},
+	}
+
+	tests := []struct {
+		name     string
+		redactor *secrets.Redactor
+		visible  bool
+	}{
+		{name: "redacted", redactor: secrets.NewRedactor(secret), visible: false},
+		{name: "revealed", redactor: nil, visible: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := ConvertOutcomeToProto(o, tt.redactor)
+			for field, v := range map[string]string{
+				"error":        resp.GetError(),
+				"script_error": resp.GetScriptError(),
+				"console":      strings.Join(resp.GetConsole(), "\n"),
+			} {
+				if got := strings.Contains(v, secret); got != tt.visible {
+					t.Errorf("%s = %q, secret visible %v, want %v", field, v, got, tt.visible)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ConvertMemberToProto(subject string, role models.Role, createdAt time.Time) *proto.Member {
+	return &proto.Member{
+		Subject:   subject,
+		Role:      string(role),
+		CreatedAt: timestamppb.New(createdAt),
+	}
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/datatypes"
+)
+
+// ConvertProtoMonitorVariables encodes monitor variables. Secrets belong in
+// the collection's variables, so monitor variables cannot be secret.
+func ConvertProtoMonitorVariables(vars []*proto.VariableInput) (datatypes.JSON, error) {
+	for i, v := range vars {
+		if v.GetSecret() {
+			return nil, apperr.Invalid(fmt.Sprintf("variables[%d].secret", i), "monitor variable %q cannot be secret", v.GetKey())
+		}
+	}
+	return ConvertProtoVariables(vars)
+}
+
+func ConvertMonitorToProto(m *models.Monitor) *proto.MonitorResponse {
+	if m == nil {
+		return nil
+	}
+	resp := &proto.MonitorResponse{
+		Id:                  m.ID,
+		CollectionId:        m.CollectionID,
+		Name:                m.Name,
+		Cron:                m.Cron,
+		Timezone:            m.Timezone,
+		Enabled:             m.Enabled,
+		Variables:           ConvertVariablesToProto(m.Variables),
+		NotifyUrl:           deref(m.NotifyURL),
+		CreatedBy:           m.CreatedBy,
+		LastStatus:          protoRunStatus(m.LastStatus),
+		LastCollectionRunId: deref(m.LastCollectionRunID),
+		CreatedAt:           timestamppb.New(m.CreatedAt),
+		UpdatedAt:           timestamppb.New(m.UpdatedAt),
+	}
+	if m.NextRunAt != nil {
+		resp.NextRunAt = timestamppb.New(*m.NextRunAt)
+	}
+	if m.LastRunAt != nil {
+		resp.LastRunAt = timestamppb.New(*m.LastRunAt)
+	}
+	return resp
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/secrets"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var proposalChangeTypes = map[models.ProposalChangeType]proto.ProposalChangeType{
+	models.ProposalChangeAdded:      proto.ProposalChangeType_PROPOSAL_CHANGE_TYPE_ADDED,
+	models.ProposalChangeModified:   proto.ProposalChangeType_PROPOSAL_CHANGE_TYPE_MODIFIED,
+	models.ProposalChangeRemoved:    proto.ProposalChangeType_PROPOSAL_CHANGE_TYPE_REMOVED,
+	models.ProposalChangeCollection: proto.ProposalChangeType_PROPOSAL_CHANGE_TYPE_COLLECTION,
+}
+
+func ConvertProtoProposalStatus(s proto.ProposalStatus) models.ProposalStatus {
+	switch s {
+	case proto.ProposalStatus_PROPOSAL_STATUS_OPEN:
+		return models.ProposalStatusOpen
+	case proto.ProposalStatus_PROPOSAL_STATUS_APPROVED:
+		return models.ProposalStatusApproved
+	case proto.ProposalStatus_PROPOSAL_STATUS_REJECTED:
+		return models.ProposalStatusRejected
+	}
+	return ""
+}
+
+func convertProposalStatusToProto(s models.ProposalStatus) proto.ProposalStatus {
+	switch s {
+	case models.ProposalStatusOpen:
+		return proto.ProposalStatus_PROPOSAL_STATUS_OPEN
+	case models.ProposalStatusApproved:
+		return proto.ProposalStatus_PROPOSAL_STATUS_APPROVED
+	case models.ProposalStatusRejected:
+		return proto.ProposalStatus_PROPOSAL_STATUS_REJECTED
+	}
+	return proto.ProposalStatus_PROPOSAL_STATUS_UNSPECIFIED
+}
+
+func ConvertProposalToProto(p *models.ChangeProposal) (*proto.ChangeProposalResponse, error) {
+	if p == nil {
+		return nil, nil
+	}
+	pp := &proto.ChangeProposalResponse{
+		Id:                   p.ID,
+		ForkCollectionId:     p.ForkCollectionID,
+		UpstreamCollectionId: p.UpstreamCollectionID,
+		Title:                p.Title,
+		Author:               p.Author,
+		Status:               convertProposalStatusToProto(p.Status),
+		CreatedAt:            timestamppb.New(p.CreatedAt),
+	}
+	if p.Description != nil {
+		pp.Description = *p.Description
+	}
+	if p.ReviewedBy != nil {
+		pp.ReviewedBy = *p.ReviewedBy
+	}
+	if p.ReviewedAt != nil {
+		pp.ReviewedAt = timestamppb.New(*p.ReviewedAt)
+	}
+
+	var changes []models.ProposalChange
+	if len(p.Changes) > 0 {
+		if err := json.Unmarshal(p.Changes, &changes); err != nil {
+			return nil, fmt.Errorf("failed to decode proposal changes: %w", err)
+		}
+	}
+	for _, c := range changes {
+		pc := &proto.ProposedChange{
+			Type:              proposalChangeTypes[c.Type],
+			Name:              c.Name,
+			ForkRequestId:     c.ForkRequestID,
+			UpstreamRequestId: c.UpstreamRequestID,
+		}
+		for _, d := range c.Diffs {
+			pc.Diffs = append(pc.Diffs, &proto.ProposalFieldChange{
+				Field:         d.Field,
+				ProposedValue: secrets.RedactField(d.Field, d.Proposed),
+				CurrentValue:  secrets.RedactField(d.Field, d.Current),
+			})
+		}
+		pp.Changes = append(pp.Changes, pc)
+	}
+
+	for _, c := range p.Comments {
+		pp.Comments = append(pp.Comments, &proto.ProposalComment{
+			Id:        c.ID,
+			Author:    c.Author,
+			Body:      c.Body,
+			CreatedAt: timestamppb.New(c.CreatedAt),
+		})
+	}
+
+	return pp, nil
+}
@@ -0,0 +1,176 @@
+package utils
+
+import (
+	"collectionsservice/internal/executor"
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/scripting"
+	"collectionsservice/internal/secrets"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/datatypes"
+)
+
+var runStatuses = map[proto.RunStatus]models.RunStatus{
+	proto.RunStatus_RUN_STATUS_PASSED: models.RunStatusPassed,
+	proto.RunStatus_RUN_STATUS_FAILED: models.RunStatusFailed,
+	proto.RunStatus_RUN_STATUS_ERROR:  models.RunStatusError,
+}
+
+// credentialHeaders are always redacted in stored request headers.
+var credentialHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+}
+
+// ConvertOutcomeToRun builds the history record of o. Values known to
+// redactor are hidden, and each body is cut to bodyLimit bytes.
+func ConvertOutcomeToRun(o *executor.Outcome, r *models.Request, redactor *secrets.Redactor, bodyLimit int) *models.RequestRun {
+	run := &models.RequestRun{
+		CollectionID: r.CollectionID,
+		RequestID:    r.ID,
+		RequestName:  r.Name,
+		Status:       models.RunStatusPassed,
+	}
+	switch {
+	case o.Result == nil:
+		run.Status = models.RunStatusError
+	case o.Failed():
+		run.Status = models.RunStatusFailed
+	}
+	if o.Err != nil {
+		msg := redactor.Redact(o.Err.Error())
+		run.Error = &msg
+	}
+	if o.ScriptErr != nil {
+		msg := redactor.Redact(o.ScriptErr.Error())
+		run.ScriptError = &msg
+	}
+
+	var truncated bool
+	if sent := o.Request; sent != nil {
+		run.Method = sent.Method
+		run.URL = redactor.Redact(sent.URL)
+		run.RequestHeaders = runHeaders(sent.Headers, redactor, true)
+		run.RequestBody, truncated = runBody(redactor.Redact(string(sent.Body)), bodyLimit)
+		run.Truncated = truncated
+	}
+	if res := o.Result; res != nil {
+		run.StatusCode = res.StatusCode
+		run.DurationMs = res.Duration.Milliseconds()
+		run.ResponseHeaders = runHeaders(res.Headers, redactor, false)
+		run.ResponseBody, truncated = runBody(redactor.Redact(string(res.Body)), bodyLimit)
+		run.Truncated = run.Truncated || truncated
+	}
+
+	if len(o.Assertions) > 0 {
+		raw, _ := json.Marshal(o.Assertions)
+		run.Assertions = datatypes.JSON(redactor.Redact(string(raw)))
+	}
+	if len(o.Tests) > 0 {
+		raw, _ := json.Marshal(o.Tests)
+		run.Tests = datatypes.JSON(redactor.Redact(string(raw)))
+	}
+	return run
+}
+
+func runHeaders(h http.Header, redactor *secrets.Redactor, outgoing bool) datatypes.JSON {
+	var entries []secrets.Entry
+	for name, values := range h {
+		for _, v := range values {
+			if outgoing && credentialHeaders[http.CanonicalHeaderKey(name)] {
+				v = secrets.Redacted
+			}
+			entries = append(entries, secrets.Entry{Key: name, Value: redactor.Redact(v)})
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	raw, _ := json.Marshal(entries)
+	return datatypes.JSON(raw)
+}
+
+// runBody makes body storable as text, cutting it to limit bytes at a rune
+// boundary. A limit of zero or less keeps the whole body.
+func runBody(body string, limit int) (string, bool) {
+	truncated := false
+	if limit > 0 && len(body) > limit {
+		body = body[:limit]
+		for i := 1; i < utf8.UTFMax && len(body) > 0; i++ {
+			if r, size := utf8.DecodeLastRuneInString(body); r != utf8.RuneError || size != 1 {
+				break
+			}
+			body = body[:len(body)-1]
+		}
+		truncated = true
+	}
+	body = strings.ToValidUTF8(body, "�")
+	return strings.ReplaceAll(body, "\x00", "�"), truncated
+}
+
+// ConvertRunToProto converts a stored run. Headers are stored already
+// redacted, so they are returned as they are.
+func ConvertRunToProto(run *models.RequestRun) *proto.RunRecord {
+	if run == nil {
+		return nil
+	}
+	resp := &proto.RunRecord{
+		Id:              run.ID,
+		CollectionId:    run.CollectionID,
+		RequestId:       run.RequestID,
+		CollectionRunId: deref(run.CollectionRunID),
+		RequestName:     run.RequestName,
+		TriggeredBy:     run.TriggeredBy,
+		Status:          protoRunStatus(run.Status),
+		Method:          run.Method,
+		Url:             run.URL,
+		RequestHeaders:  ConvertHeadersToProto(run.RequestHeaders),
+		RequestBody:     run.RequestBody,
+		StatusCode:      int32(run.StatusCode),
+		ResponseHeaders: ConvertHeadersToProto(run.ResponseHeaders),
+		ResponseBody:    run.ResponseBody,
+		Truncated:       run.Truncated,
+		DurationMs:      run.DurationMs,
+		Error:           deref(run.Error),
+		ScriptError:     deref(run.ScriptError),
+		StartedAt:       timestamppb.New(run.StartedAt),
+	}
+
+	var assertions []executor.AssertionResult
+	if len(run.Assertions) > 0 && json.Unmarshal(run.Assertions, &assertions) == nil {
+		for _, a := range assertions {
+			resp.Assertions = append(resp.Assertions, ConvertAssertionResultToProto(a))
+		}
+	}
+	var tests []scripting.TestResult
+	if len(run.Tests) > 0 && json.Unmarshal(run.Tests, &tests) == nil {
+		for _, t := range tests {
+			resp.Tests = append(resp.Tests, &proto.ScriptTestResult{
+				Name:    t.Name,
+				Passed:  t.Passed,
+				Message: t.Message,
+			})
+		}
+	}
+	return resp
+}
+
+// ConvertProtoRunStatus maps a status filter; UNSPECIFIED matches any run.
+func ConvertProtoRunStatus(s proto.RunStatus) models.RunStatus {
+	return runStatuses[s]
+}
+
+func protoRunStatus(s models.RunStatus) proto.RunStatus {
+	for p, m := range runStatuses {
+		if m == s {
+			return p
+		}
+	}
+	return proto.RunStatus_RUN_STATUS_UNSPECIFIED
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"collectionsservice/internal/executor"
+	"collectionsservice/internal/models"
+	"collectionsservice/internal/secrets"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRunBody(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		limit         int
+		want          string
+		wantTruncated bool
+	}{
+		{name: "under limit", body: "hello", limit: 10, want: "hello"},
+		{name: "no limit", body: strings.Repeat("a", 100), limit: 0, want: strings.Repeat("a", 100)},
+		{name: "cut", body: "hello world", limit: 5, want: "hello", wantTruncated: true},
+		{name: "cut inside a rune", body: "héllo", limit: 2, want: "h", wantTruncated: true},
+		{name: "invalid UTF-8 and NUL", body: "a\xffb\x00c", limit: 0, want: "a�b�c"},
+	}
+	for _, tt := range tests {
+		got, truncated := runBody(tt.body, tt.limit)
+		if got != tt.want || truncated != tt.wantTruncated {
+			t.Errorf("%s: runBody = %q, %v, want %q, %v", tt.name, got, truncated, tt.want, tt.wantTruncated)
+		}
+	}
+}
+
+func TestConvertOutcomeToRun(t *testing.T) {
+	const secret = "s3cr3t"
+	redactor := secrets.NewRedactor(secret)
+	r := &models.Request{ID: "r1", CollectionID: "c1", Name: "get"}
+	sent := &executor.Sent{
+		Method:  http.MethodGet,
+		URL:     "https://example.com/?key=" + secret,
+		Headers: http.Header{"Authorization": {"Bearer abc"}, "X-Trace": {secret}},
+	}
+
+	tests := []struct {
+		name       string
+		outcome    *executor.Outcome
+		wantStatus models.RunStatus
+	}{
+		{
+			name:       "passed",
+			outcome:    &executor.Outcome{Request: sent, Result: &executor.Result{StatusCode: 200, Body: []byte("token " + secret)}},
+			wantStatus: models.RunStatusPassed,
+		},
+		{
+			name: "failed assertion",
+			outcome: &executor.Outcome{
+				Request:    sent,
+				Result:     &executor.Result{StatusCode: 500},
+				Assertions: []executor.AssertionResult{{Passed: false}},
+			},
+			wantStatus: models.RunStatusFailed,
+		},
+		{
+			name:       "not sent",
+			outcome:    &executor.Outcome{Request: sent, Err: errors.New("dial " + secret + ": refused")},
+			wantStatus: models.RunStatusError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := ConvertOutcomeToRun(tt.outcome, r, redactor, 1024)
+			if run.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", run.Status, tt.wantStatus)
+			}
+			if run.RequestID != "r1" || run.CollectionID != "c1" || run.RequestName != "get" {
+				t.Errorf("run identifies %s/%s %q", run.CollectionID, run.RequestID, run.RequestName)
+			}
+			stored := run.URL + string(run.RequestHeaders) + run.ResponseBody + deref(run.Error)
+			if strings.Contains(stored, secret) {
+				t.Errorf("secret stored in %q", stored)
+			}
+			if strings.Contains(string(run.RequestHeaders), "Bearer abc") {
+				t.Errorf("credential header stored in %s", run.RequestHeaders)
+			}
+		})
+	}
+}
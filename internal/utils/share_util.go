@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ConvertProtoShareAccess(a proto.ShareAccess) models.ShareAccess {
+	if a == proto.ShareAccess_SHARE_ACCESS_RUN {
+		return models.ShareAccessRun
+	}
+	return models.ShareAccessRead
+}
+
+func ConvertShareAccessToProto(a models.ShareAccess) proto.ShareAccess {
+	if a == models.ShareAccessRun {
+		return proto.ShareAccess_SHARE_ACCESS_RUN
+	}
+	return proto.ShareAccess_SHARE_ACCESS_READ
+}
+
+func ConvertShareToProto(s *models.CollectionShare) *proto.ShareInfo {
+	if s == nil {
+		return nil
+	}
+	info := &proto.ShareInfo{
+		Id:           s.ID,
+		CollectionId: s.CollectionID,
+		Access:       ConvertShareAccessToProto(s.Access),
+		Prefix:       s.Prefix,
+		CreatedBy:    s.CreatedBy,
+		CreatedAt:    timestamppb.New(s.CreatedAt),
+		ExpiresAt:    timestamppb.New(s.ExpiresAt),
+	}
+	if s.RevokedAt != nil {
+		info.RevokedAt = timestamppb.New(*s.RevokedAt)
+	}
+	return info
+}
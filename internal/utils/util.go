@@ -1,8 +1,12 @@
 package utils
 
 import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/merge"
 	"collectionsservice/internal/models"
 	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/scripting"
+	"collectionsservice/internal/secrets"
 	"encoding/json"
 	"fmt"
 
@@ -14,14 +18,39 @@ func ConvertProtoRequests(protoReqs []*proto.CollectionRequestInput, collectionI
 	var requests []models.Request
 
 	for _, r := range protoReqs {
+		if r == nil {
+			continue
+		}
 		req := models.Request{
-			Kind:         models.RequestKind(r.Kind.String()),
-			Name:         r.Name,
+			Kind:         models.RequestKind(r.GetKind().String()),
+			Name:         r.GetName(),
 			ID:           uuid.New().String(),
 			CollectionID: collectionID,
 		}
 
-		switch r.Kind {
+		auth, err := ConvertProtoAuth(r.GetAuth())
+		if err != nil {
+			return nil, err
+		}
+		req.Auth = auth
+
+		if err := CheckScripts(r.GetPreRequestScript(), r.GetTestScript()); err != nil {
+			return nil, err
+		}
+		if req.Assertions, err = ConvertProtoAssertions(r.GetAssertions()); err != nil {
+			return nil, err
+		}
+		if req.Captures, err = ConvertProtoCaptures(r.GetCaptures()); err != nil {
+			return nil, err
+		}
+		if r.PreRequestScript != "" {
+			req.PreRequestScript = &r.PreRequestScript
+		}
+		if r.TestScript != "" {
+			req.TestScript = &r.TestScript
+		}
+
+		switch r.GetKind() {
 		case proto.RequestKind_HTTP:
 			if r.Http != nil {
 				method := r.Http.Method.String()
@@ -81,6 +110,9 @@ func ConvertProtoRequests(protoReqs []*proto.CollectionRequestInput, collectionI
 }
 
 func ConvertModelCollectionToProto(col *models.Collection) *proto.CollectionResponse {
+	if col == nil {
+		return nil
+	}
 	pCol := &proto.CollectionResponse{
 		Id:           col.ID,
 		Name:         col.Name,
@@ -92,6 +124,14 @@ func ConvertModelCollectionToProto(col *models.Collection) *proto.CollectionResp
 	if col.Description != nil {
 		pCol.Description = *col.Description
 	}
+	if col.ForkedFromID != nil {
+		pCol.ForkedFromId = *col.ForkedFromID
+	}
+	pCol.Protected = col.Protected
+	pCol.Variables = ConvertVariablesToProto(col.Variables)
+	pCol.Auth = ConvertAuthToProto(col.Auth)
+	pCol.PreRequestScript = deref(col.PreRequestScript)
+	pCol.TestScript = deref(col.TestScript)
 
 	for _, r := range col.Requests {
 		pReq := &proto.CollectionRequest{}
@@ -100,17 +140,31 @@ func ConvertModelCollectionToProto(col *models.Collection) *proto.CollectionResp
 		case models.RequestKindHTTP:
 			pReq.Request = &proto.CollectionRequest_HttpRequest{
 				HttpRequest: &proto.HTTPRequest{
-					Name:   r.Name,
-					Method: proto.HTTPMethod(proto.HTTPMethod_value[*r.HTTPMethod]),
-					Url:    *r.HTTPURL,
+					Name:    r.Name,
+					Method:  ConvertHTTPMethodToProto(r.HTTPMethod),
+					Url:     deref(r.HTTPURL),
+					Headers: ConvertHeadersToProto(r.HTTPHeaders),
+					Auth:    ConvertAuthToProto(r.Auth),
+
+					PreRequestScript: deref(r.PreRequestScript),
+					TestScript:       deref(r.TestScript),
+					Assertions:       ConvertAssertionsToProto(r.Assertions),
+					Captures:         ConvertCapturesToProto(r.Captures),
 				},
 			}
 		case models.RequestKindGraphQL:
 			pReq.Request = &proto.CollectionRequest_GraphqlRequest{
 				GraphqlRequest: &proto.GraphQLRequest{
 					Name:     r.Name,
-					Endpoint: *r.GraphQLEndpoint,
-					Query:    *r.GraphQLQuery,
+					Endpoint: deref(r.GraphQLEndpoint),
+					Query:    deref(r.GraphQLQuery),
+					Headers:  ConvertHeadersToProto(r.GraphQLHeaders),
+					Auth:     ConvertAuthToProto(r.Auth),
+
+					PreRequestScript: deref(r.PreRequestScript),
+					TestScript:       deref(r.TestScript),
+					Assertions:       ConvertAssertionsToProto(r.Assertions),
+					Captures:         ConvertCapturesToProto(r.Captures),
 				},
 			}
 		}
@@ -121,6 +175,140 @@ func ConvertModelCollectionToProto(col *models.Collection) *proto.CollectionResp
 	return pCol
 }
 
+// ConvertHTTPMethodToProto reads a stored method. A request stored without
+// one is sent as GET, so it reads as GET.
+func ConvertHTTPMethodToProto(method *string) proto.HTTPMethod {
+	if m, ok := proto.HTTPMethod_value[deref(method)]; ok && m != int32(proto.HTTPMethod_HTTP_METHOD_UNSPECIFIED) {
+		return proto.HTTPMethod(m)
+	}
+	return proto.HTTPMethod_GET
+}
+
+// ConvertHeadersToProto renders stored headers for a read. Sealed secrets are
+// redacted.
+func ConvertHeadersToProto(raw []byte) []*proto.HeaderInput {
+	entries, _ := secrets.ParseEntries(raw)
+	var headers []*proto.HeaderInput
+	for _, e := range entries {
+		headers = append(headers, &proto.HeaderInput{
+			Key:    e.Key,
+			Value:  secrets.DisplayValue(e),
+			Secret: e.Secret,
+		})
+	}
+	return headers
+}
+
+func ConvertVariablesToProto(raw []byte) []*proto.VariableInput {
+	entries, _ := secrets.ParseEntries(raw)
+	var vars []*proto.VariableInput
+	for _, e := range entries {
+		vars = append(vars, &proto.VariableInput{
+			Key:    e.Key,
+			Value:  secrets.DisplayValue(e),
+			Secret: e.Secret,
+		})
+	}
+	return vars
+}
+
+func ConvertProtoVariables(vars []*proto.VariableInput) (datatypes.JSON, error) {
+	if len(vars) == 0 {
+		return nil, nil
+	}
+	entries := make([]secrets.Entry, 0, len(vars))
+	for i, v := range vars {
+		if v.GetKey() == "" {
+			return nil, apperr.Invalid(fmt.Sprintf("variables[%d].key", i), "variable key cannot be empty")
+		}
+		entries = append(entries, secrets.Entry{Key: v.GetKey(), Value: v.GetValue(), Secret: v.GetSecret()})
+	}
+	raw, err := json.Marshal(entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal variables: %w", err)
+	}
+	return datatypes.JSON(raw), nil
+}
+
+func ConvertProtoUpdateRequest(req *proto.UpdateRequestInCollectionRequest) *models.Request {
+	input := &models.Request{}
+	if req == nil {
+		return input
+	}
 
+	if req.Name != "" {
+		input.Name = req.Name
+	}
+	if req.Kind != proto.RequestKind(0) {
+		input.Kind = models.RequestKind(req.Kind.String())
+	}
+	if req.HttpMethod != "" {
+		input.HTTPMethod = &req.HttpMethod
+	}
+	if req.HttpUrl != "" {
+		input.HTTPURL = &req.HttpUrl
+	}
+	if req.HttpHeaders != "" {
+		input.HTTPHeaders = datatypes.JSON([]byte(req.HttpHeaders))
+	}
+	if req.HttpQueryParams != "" {
+		input.HTTPQueryParams = datatypes.JSON([]byte(req.HttpQueryParams))
+	}
+	if req.HttpBody != "" {
+		input.HTTPBody = &req.HttpBody
+	}
+	if req.GraphqlEndpoint != "" {
+		input.GraphQLEndpoint = &req.GraphqlEndpoint
+	}
+	if req.GraphqlQuery != "" {
+		input.GraphQLQuery = &req.GraphqlQuery
+	}
+	if req.GraphqlVariables != "" {
+		input.GraphQLVariables = datatypes.JSON([]byte(req.GraphqlVariables))
+	}
+	if req.GraphqlHeaders != "" {
+		input.GraphQLHeaders = datatypes.JSON([]byte(req.GraphqlHeaders))
+	}
+	if req.PreRequestScript != "" {
+		input.PreRequestScript = &req.PreRequestScript
+	}
+	if req.TestScript != "" {
+		input.TestScript = &req.TestScript
+	}
+
+	return input
+}
 
+func ConvertMergeConflictToProto(c merge.Conflict) *proto.MergeConflict {
+	pc := &proto.MergeConflict{
+		Key:             c.Key,
+		SourceRequestId: c.Source.ID,
+		TargetRequestId: c.Target.ID,
+	}
+	for _, d := range c.Diffs {
+		pc.Diffs = append(pc.Diffs, &proto.MergeFieldDiff{
+			Field:       d.Field,
+			SourceValue: secrets.RedactField(d.Field, d.Source),
+			TargetValue: secrets.RedactField(d.Field, d.Target),
+		})
+	}
+	return pc
+}
 
+// CheckScripts rejects a pre-request or test script that does not compile.
+func CheckScripts(preRequest, test string) error {
+	if err := scripting.Check(preRequest); err != nil {
+		return apperr.Invalid("pre_request_script", "pre-request script: %v", err)
+	}
+	if err := scripting.Check(test); err != nil {
+		return apperr.Invalid("test_script", "test script: %v", err)
+	}
+	return nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	protobuf "google.golang.org/protobuf/proto"
+	"gorm.io/datatypes"
+)
+
+// FuzzConvertModelCollectionToProto reads stored collections whose optional
+// columns are missing or hold malformed JSON.
+func FuzzConvertModelCollectionToProto(f *testing.F) {
+	f.Add("HTTP", "GET", "https://example.com", "", "", uint8(0xff), []byte(`[{"key":"Accept","value":"*/*"}]`), []byte(`{"type":"bearer","token":"t"}`))
+	f.Add("HTTP", "", "", "", "", uint8(0), []byte(nil), []byte(nil))
+	f.Add("GRAPHQL", "", "", "https://example.com/graphql", "{ me { id } }", uint8(0x0c), []byte(`{`), []byte(`[]`))
+	f.Add("UNKNOWN", "PATCH", "x", "y", "z", uint8(0x55), []byte(`null`), []byte(`"s"`))
+
+	f.Fuzz(func(t *testing.T, kind, method, url, endpoint, query string, set uint8, headers, auth []byte) {
+		opt := func(bit uint8, s string) *string {
+			if set&bit == 0 {
+				return nil
+			}
+			return &s
+		}
+		col := &models.Collection{
+			ID:          "c",
+			Description: opt(0x10, "description"),
+			Variables:   datatypes.JSON(headers),
+			Auth:        datatypes.JSON(auth),
+			Requests: []models.Request{{
+				ID:              "r",
+				Kind:            models.RequestKind(kind),
+				HTTPMethod:      opt(0x01, method),
+				HTTPURL:         opt(0x02, url),
+				GraphQLEndpoint: opt(0x04, endpoint),
+				GraphQLQuery:    opt(0x08, query),
+				HTTPHeaders:     datatypes.JSON(headers),
+				GraphQLHeaders:  datatypes.JSON(headers),
+				Auth:            datatypes.JSON(auth),
+				Assertions:      datatypes.JSON(headers),
+				Captures:        datatypes.JSON(auth),
+			}},
+		}
+		if ConvertModelCollectionToProto(col) == nil {
+			t.Fatal("collection converted to nil")
+		}
+	})
+}
+
+// FuzzConvertProtoRequests converts arbitrary wire-format request inputs,
+// including ones with missing payloads.
+func FuzzConvertProtoRequests(f *testing.F) {
+	for _, in := range []*proto.CollectionRequestInput{
+		{},
+		{Kind: proto.RequestKind_HTTP, Name: "no payload"},
+		{Kind: proto.RequestKind_GRAPHQL, Name: "no payload"},
+		{Kind: proto.RequestKind_HTTP, Name: "get", Http: &proto.HTTPRequestInput{Method: proto.HTTPMethod_GET, Url: "https://example.com", Headers: []*proto.HeaderInput{{Key: "Accept", Value: "*/*"}}}},
+		{Kind: proto.RequestKind_GRAPHQL, Name: "query", Graphql: &proto.GraphQLRequestInput{Endpoint: "https://example.com/graphql", Query: "{ me { id } }"}},
+		{Kind: proto.RequestKind_HTTP, Auth: &proto.AuthConfig{Type: proto.AuthType_AUTH_TYPE_BASIC, Username: "u"}, Assertions: []*proto.Assertion{{}}, Captures: []*proto.Capture{{}}},
+	} {
+		f.Add(marshal(f, in))
+	}
+	f.Add(protowire.AppendVarint(protowire.AppendTag(nil, 1, protowire.VarintType), 99))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		in := &proto.CollectionRequestInput{}
+		if err := protobuf.Unmarshal(data, in); err != nil {
+			return
+		}
+		reqs, err := ConvertProtoRequests([]*proto.CollectionRequestInput{in, nil}, "c")
+		if err != nil {
+			return
+		}
+		if len(reqs) != 1 {
+			t.Fatalf("got %d requests, want 1", len(reqs))
+		}
+		ConvertModelCollectionToProto(&models.Collection{Requests: reqs})
+	})
+}
+
+// FuzzConvertProtoUpdateRequest converts arbitrary partial request updates.
+func FuzzConvertProtoUpdateRequest(f *testing.F) {
+	for _, in := range []*proto.UpdateRequestInCollectionRequest{
+		{},
+		{Kind: proto.RequestKind_HTTP, HttpMethod: "POST", HttpHeaders: `[{"key":"a"}]`, HttpBody: "{}"},
+		{Kind: proto.RequestKind_GRAPHQL, GraphqlQuery: "{ me }", GraphqlVariables: `{"id": 1}`, GraphqlHeaders: "not json"},
+	} {
+		f.Add(marshal(f, in))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		in := &proto.UpdateRequestInCollectionRequest{}
+		if err := protobuf.Unmarshal(data, in); err != nil {
+			return
+		}
+		r := ConvertProtoUpdateRequest(in)
+		if r == nil {
+			t.Fatal("update converted to nil")
+		}
+		r.Auth, _ = ConvertProtoAuth(in.GetAuth())
+		r.Assertions, _ = ConvertProtoAssertions(in.GetAssertions())
+		r.Captures, _ = ConvertProtoCaptures(in.GetCaptures())
+		ConvertModelCollectionToProto(&models.Collection{Requests: []models.Request{*r}})
+	})
+}
+
+func marshal(f *testing.F, m protobuf.Message) []byte {
+	b, err := protobuf.Marshal(m)
+	if err != nil {
+		f.Fatal(err)
+	}
+	return b
+}
+
+func TestConvertNil(t *testing.T) {
+	if ConvertModelCollectionToProto(nil) != nil {
+		t.Error("ConvertModelCollectionToProto(nil) is not nil")
+	}
+	if r := ConvertProtoUpdateRequest(nil); r == nil {
+		t.Error("ConvertProtoUpdateRequest(nil) is nil")
+	}
+	if reqs, err := ConvertProtoRequests([]*proto.CollectionRequestInput{nil}, "c"); err != nil || len(reqs) != 0 {
+		t.Errorf("ConvertProtoRequests(nil item) = %v, %v", reqs, err)
+	}
+
+	// An HTTP request stored without its payload reads as an empty GET.
+	pCol := ConvertModelCollectionToProto(&models.Collection{Requests: []models.Request{{Kind: models.RequestKindHTTP}}})
+	http := pCol.GetRequests()[0].GetHttpRequest()
+	if http.GetMethod() != proto.HTTPMethod_GET || http.GetUrl() != "" {
+		t.Errorf("got method %v and url %q, want GET and no url", http.GetMethod(), http.GetUrl())
+	}
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"encoding/json"
+	"strconv"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var changeKinds = map[string]struct {
+	target proto.ChangeTarget
+	typ    proto.ChangeType
+}{
+	models.EventCollectionCreated: {proto.ChangeTarget_CHANGE_TARGET_COLLECTION, proto.ChangeType_CHANGE_TYPE_CREATED},
+	models.EventCollectionUpdated: {proto.ChangeTarget_CHANGE_TARGET_COLLECTION, proto.ChangeType_CHANGE_TYPE_UPDATED},
+	models.EventCollectionDeleted: {proto.ChangeTarget_CHANGE_TARGET_COLLECTION, proto.ChangeType_CHANGE_TYPE_DELETED},
+	models.EventRequestAdded:      {proto.ChangeTarget_CHANGE_TARGET_REQUEST, proto.ChangeType_CHANGE_TYPE_CREATED},
+	models.EventRequestUpdated:    {proto.ChangeTarget_CHANGE_TARGET_REQUEST, proto.ChangeType_CHANGE_TYPE_UPDATED},
+	models.EventRequestRemoved:    {proto.ChangeTarget_CHANGE_TARGET_REQUEST, proto.ChangeType_CHANGE_TYPE_DELETED},
+}
+
+// ConvertChangeToProto converts a published outbox event; its position is
+// the cursor.
+func ConvertChangeToProto(e *models.OutboxEvent) *proto.CollectionChange {
+	if e == nil {
+		return nil
+	}
+	kind := changeKinds[e.Event]
+	change := &proto.CollectionChange{
+		EventId:      e.ID,
+		Type:         kind.typ,
+		Target:       kind.target,
+		WorkspaceId:  e.WorkspaceID,
+		CollectionId: e.CollectionID,
+		Actor:        e.Actor,
+		OccurredAt:   timestamppb.New(e.CreatedAt),
+	}
+	if e.Position != nil {
+		change.Cursor = strconv.FormatInt(*e.Position, 10)
+	}
+	if kind.target == proto.ChangeTarget_CHANGE_TARGET_REQUEST {
+		var data struct {
+			RequestIDs []string `json:"request_ids"`
+		}
+		_ = json.Unmarshal(e.Data, &data)
+		change.RequestIds = data.RequestIDs
+	}
+	return change
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"collectionsservice/internal/models"
+	proto "collectionsservice/internal/proto"
+	"encoding/json"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var deliveryStatuses = map[proto.DeliveryStatus]models.DeliveryStatus{
+	proto.DeliveryStatus_DELIVERY_STATUS_PENDING:   models.DeliveryPending,
+	proto.DeliveryStatus_DELIVERY_STATUS_SUCCEEDED: models.DeliverySucceeded,
+	proto.DeliveryStatus_DELIVERY_STATUS_FAILED:    models.DeliveryFailed,
+}
+
+// ConvertProtoDeliveryStatus maps a status filter; UNSPECIFIED matches any
+// delivery.
+func ConvertProtoDeliveryStatus(s proto.DeliveryStatus) models.DeliveryStatus {
+	return deliveryStatuses[s]
+}
+
+func protoDeliveryStatus(s models.DeliveryStatus) proto.DeliveryStatus {
+	for p, m := range deliveryStatuses {
+		if m == s {
+			return p
+		}
+	}
+	return proto.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED
+}
+
+// ConvertWebhookToProto leaves out the secret, which only CreateWebhook
+// returns.
+func ConvertWebhookToProto(w *models.Webhook) *proto.WebhookResponse {
+	if w == nil {
+		return nil
+	}
+	var events []string
+	if len(w.Events) > 0 {
+		_ = json.Unmarshal(w.Events, &events)
+	}
+	return &proto.WebhookResponse{
+		Id:           w.ID,
+		Url:          w.URL,
+		Events:       events,
+		CollectionId: deref(w.CollectionID),
+		Enabled:      w.Enabled,
+		CreatedBy:    w.CreatedBy,
+		CreatedAt:    timestamppb.New(w.CreatedAt),
+		UpdatedAt:    timestamppb.New(w.UpdatedAt),
+	}
+}
+
+func ConvertDeliveryToProto(d *models.WebhookDelivery) *proto.WebhookDelivery {
+	if d == nil {
+		return nil
+	}
+	resp := &proto.WebhookDelivery{
+		Id:           d.ID,
+		WebhookId:    d.WebhookID,
+		EventId:      d.EventID,
+		Event:        d.Event,
+		Payload:      string(d.Payload),
+		Status:       protoDeliveryStatus(d.Status),
+		AttemptCount: int32(d.AttemptCount),
+		CreatedAt:    timestamppb.New(d.CreatedAt),
+	}
+	if d.Status == models.DeliveryPending && d.NextAttemptAt != nil {
+		resp.NextAttemptAt = timestamppb.New(*d.NextAttemptAt)
+	}
+	if d.DeliveredAt != nil {
+		resp.DeliveredAt = timestamppb.New(*d.DeliveredAt)
+	}
+	for _, a := range d.Attempts {
+		resp.Attempts = append(resp.Attempts, &proto.WebhookAttempt{
+			Number:       int32(a.Number),
+			StatusCode:   int32(a.StatusCode),
+			Error:        deref(a.Error),
+			ResponseBody: a.ResponseBody,
+			DurationMs:   a.DurationMs,
+			CreatedAt:    timestamppb.New(a.CreatedAt),
+		})
+	}
+	return resp
+}
@@ -0,0 +1,437 @@
+package validate
+
+import (
+	proto "collectionsservice/internal/proto"
+	"collectionsservice/internal/utils"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Request checks an RPC request message. It returns an INVALID_ARGUMENT
+// error listing every invalid field, or nil. Messages without rules pass.
+func Request(req interface{}) error {
+	v := &validator{}
+	batch := false
+
+	switch r := req.(type) {
+	case *proto.CreateCollectionRequest:
+		v.requiredName("name", r.GetName())
+		v.text("description", r.GetDescription(), maxTextLength)
+		v.collectionSettings(r.GetVariables(), r.GetAuth(), r.GetPreRequestScript(), r.GetTestScript())
+	case *proto.UpdateCollectionRequest:
+		v.requiredID("id", r.GetId())
+		v.name("name", r.GetName())
+		v.text("description", r.GetDescription(), maxTextLength)
+		v.collectionSettings(r.GetVariables(), r.GetAuth(), r.GetPreRequestScript(), r.GetTestScript())
+	case *proto.DeleteCollectionRequest:
+		v.requiredID("id", r.GetId())
+	case *proto.SetCollectionProtectedRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+
+	case *proto.AddRequestToCollectionRequest:
+		v.requiredName("collection_name", r.GetCollectionName())
+		v.requestInput("request", r.GetRequest())
+	case *proto.AddRequestToCollectionByIDRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.requestInput("request", r.GetRequest())
+	case *proto.UpdateRequestInCollectionRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.requestUpdate("", r)
+	case *proto.DeleteRequestFromCollectionRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.requiredID("request_id", r.GetRequestId())
+
+	case *proto.BatchAddRequestsToCollectionRequest:
+		batch = true
+		if r.GetCollectionId() == "" && r.GetCollectionName() == "" {
+			v.add("collection_id", "collection id or name is required")
+		}
+		v.id("collection_id", r.GetCollectionId())
+		v.name("collection_name", r.GetCollectionName())
+		v.batchSize("requests", len(r.GetRequests()))
+		for i, in := range r.GetRequests() {
+			v.requestInput(index("requests", i), in)
+		}
+	case *proto.BatchUpdateRequestsInCollectionRequest:
+		batch = true
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.batchSize("requests", len(r.GetRequests()))
+		seen := make(map[string]bool)
+		for i, in := range r.GetRequests() {
+			field := index("requests", i)
+			if in == nil {
+				v.add(field, "request cannot be empty")
+				continue
+			}
+			if in.GetCollectionId() != "" && in.GetCollectionId() != r.GetCollectionId() {
+				v.add(join(field, "collection_id"), "collection id does not match the batch collection")
+			}
+			if in.GetRequestId() != "" && seen[in.GetRequestId()] {
+				v.add(join(field, "request_id"), "duplicate request id in batch")
+			}
+			seen[in.GetRequestId()] = true
+			v.requestUpdate(field, in)
+		}
+	case *proto.BatchDeleteRequestsFromCollectionRequest:
+		batch = true
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.batchSize("request_ids", len(r.GetRequestIds()))
+		seen := make(map[string]bool)
+		for i, id := range r.GetRequestIds() {
+			field := index("request_ids", i)
+			if seen[id] {
+				v.add(field, "duplicate request id in batch")
+			} else {
+				v.requiredID(field, id)
+			}
+			seen[id] = true
+		}
+
+	case *proto.PreviewMergeCollectionsRequest:
+		v.requiredID("source_collection_id", r.GetSourceCollectionId())
+		v.requiredID("target_collection_id", r.GetTargetCollectionId())
+		v.enum("match_by", r.GetMatchBy())
+	case *proto.MergeCollectionsRequest:
+		v.requiredID("source_collection_id", r.GetSourceCollectionId())
+		v.requiredID("target_collection_id", r.GetTargetCollectionId())
+		v.enum("match_by", r.GetMatchBy())
+		v.enum("default_resolution", r.GetDefaultResolution())
+		for i, res := range r.GetResolutions() {
+			field := index("resolutions", i)
+			if res.GetKey() == "" {
+				v.add(join(field, "key"), "key cannot be empty")
+			}
+			v.enum(join(field, "resolution"), res.GetResolution())
+		}
+	case *proto.ForkCollectionRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.name("name", r.GetName())
+		v.name("author", r.GetAuthor())
+
+	case *proto.OpenChangeProposalRequest:
+		v.requiredID("fork_collection_id", r.GetForkCollectionId())
+		v.name("title", r.GetTitle())
+		v.text("description", r.GetDescription(), maxTextLength)
+		v.name("author", r.GetAuthor())
+	case *proto.GetChangeProposalRequest:
+		v.requiredID("id", r.GetId())
+	case *proto.ListChangeProposalsRequest:
+		v.id("upstream_collection_id", r.GetUpstreamCollectionId())
+		v.enum("status", r.GetStatus())
+	case *proto.CommentOnChangeProposalRequest:
+		v.requiredID("proposal_id", r.GetProposalId())
+		v.name("author", r.GetAuthor())
+		v.text("body", r.GetBody(), maxTextLength)
+	case *proto.ReviewChangeProposalRequest:
+		v.requiredID("proposal_id", r.GetProposalId())
+		v.name("reviewer", r.GetReviewer())
+		v.enum("decision", r.GetDecision())
+		v.text("comment", r.GetComment(), maxTextLength)
+
+	case *proto.CreateWorkspaceRequest:
+		v.requiredName("name", r.GetName())
+		v.text("description", r.GetDescription(), maxTextLength)
+	case *proto.GetWorkspaceRequest:
+		v.requiredID("id", r.GetId())
+	case *proto.UpdateWorkspaceRequest:
+		v.requiredID("id", r.GetId())
+		v.name("name", r.GetName())
+		v.text("description", r.GetDescription(), maxTextLength)
+	case *proto.DeleteWorkspaceRequest:
+		v.requiredID("id", r.GetId())
+	case *proto.GrantWorkspaceRoleRequest:
+		v.requiredID("workspace_id", r.GetWorkspaceId())
+		v.name("subject", r.GetSubject())
+	case *proto.RevokeWorkspaceRoleRequest:
+		v.requiredID("workspace_id", r.GetWorkspaceId())
+		v.name("subject", r.GetSubject())
+	case *proto.ListWorkspaceMembersRequest:
+		v.requiredID("workspace_id", r.GetWorkspaceId())
+	case *proto.GrantCollectionRoleRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.name("subject", r.GetSubject())
+	case *proto.RevokeCollectionRoleRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.name("subject", r.GetSubject())
+	case *proto.ListCollectionMembersRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+
+	case *proto.ShareCollectionRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.enum("access", r.GetAccess())
+	case *proto.ListCollectionSharesRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+	case *proto.RevokeCollectionShareRequest:
+		v.requiredID("id", r.GetId())
+	case *proto.GetSharedCollectionRequest:
+		if r.GetToken() == "" {
+			v.add("token", "token is required")
+		}
+
+	case *proto.ExecuteRequestRequest:
+		if r.GetShareToken() == "" {
+			v.requiredID("collection_id", r.GetCollectionId())
+		} else {
+			v.id("collection_id", r.GetCollectionId())
+		}
+		v.requiredID("request_id", r.GetRequestId())
+		v.variableKeys("variables", r.GetVariables())
+		v.name("save_as_example", r.GetSaveAsExample())
+	case *proto.RunCollectionRequest:
+		if r.GetShareToken() == "" {
+			v.requiredID("collection_id", r.GetCollectionId())
+		} else {
+			v.id("collection_id", r.GetCollectionId())
+		}
+		v.variableKeys("variables", r.GetVariables())
+
+	case *proto.CreateExampleRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.requiredID("request_id", r.GetRequestId())
+		v.name("name", r.GetName())
+	case *proto.GetExampleRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.requiredID("id", r.GetId())
+	case *proto.ListExamplesRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.id("request_id", r.GetRequestId())
+	case *proto.UpdateExampleRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.requiredID("id", r.GetId())
+		v.name("name", r.GetName())
+	case *proto.DeleteExampleRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.requiredID("id", r.GetId())
+
+	case *proto.ListRunHistoryRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.id("request_id", r.GetRequestId())
+		v.id("collection_run_id", r.GetCollectionRunId())
+		v.enum("status", r.GetStatus())
+		if min, max := r.GetStatusCodeMin(), r.GetStatusCodeMax(); min != 0 && max != 0 && min > max {
+			v.add("status_code_min", "status_code_min %d is above status_code_max %d", min, max)
+		}
+		v.timeRange("", r.GetSince(), r.GetUntil())
+	case *proto.GetRunRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.requiredID("id", r.GetId())
+
+	case *proto.CreateMonitorRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.name("name", r.GetName())
+	case *proto.GetMonitorRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.requiredID("id", r.GetId())
+	case *proto.ListMonitorsRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+	case *proto.UpdateMonitorRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.requiredID("id", r.GetId())
+		v.name("name", r.GetName())
+	case *proto.SetMonitorEnabledRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.requiredID("id", r.GetId())
+	case *proto.DeleteMonitorRequest:
+		v.requiredID("collection_id", r.GetCollectionId())
+		v.requiredID("id", r.GetId())
+
+	case *proto.CreateWebhookRequest:
+		v.id("collection_id", r.GetCollectionId())
+	case *proto.UpdateWebhookRequest:
+		v.requiredID("id", r.GetId())
+	case *proto.SetWebhookEnabledRequest:
+		v.requiredID("id", r.GetId())
+	case *proto.DeleteWebhookRequest:
+		v.requiredID("id", r.GetId())
+	case *proto.ListWebhookDeliveriesRequest:
+		v.requiredID("webhook_id", r.GetWebhookId())
+		v.enum("status", r.GetStatus())
+
+	case *proto.WatchCollectionsRequest:
+		v.id("collection_id", r.GetCollectionId())
+
+	case *proto.CreateAPIKeyRequest:
+		v.name("name", r.GetName())
+		v.name("subject", r.GetSubject())
+	case *proto.RevokeAPIKeyRequest:
+		v.requiredID("id", r.GetId())
+
+	case *proto.ListAuditEventsRequest:
+		v.auditFilter(r.GetFilter())
+	case *proto.ExportAuditEventsRequest:
+		v.auditFilter(r.GetFilter())
+		v.enum("format", r.GetFormat())
+	}
+
+	err := v.err()
+	if err == nil {
+		return nil
+	}
+	if batch {
+		err.Message = fmt.Sprintf("batch rejected: %d validation errors, nothing was applied", len(err.Violations))
+	}
+	return err
+}
+
+func (v *validator) collectionSettings(vars []*proto.VariableInput, auth *proto.AuthConfig, preRequest, test string) {
+	_, err := utils.ConvertProtoVariables(vars)
+	v.merge("", err)
+	_, err = utils.ConvertProtoAuth(auth)
+	v.merge("", err)
+	v.merge("", utils.CheckScripts(preRequest, test))
+}
+
+func (v *validator) requestInput(field string, in *proto.CollectionRequestInput) {
+	if in == nil {
+		v.add(field, "request cannot be empty")
+		return
+	}
+	v.requiredName(join(field, "name"), in.GetName())
+	v.enum(join(field, "kind"), in.GetKind())
+
+	switch in.GetKind() {
+	case proto.RequestKind_HTTP:
+		if in.GetGraphql() != nil {
+			v.add(join(field, "graphql"), "an HTTP request cannot have a graphql payload")
+		}
+		http := in.GetHttp()
+		if http == nil {
+			v.add(join(field, "http"), "an HTTP request requires an http payload")
+			break
+		}
+		if http.GetMethod() == proto.HTTPMethod_HTTP_METHOD_UNSPECIFIED {
+			v.add(join(field, "http.method"), "method is required")
+		} else {
+			v.enum(join(field, "http.method"), http.GetMethod())
+		}
+		v.url(join(field, "http.url"), http.GetUrl())
+		for i, h := range http.GetHeaders() {
+			if h.GetKey() == "" {
+				v.add(join(field, index("http.headers", i)+".key"), "key cannot be empty")
+			}
+		}
+		for i, q := range http.GetQueryParams() {
+			if q.GetKey() == "" {
+				v.add(join(field, index("http.query_params", i)+".key"), "key cannot be empty")
+			}
+		}
+	case proto.RequestKind_GRAPHQL:
+		if in.GetHttp() != nil {
+			v.add(join(field, "http"), "a GraphQL request cannot have an http payload")
+		}
+		gql := in.GetGraphql()
+		if gql == nil {
+			v.add(join(field, "graphql"), "a GraphQL request requires a graphql payload")
+			break
+		}
+		v.url(join(field, "graphql.endpoint"), gql.GetEndpoint())
+		v.graphQLQuery(join(field, "graphql.query"), gql.GetQuery())
+		for i, h := range gql.GetHeaders() {
+			if h.GetKey() == "" {
+				v.add(join(field, index("graphql.headers", i)+".key"), "key cannot be empty")
+			}
+		}
+	case proto.RequestKind_REQUEST_KIND_UNSPECIFIED:
+		v.add(join(field, "kind"), "request kind must be HTTP or GRAPHQL")
+	}
+
+	v.requestSettings(field, in.GetAuth(), in.GetPreRequestScript(), in.GetTestScript(), in.GetAssertions(), in.GetCaptures())
+}
+
+// requestUpdate checks a partial request update. Only the fields that are
+// set are checked, and a kind that is set must match the fields sent.
+func (v *validator) requestUpdate(field string, in *proto.UpdateRequestInCollectionRequest) {
+	v.requiredID(join(field, "request_id"), in.GetRequestId())
+	v.name(join(field, "name"), in.GetName())
+	v.enum(join(field, "kind"), in.GetKind())
+
+	httpFields := []setField{
+		{"http_method", in.GetHttpMethod()},
+		{"http_url", in.GetHttpUrl()},
+		{"http_headers", in.GetHttpHeaders()},
+		{"http_query_params", in.GetHttpQueryParams()},
+		{"http_body", in.GetHttpBody()},
+	}
+	graphQLFields := []setField{
+		{"graphql_endpoint", in.GetGraphqlEndpoint()},
+		{"graphql_query", in.GetGraphqlQuery()},
+		{"graphql_variables", in.GetGraphqlVariables()},
+		{"graphql_headers", in.GetGraphqlHeaders()},
+	}
+	switch in.GetKind() {
+	case proto.RequestKind_HTTP:
+		v.unexpected(field, graphQLFields, "an HTTP request")
+	case proto.RequestKind_GRAPHQL:
+		v.unexpected(field, httpFields, "a GraphQL request")
+	case proto.RequestKind_REQUEST_KIND_UNSPECIFIED:
+		if anySet(httpFields) && anySet(graphQLFields) {
+			v.add(join(field, "kind"), "HTTP and GraphQL fields cannot be updated together")
+		}
+	}
+
+	if m := in.GetHttpMethod(); m != "" {
+		if n, ok := proto.HTTPMethod_value[m]; !ok || n == int32(proto.HTTPMethod_HTTP_METHOD_UNSPECIFIED) {
+			v.add(join(field, "http_method"), "unknown HTTP method %q", m)
+		}
+	}
+	if in.GetHttpUrl() != "" {
+		v.url(join(field, "http_url"), in.GetHttpUrl())
+	}
+	v.entries(join(field, "http_headers"), in.GetHttpHeaders())
+	v.entries(join(field, "http_query_params"), in.GetHttpQueryParams())
+	if in.GetGraphqlEndpoint() != "" {
+		v.url(join(field, "graphql_endpoint"), in.GetGraphqlEndpoint())
+	}
+	if in.GetGraphqlQuery() != "" {
+		v.graphQLQuery(join(field, "graphql_query"), in.GetGraphqlQuery())
+	}
+	v.jsonObject(join(field, "graphql_variables"), in.GetGraphqlVariables())
+	v.entries(join(field, "graphql_headers"), in.GetGraphqlHeaders())
+
+	v.requestSettings(field, in.GetAuth(), in.GetPreRequestScript(), in.GetTestScript(), in.GetAssertions(), in.GetCaptures())
+}
+
+func (v *validator) requestSettings(field string, auth *proto.AuthConfig, preRequest, test string, assertions []*proto.Assertion, captures []*proto.Capture) {
+	_, err := utils.ConvertProtoAuth(auth)
+	v.merge(field, err)
+	v.merge(field, utils.CheckScripts(preRequest, test))
+	_, err = utils.ConvertProtoAssertions(assertions)
+	v.merge(field, err)
+	_, err = utils.ConvertProtoCaptures(captures)
+	v.merge(field, err)
+}
+
+// unexpected reports the fields that do not belong to the request kind.
+func (v *validator) unexpected(field string, fields []setField, kind string) {
+	for _, f := range fields {
+		if f.value != "" {
+			v.add(join(field, f.name), "not allowed for %s", kind)
+		}
+	}
+}
+
+func (v *validator) auditFilter(f *proto.AuditEventFilter) {
+	v.id("filter.collection_id", f.GetCollectionId())
+	v.enum("filter.outcome", f.GetOutcome())
+	v.timeRange("filter.", f.GetSince(), f.GetUntil())
+}
+
+func (v *validator) timeRange(prefix string, since, until *timestamppb.Timestamp) {
+	if since != nil && until != nil && since.AsTime().After(until.AsTime()) {
+		v.add(prefix+"since", "since must not be after until")
+	}
+}
+
+type setField struct {
+	name, value string
+}
+
+func anySet(fields []setField) bool {
+	for _, f := range fields {
+		if f.value != "" {
+			return true
+		}
+	}
+	return false
+}
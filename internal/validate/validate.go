@@ -0,0 +1,204 @@
+// Package validate checks RPC request messages before they reach a handler.
+// Every problem is reported as a field violation, so a client learns about
+// all of them from one INVALID_ARGUMENT error.
+package validate
+
+import (
+	"collectionsservice/internal/apperr"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	maxNameLength = 255
+	maxTextLength = 10000
+	maxURLLength  = 2048
+	maxBatchSize  = 500
+)
+
+// placeholder matches the {{name}} variables executor.Interpolate resolves
+// when a request runs.
+var placeholder = regexp.MustCompile(`\{\{\s*[\w.\-]+\s*\}\}`)
+
+type validator struct {
+	violations []apperr.Violation
+}
+
+func (v *validator) add(field, format string, args ...interface{}) {
+	v.violations = append(v.violations, apperr.Violation{Field: field, Description: fmt.Sprintf(format, args...)})
+}
+
+// merge adds the violations of an error returned by a converter, with their
+// fields placed under prefix.
+func (v *validator) merge(prefix string, err error) {
+	if err == nil {
+		return
+	}
+	e, ok := apperr.As(err)
+	if !ok || len(e.Violations) == 0 {
+		v.add(prefix, "%v", err)
+		return
+	}
+	for _, viol := range e.Violations {
+		v.violations = append(v.violations, apperr.Violation{Field: join(prefix, viol.Field), Description: viol.Description})
+	}
+}
+
+func (v *validator) err() *apperr.Error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return apperr.InvalidFields(v.violations...)
+}
+
+func (v *validator) requiredID(field, id string) {
+	if id == "" {
+		v.add(field, "%s is required", field)
+		return
+	}
+	v.id(field, id)
+}
+
+// id checks an optional ID. Every resource ID is a UUID in its canonical
+// 36 character form.
+func (v *validator) id(field, id string) {
+	if id == "" {
+		return
+	}
+	if _, err := uuid.Parse(id); err != nil || len(id) != 36 {
+		v.add(field, "%q is not a valid id", id)
+	}
+}
+
+func (v *validator) requiredName(field, name string) {
+	if strings.TrimSpace(name) == "" {
+		v.add(field, "%s cannot be empty", field)
+		return
+	}
+	v.name(field, name)
+}
+
+func (v *validator) name(field, name string) {
+	v.text(field, name, maxNameLength)
+}
+
+func (v *validator) text(field, s string, max int) {
+	if n := utf8.RuneCountInString(s); n > max {
+		v.add(field, "must be at most %d characters, got %d", max, n)
+	}
+}
+
+// url checks a request URL or GraphQL endpoint. {{name}} variables are
+// allowed anywhere; a URL that does not start with one must be an absolute
+// http or https URL.
+func (v *validator) url(field, raw string) {
+	if raw == "" {
+		v.add(field, "%s cannot be empty", field)
+		return
+	}
+	if len(raw) > maxURLLength {
+		v.add(field, "must be at most %d bytes", maxURLLength)
+		return
+	}
+	templated := strings.HasPrefix(strings.TrimSpace(raw), "{{")
+	u, err := url.Parse(placeholder.ReplaceAllString(raw, "x"))
+	switch {
+	case err != nil:
+		v.add(field, "invalid URL: %v", err)
+	case templated:
+	case u.Scheme != "http" && u.Scheme != "https":
+		v.add(field, "must be an http or https URL")
+	case u.Host == "":
+		v.add(field, "URL has no host")
+	}
+}
+
+// graphQLQuery checks that a query parses. Variables are replaced by a
+// name first, which is valid both where a value and where a name belongs.
+func (v *validator) graphQLQuery(field, query string) {
+	if strings.TrimSpace(query) == "" {
+		v.add(field, "%s cannot be empty", field)
+		return
+	}
+	src := &ast.Source{Input: placeholder.ReplaceAllString(query, "_")}
+	if _, err := parser.ParseQuery(src); err != nil {
+		v.add(field, "invalid GraphQL query: %v", err)
+	}
+}
+
+// entries checks a JSON encoded list of headers or query parameters, the
+// shape they are stored in.
+func (v *validator) entries(field, raw string) {
+	if raw == "" {
+		return
+	}
+	var list []struct {
+		Key string `json:"key"`
+	}
+	if err := json.Unmarshal([]byte(raw), &list); err != nil {
+		v.add(field, `must be a JSON array of {"key", "value"} objects`)
+		return
+	}
+	for i, e := range list {
+		if e.Key == "" {
+			v.add(fmt.Sprintf("%s[%d].key", field, i), "key cannot be empty")
+		}
+	}
+}
+
+func (v *validator) jsonObject(field, raw string) {
+	if raw == "" {
+		return
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(placeholder.ReplaceAllString(raw, "null")), &obj); err != nil {
+		v.add(field, "must be a JSON object")
+	}
+}
+
+// enum rejects numbers that are not a value of the enum, which proto3 lets
+// through.
+func (v *validator) enum(field string, e protoreflect.Enum) {
+	if e.Descriptor().Values().ByNumber(e.Number()) == nil {
+		v.add(field, "unknown value %d", e.Number())
+	}
+}
+
+func (v *validator) batchSize(field string, n int) {
+	if n == 0 {
+		v.add(field, "batch cannot be empty")
+	} else if n > maxBatchSize {
+		v.add(field, "batch size %d exceeds the limit of %d", n, maxBatchSize)
+	}
+}
+
+func (v *validator) variableKeys(field string, vars map[string]string) {
+	for k := range vars {
+		if k == "" {
+			v.add(field, "variable name cannot be empty")
+		}
+	}
+}
+
+func join(prefix, field string) string {
+	switch {
+	case prefix == "":
+		return field
+	case field == "":
+		return prefix
+	}
+	return prefix + "." + field
+}
+
+func index(field string, i int) string {
+	return fmt.Sprintf("%s[%d]", field, i)
+}
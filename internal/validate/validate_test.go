@@ -0,0 +1,253 @@
+package validate
+
+import (
+	"collectionsservice/internal/apperr"
+	proto "collectionsservice/internal/proto"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	collectionID = "0b5c3a59-6f0e-4b8e-9d3f-1a2b3c4d5e6f"
+	requestID    = "7d9e1f20-3a4b-4c5d-8e6f-7a8b9c0d1e2f"
+	otherID      = "1f2e3d4c-5b6a-4798-8a7b-6c5d4e3f2a1b"
+)
+
+func TestRequest(t *testing.T) {
+	httpGet := func(url string) *proto.CollectionRequestInput {
+		return &proto.CollectionRequestInput{
+			Kind: proto.RequestKind_HTTP,
+			Name: "get",
+			Http: &proto.HTTPRequestInput{Method: proto.HTTPMethod_GET, Url: url},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		req    interface{}
+		fields []string
+		// message, when set, must prefix the error message.
+		message string
+	}{
+		{name: "unchecked message", req: &proto.ListCollectionsRequest{}},
+		{name: "valid collection", req: &proto.CreateCollectionRequest{Name: "api", Description: "d"}},
+		{name: "blank collection name", req: &proto.CreateCollectionRequest{Name: "  "}, fields: []string{"name"}},
+		{name: "long collection name", req: &proto.CreateCollectionRequest{Name: strings.Repeat("é", maxNameLength+1)}, fields: []string{"name"}},
+		{
+			name:   "invalid collection id",
+			req:    &proto.UpdateCollectionRequest{Id: "not-a-uuid"},
+			fields: []string{"id"},
+		},
+		{
+			name:   "id not in canonical form",
+			req:    &proto.DeleteCollectionRequest{Id: "{" + collectionID + "}"},
+			fields: []string{"id"},
+		},
+
+		{name: "valid HTTP request", req: &proto.AddRequestToCollectionByIDRequest{CollectionId: collectionID, Request: httpGet("https://example.com/users")}},
+		{name: "templated URL", req: &proto.AddRequestToCollectionByIDRequest{CollectionId: collectionID, Request: httpGet("{{base_url}}/users/{{ id }}")}},
+		{name: "relative URL", req: &proto.AddRequestToCollectionByIDRequest{CollectionId: collectionID, Request: httpGet("/users")}, fields: []string{"request.http.url"}},
+		{name: "ftp URL", req: &proto.AddRequestToCollectionByIDRequest{CollectionId: collectionID, Request: httpGet("ftp://example.com")}, fields: []string{"request.http.url"}},
+		{name: "missing request", req: &proto.AddRequestToCollectionRequest{CollectionName: "api"}, fields: []string{"request"}},
+		{
+			name: "HTTP request without payload",
+			req: &proto.AddRequestToCollectionRequest{
+				CollectionName: "api",
+				Request:        &proto.CollectionRequestInput{Kind: proto.RequestKind_HTTP, Name: "x"},
+			},
+			fields: []string{"request.http"},
+		},
+		{
+			name: "every HTTP problem at once",
+			req: &proto.AddRequestToCollectionRequest{
+				Request: &proto.CollectionRequestInput{
+					Kind: proto.RequestKind_HTTP,
+					Http: &proto.HTTPRequestInput{
+						Headers:     []*proto.HeaderInput{{Key: "Accept"}, {Value: "v"}},
+						QueryParams: []*proto.QueryParamInput{{Value: "v"}},
+					},
+					Graphql: &proto.GraphQLRequestInput{},
+				},
+			},
+			fields: []string{
+				"collection_name",
+				"request.name",
+				"request.graphql",
+				"request.http.method",
+				"request.http.url",
+				"request.http.headers[1].key",
+				"request.http.query_params[0].key",
+			},
+		},
+		{
+			name: "GraphQL query with variables",
+			req: &proto.AddRequestToCollectionByIDRequest{
+				CollectionId: collectionID,
+				Request: &proto.CollectionRequestInput{
+					Kind:    proto.RequestKind_GRAPHQL,
+					Name:    "me",
+					Graphql: &proto.GraphQLRequestInput{Endpoint: "https://example.com/graphql", Query: "query { user(id: {{id}}) { {{field}} } }"},
+				},
+			},
+		},
+		{
+			name: "GraphQL query that does not parse",
+			req: &proto.AddRequestToCollectionByIDRequest{
+				CollectionId: collectionID,
+				Request: &proto.CollectionRequestInput{
+					Kind:    proto.RequestKind_GRAPHQL,
+					Name:    "me",
+					Graphql: &proto.GraphQLRequestInput{Endpoint: "https://example.com/graphql", Query: "{ me { id }"},
+				},
+			},
+			fields: []string{"request.graphql.query"},
+		},
+		{
+			name:   "unknown kind",
+			req:    &proto.AddRequestToCollectionByIDRequest{CollectionId: collectionID, Request: &proto.CollectionRequestInput{Kind: 7, Name: "x"}},
+			fields: []string{"request.kind"},
+		},
+
+		{
+			name: "valid partial update",
+			req:  &proto.UpdateRequestInCollectionRequest{CollectionId: collectionID, RequestId: requestID, Name: "renamed"},
+		},
+		{
+			name: "update mixing kinds",
+			req: &proto.UpdateRequestInCollectionRequest{
+				CollectionId: collectionID,
+				RequestId:    requestID,
+				HttpUrl:      "https://example.com",
+				GraphqlQuery: "{ me }",
+			},
+			fields: []string{"kind"},
+		},
+		{
+			name: "update with fields of another kind",
+			req: &proto.UpdateRequestInCollectionRequest{
+				CollectionId:    collectionID,
+				RequestId:       requestID,
+				Kind:            proto.RequestKind_HTTP,
+				GraphqlEndpoint: "https://example.com/graphql",
+			},
+			fields: []string{"graphql_endpoint"},
+		},
+		{
+			name: "update with malformed JSON fields",
+			req: &proto.UpdateRequestInCollectionRequest{
+				CollectionId:     collectionID,
+				RequestId:        requestID,
+				HttpMethod:       "FETCH",
+				HttpHeaders:      `[{"key":"a"},{"value":"b"}]`,
+				HttpQueryParams:  `{"key":"a"}`,
+				GraphqlVariables: `[1]`,
+			},
+			fields: []string{"kind", "http_method", "http_headers[1].key", "http_query_params", "graphql_variables"},
+		},
+		{
+			name: "templated GraphQL variables",
+			req: &proto.UpdateRequestInCollectionRequest{
+				CollectionId:     collectionID,
+				RequestId:        requestID,
+				GraphqlVariables: `{"id": {{user_id}}}`,
+			},
+		},
+
+		{
+			name:    "empty batch",
+			req:     &proto.BatchAddRequestsToCollectionRequest{CollectionId: collectionID},
+			fields:  []string{"requests"},
+			message: "batch rejected: 1 validation errors",
+		},
+		{
+			name: "batch update with duplicate and foreign items",
+			req: &proto.BatchUpdateRequestsInCollectionRequest{
+				CollectionId: collectionID,
+				Requests: []*proto.UpdateRequestInCollectionRequest{
+					{RequestId: requestID},
+					{RequestId: requestID, CollectionId: otherID},
+					nil,
+				},
+			},
+			fields:  []string{"requests[1].collection_id", "requests[1].request_id", "requests[2]"},
+			message: "batch rejected: 3 validation errors",
+		},
+		{
+			name: "batch delete",
+			req: &proto.BatchDeleteRequestsFromCollectionRequest{
+				CollectionId: collectionID,
+				RequestIds:   []string{requestID, requestID, "x", otherID},
+			},
+			fields: []string{"request_ids[1]", "request_ids[2]"},
+		},
+		{
+			name:   "batch without collection",
+			req:    &proto.BatchAddRequestsToCollectionRequest{Requests: []*proto.CollectionRequestInput{httpGet("https://example.com")}},
+			fields: []string{"collection_id"},
+		},
+		{
+			name: "oversized batch",
+			req: &proto.BatchAddRequestsToCollectionRequest{
+				CollectionId: collectionID,
+				Requests:     repeat(httpGet("https://example.com"), maxBatchSize+1),
+			},
+			fields: []string{"requests"},
+		},
+
+		{
+			name: "unknown enum values",
+			req: &proto.MergeCollectionsRequest{
+				SourceCollectionId: collectionID,
+				TargetCollectionId: otherID,
+				MatchBy:            42,
+				Resolutions:        []*proto.MergeConflictResolution{{Key: "GET /users", Resolution: 9}, {}},
+			},
+			fields: []string{"match_by", "resolutions[0].resolution", "resolutions[1].key"},
+		},
+		{
+			name:   "share token makes collection optional",
+			req:    &proto.ExecuteRequestRequest{ShareToken: "tok", RequestId: requestID},
+			fields: nil,
+		},
+		{
+			name:   "execute without share token",
+			req:    &proto.ExecuteRequestRequest{RequestId: requestID},
+			fields: []string{"collection_id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Request(tt.req)
+			if len(tt.fields) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			e, ok := apperr.As(err)
+			if !ok || e.Kind != apperr.KindInvalidArgument {
+				t.Fatalf("got %v, want an invalid argument error", err)
+			}
+			var fields []string
+			for _, v := range e.Violations {
+				fields = append(fields, v.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("violations on %v, want %v\n%s", fields, tt.fields, e.Message)
+			}
+			if tt.message != "" && !strings.HasPrefix(e.Message, tt.message) {
+				t.Errorf("message %q, want prefix %q", e.Message, tt.message)
+			}
+		})
+	}
+}
+
+func repeat(in *proto.CollectionRequestInput, n int) []*proto.CollectionRequestInput {
+	out := make([]*proto.CollectionRequestInput, n)
+	for i := range out {
+		out[i] = in
+	}
+	return out
+}
@@ -0,0 +1,175 @@
+package webhook
+
+import (
+	"bytes"
+	"collectionsservice/internal/models"
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// claimBatch caps how many deliveries one tick sends.
+	claimBatch = 20
+	// claimLease is how long a claimed delivery is hidden from other
+	// replicas; it must outlast one attempt.
+	claimLease = 2 * time.Minute
+	// baseBackoff is the wait after the first failed attempt; it doubles
+	// after each further one, up to maxBackoff.
+	baseBackoff = 10 * time.Second
+	maxBackoff  = time.Hour
+	// maxLoggedResponse caps how much of a response body an attempt keeps.
+	maxLoggedResponse = 2048
+)
+
+type DispatchStore interface {
+	ClaimDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*models.WebhookDelivery, error)
+	RecordDeliveryAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) error
+}
+
+// Dispatcher sends queued deliveries, retrying failures with exponential
+// backoff until maxAttempts is reached. Several replicas may run one; each
+// delivery is claimed before it is sent.
+type Dispatcher struct {
+	store       DispatchStore
+	open        func(sealed string) (string, error)
+	client      *http.Client
+	interval    time.Duration
+	maxAttempts int
+}
+
+// NewDispatcher returns a Dispatcher that opens webhook secrets with open.
+func NewDispatcher(store DispatchStore, open func(string) (string, error), timeout, interval time.Duration, maxAttempts int) *Dispatcher {
+	return &Dispatcher{
+		store:       store,
+		open:        open,
+		client:      &http.Client{Timeout: timeout},
+		interval:    interval,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Start sends due deliveries every interval until ctx is done.
+func (d *Dispatcher) Start(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		d.tick(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) tick(ctx context.Context) {
+	deliveries, err := d.store.ClaimDeliveries(ctx, time.Now(), claimBatch, claimLease)
+	if err != nil {
+		return
+	}
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(delivery *models.WebhookDelivery) {
+			defer wg.Done()
+			d.deliver(ctx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery *models.WebhookDelivery) {
+	start := time.Now()
+	attempt := &models.WebhookAttempt{
+		ID:         uuid.New().String(),
+		DeliveryID: delivery.ID,
+		Number:     delivery.AttemptCount + 1,
+	}
+
+	err := d.send(ctx, delivery, attempt)
+	attempt.DurationMs = time.Since(start).Milliseconds()
+
+	delivery.AttemptCount = attempt.Number
+	delivery.LastStatusCode = attempt.StatusCode
+	delivery.LastError = nil
+	now := time.Now()
+	switch {
+	case err == nil:
+		delivery.Status = models.DeliverySucceeded
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+	case !delivery.Webhook.Enabled || delivery.AttemptCount >= d.maxAttempts:
+		delivery.Status = models.DeliveryFailed
+		delivery.NextAttemptAt = nil
+	default:
+		next := now.Add(backoff(delivery.AttemptCount))
+		delivery.NextAttemptAt = &next
+	}
+	if err != nil {
+		msg := err.Error()
+		attempt.Error = &msg
+		delivery.LastError = &msg
+		log.Warn().Err(err).Str("delivery_id", delivery.ID).Str("webhook_id", delivery.WebhookID).
+			Int("attempt", attempt.Number).Str("status", string(delivery.Status)).Msg("Webhook delivery failed")
+	}
+
+	// The attempt is logged even when shutdown cancelled it.
+	_ = d.store.RecordDeliveryAttempt(context.WithoutCancel(ctx), delivery, attempt)
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) error {
+	if !delivery.Webhook.Enabled {
+		return fmt.Errorf("webhook is disabled")
+	}
+	secret, err := d.open(delivery.Webhook.Secret)
+	if err != nil {
+		return fmt.Errorf("failed to open webhook secret: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "collectionsservice-webhooks")
+	req.Header.Set(EventHeader, delivery.Event)
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxLoggedResponse))
+	attempt.StatusCode = resp.StatusCode
+	attempt.ResponseBody = strings.ReplaceAll(strings.ToValidUTF8(string(body), "�"), "\x00", "�")
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+// backoff returns the wait after the given number of failed attempts, with
+// up to 10% jitter so retries of one outage spread out.
+func backoff(attempts int) time.Duration {
+	wait := maxBackoff
+	if attempts <= 20 {
+		wait = min(baseBackoff<<(attempts-1), maxBackoff)
+	}
+	return wait + rand.N(wait/10+1)
+}
@@ -0,0 +1,259 @@
+package webhook
+
+import (
+	"collectionsservice/internal/models"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeDispatchStore hands out its deliveries once and logs every attempt.
+type fakeDispatchStore struct {
+	mu         sync.Mutex
+	deliveries []*models.WebhookDelivery
+	attempts   []*models.WebhookAttempt
+}
+
+func (s *fakeDispatchStore) ClaimDeliveries(context.Context, time.Time, int, time.Duration) ([]*models.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	claimed := s.deliveries
+	s.deliveries = nil
+	return claimed, nil
+}
+
+func (s *fakeDispatchStore) RecordDeliveryAttempt(_ context.Context, _ *models.WebhookDelivery, attempt *models.WebhookAttempt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts = append(s.attempts, attempt)
+	return nil
+}
+
+const testSecret = "whsec_test"
+
+func openSecret(sealed string) (string, error) {
+	if sealed != "sealed:"+testSecret {
+		return "", errors.New("cannot open secret")
+	}
+	return testSecret, nil
+}
+
+func newDelivery(url string, enabled bool, attempts int) *models.WebhookDelivery {
+	return &models.WebhookDelivery{
+		ID:           "d1",
+		WebhookID:    "w1",
+		Event:        string(EventCollectionCreated),
+		Payload:      []byte(`{"event":"collection.created"}`),
+		Status:       models.DeliveryPending,
+		AttemptCount: attempts,
+		Webhook:      models.Webhook{ID: "w1", URL: url, Secret: "sealed:" + testSecret, Enabled: enabled},
+	}
+}
+
+// receiver checks each request's signature the way a subscriber would and
+// answers with status.
+func receiver(t *testing.T, status int, hits *int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		body, _ := io.ReadAll(r.Body)
+
+		mac := hmac.New(sha256.New, []byte(testSecret))
+		mac.Write([]byte(r.Header.Get(TimestampHeader) + "."))
+		mac.Write(body)
+		want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+		if !hmac.Equal([]byte(r.Header.Get(SignatureHeader)), []byte(want)) {
+			t.Errorf("signature %q does not match %q", r.Header.Get(SignatureHeader), want)
+		}
+		if got := r.Header.Get(EventHeader); got != string(EventCollectionCreated) {
+			t.Errorf("%s = %q", EventHeader, got)
+		}
+		if got := r.Header.Get(DeliveryHeader); got != "d1" {
+			t.Errorf("%s = %q", DeliveryHeader, got)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("ack"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDispatcherDeliver(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		enabled     bool
+		attempts    int
+		maxAttempts int
+
+		wantHits    int32
+		wantStatus  models.DeliveryStatus
+		wantRetry   bool
+		wantError   string
+		wantCode    int
+		wantAttempt int
+	}{
+		{
+			name: "delivered", status: http.StatusOK, enabled: true, maxAttempts: 3,
+			wantHits: 1, wantStatus: models.DeliverySucceeded, wantCode: http.StatusOK, wantAttempt: 1,
+		},
+		{
+			name: "server error is retried", status: http.StatusInternalServerError, enabled: true, maxAttempts: 3,
+			wantHits: 1, wantStatus: models.DeliveryPending, wantRetry: true, wantError: "500", wantCode: 500, wantAttempt: 1,
+		},
+		{
+			name: "last attempt fails the delivery", status: http.StatusBadGateway, enabled: true, attempts: 2, maxAttempts: 3,
+			wantHits: 1, wantStatus: models.DeliveryFailed, wantError: "502", wantCode: 502, wantAttempt: 3,
+		},
+		{
+			name: "disabled webhook is not called", status: http.StatusOK, enabled: false, maxAttempts: 3,
+			wantStatus: models.DeliveryFailed, wantError: "webhook is disabled", wantAttempt: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits int32
+			srv := receiver(t, tt.status, &hits)
+			delivery := newDelivery(srv.URL, tt.enabled, tt.attempts)
+			store := &fakeDispatchStore{deliveries: []*models.WebhookDelivery{delivery}}
+			d := NewDispatcher(store, openSecret, 5*time.Second, time.Minute, tt.maxAttempts)
+
+			before := time.Now()
+			d.tick(context.Background())
+
+			if hits != tt.wantHits {
+				t.Errorf("receiver called %d times, want %d", hits, tt.wantHits)
+			}
+			if delivery.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", delivery.Status, tt.wantStatus)
+			}
+			if tt.wantRetry {
+				if delivery.NextAttemptAt == nil || delivery.NextAttemptAt.Before(before.Add(baseBackoff)) {
+					t.Errorf("next attempt at %v, want at least %v after %v", delivery.NextAttemptAt, baseBackoff, before)
+				}
+			} else if delivery.NextAttemptAt != nil {
+				t.Errorf("next attempt scheduled at %v, want none", delivery.NextAttemptAt)
+			}
+			if tt.wantStatus == models.DeliverySucceeded && delivery.DeliveredAt == nil {
+				t.Error("delivered_at not set")
+			}
+
+			if len(store.attempts) != 1 {
+				t.Fatalf("logged %d attempts, want 1", len(store.attempts))
+			}
+			attempt := store.attempts[0]
+			if attempt.Number != tt.wantAttempt || delivery.AttemptCount != tt.wantAttempt {
+				t.Errorf("attempt %d, count %d, want %d", attempt.Number, delivery.AttemptCount, tt.wantAttempt)
+			}
+			if attempt.StatusCode != tt.wantCode || delivery.LastStatusCode != tt.wantCode {
+				t.Errorf("status code %d, last %d, want %d", attempt.StatusCode, delivery.LastStatusCode, tt.wantCode)
+			}
+			if tt.wantHits > 0 && attempt.ResponseBody != "ack" {
+				t.Errorf("response body %q, want ack", attempt.ResponseBody)
+			}
+			switch {
+			case tt.wantError == "":
+				if attempt.Error != nil || delivery.LastError != nil {
+					t.Errorf("unexpected error %v, last %v", attempt.Error, delivery.LastError)
+				}
+			case attempt.Error == nil || !strings.Contains(*attempt.Error, tt.wantError):
+				t.Errorf("attempt error %v, want %q", attempt.Error, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, baseBackoff},
+		{2, 2 * baseBackoff},
+		{4, 8 * baseBackoff},
+		{12, maxBackoff},
+		{100, maxBackoff},
+	}
+	for _, tt := range tests {
+		got := backoff(tt.attempts)
+		if got < tt.want || got > tt.want+tt.want/10 {
+			t.Errorf("backoff(%d) = %v, want %v plus at most 10%%", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	body := []byte(`{"id":"e1"}`)
+	sig := Sign(testSecret, 1700000000, body)
+	if !strings.HasPrefix(sig, "sha256=") {
+		t.Fatalf("signature %q has no sha256= prefix", sig)
+	}
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      []byte
+		want      bool
+	}{
+		{"valid", testSecret, 1700000000, body, true},
+		{"other secret", "other", 1700000000, body, false},
+		{"other timestamp", testSecret, 1700000001, body, false},
+		{"other body", testSecret, 1700000000, []byte(`{"id":"e2"}`), false},
+	}
+	for _, tt := range tests {
+		if got := Verify(tt.secret, tt.timestamp, tt.body, sig); got != tt.want {
+			t.Errorf("%s: Verify = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDispatcherRetriesUntilDelivered(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	delivery := newDelivery(srv.URL, true, 0)
+	store := &fakeDispatchStore{}
+	d := NewDispatcher(store, openSecret, 5*time.Second, time.Minute, 5)
+
+	var waits []time.Duration
+	for delivery.Status == models.DeliveryPending && len(store.attempts) < 5 {
+		store.deliveries = []*models.WebhookDelivery{delivery}
+		sentAt := time.Now()
+		d.tick(context.Background())
+		if delivery.NextAttemptAt != nil {
+			waits = append(waits, delivery.NextAttemptAt.Sub(sentAt))
+		}
+	}
+
+	if delivery.Status != models.DeliverySucceeded {
+		t.Fatalf("status = %s after %d attempts, want succeeded", delivery.Status, len(store.attempts))
+	}
+	for i, a := range store.attempts {
+		if a.Number != i+1 {
+			t.Errorf("attempt %d logged as number %d", i+1, a.Number)
+		}
+	}
+	if codes := []int{store.attempts[0].StatusCode, store.attempts[1].StatusCode, store.attempts[2].StatusCode}; codes[0] != 503 || codes[1] != 503 || codes[2] != 200 {
+		t.Errorf("logged status codes %v, want [503 503 200]", codes)
+	}
+	if len(waits) != 2 || waits[1] < 2*baseBackoff {
+		t.Errorf("waits %v, want two growing from %v", waits, baseBackoff)
+	}
+}
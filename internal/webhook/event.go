@@ -0,0 +1,81 @@
+// Package webhook signs and sends event payloads to subscribed URLs, with
+// retries and a log of every attempt.
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Event string
+
+const (
+	EventCollectionCreated Event = "collection.created"
+	EventCollectionUpdated Event = "collection.updated"
+	EventCollectionDeleted Event = "collection.deleted"
+	EventRequestAdded      Event = "request.added"
+	EventRequestUpdated    Event = "request.updated"
+	EventRequestRemoved    Event = "request.removed"
+	EventRunFailed         Event = "run.failed"
+	EventRunRecovered      Event = "run.recovered"
+)
+
+// Events lists every event a webhook can subscribe to.
+var Events = []Event{
+	EventCollectionCreated,
+	EventCollectionUpdated,
+	EventCollectionDeleted,
+	EventRequestAdded,
+	EventRequestUpdated,
+	EventRequestRemoved,
+	EventRunFailed,
+	EventRunRecovered,
+}
+
+// Known reports whether e is one of Events.
+func Known(e Event) bool {
+	for _, known := range Events {
+		if e == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Message is an event to publish. Data must not hold secret values.
+type Message struct {
+	Event        Event
+	WorkspaceID  string
+	CollectionID string
+	Actor        string
+	Data         map[string]interface{}
+
+	// ID identifies the event to receivers, so an event published twice
+	// can be dropped as a duplicate. A new ID is used when empty.
+	ID string
+	// OccurredAt defaults to the time of publishing.
+	OccurredAt time.Time
+}
+
+// payload is the JSON body every webhook receives.
+type payload struct {
+	ID           string                 `json:"id"`
+	Event        Event                  `json:"event"`
+	OccurredAt   time.Time              `json:"occurred_at"`
+	WorkspaceID  string                 `json:"workspace_id"`
+	CollectionID string                 `json:"collection_id,omitempty"`
+	Actor        string                 `json:"actor,omitempty"`
+	Data         map[string]interface{} `json:"data,omitempty"`
+}
+
+func encode(m Message) ([]byte, error) {
+	return json.Marshal(payload{
+		ID:           m.ID,
+		Event:        m.Event,
+		OccurredAt:   m.OccurredAt.UTC(),
+		WorkspaceID:  m.WorkspaceID,
+		CollectionID: m.CollectionID,
+		Actor:        m.Actor,
+		Data:         m.Data,
+	})
+}
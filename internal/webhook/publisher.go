@@ -0,0 +1,119 @@
+package webhook
+
+import (
+	"collectionsservice/internal/models"
+	"collectionsservice/internal/monitor"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PublishStore interface {
+	ListWebhooks(ctx context.Context, workspaceID string) ([]*models.Webhook, error)
+	CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error
+	GetByID(ctx context.Context, id string) (*models.Collection, error)
+}
+
+// Publisher queues a delivery of each event for every enabled webhook that
+// subscribes to it. The Dispatcher sends them.
+type Publisher struct {
+	store PublishStore
+}
+
+func NewPublisher(store PublishStore) *Publisher {
+	return &Publisher{store: store}
+}
+
+func (p *Publisher) Publish(ctx context.Context, m Message) error {
+	webhooks, err := p.store.ListWebhooks(ctx, m.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	if m.ID == "" {
+		m.ID = uuid.New().String()
+	}
+	now := time.Now()
+	if m.OccurredAt.IsZero() {
+		m.OccurredAt = now
+	}
+	var body []byte
+	var deliveries []*models.WebhookDelivery
+	for _, w := range webhooks {
+		if !Subscribed(w, m) {
+			continue
+		}
+		if body == nil {
+			if body, err = encode(m); err != nil {
+				return fmt.Errorf("failed to encode %s payload: %w", m.Event, err)
+			}
+		}
+		deliveries = append(deliveries, &models.WebhookDelivery{
+			ID:            uuid.New().String(),
+			WebhookID:     w.ID,
+			EventID:       m.ID,
+			Event:         string(m.Event),
+			Payload:       body,
+			Status:        models.DeliveryPending,
+			NextAttemptAt: &now,
+		})
+	}
+	return p.store.CreateDeliveries(ctx, deliveries)
+}
+
+// Notify publishes a monitor's failure or recovery as run.failed or
+// run.recovered.
+func (p *Publisher) Notify(ctx context.Context, n monitor.Notification) error {
+	col, err := p.store.GetByID(ctx, n.Monitor.CollectionID)
+	if err != nil {
+		return err
+	}
+	event := EventRunRecovered
+	if n.Event == monitor.EventFailed {
+		event = EventRunFailed
+	}
+	return p.Publish(ctx, Message{
+		Event:        event,
+		WorkspaceID:  col.WorkspaceID,
+		CollectionID: col.ID,
+		Actor:        "monitor:" + n.Monitor.ID,
+		Data: map[string]interface{}{
+			"monitor_id":        n.Monitor.ID,
+			"monitor_name":      n.Monitor.Name,
+			"collection_run_id": n.Summary.CollectionRunID,
+			"requests":          n.Summary.Requests,
+			"requests_failed":   n.Summary.RequestsFailed,
+			"tests_failed":      n.Summary.TestsFailed,
+			"assertions_failed": n.Summary.AssertionsFailed,
+			"error":             n.Summary.Error,
+		},
+	})
+}
+
+// Subscribed reports whether w should receive m.
+func Subscribed(w *models.Webhook, m Message) bool {
+	if !w.Enabled {
+		return false
+	}
+	if w.CollectionID != nil && *w.CollectionID != m.CollectionID {
+		return false
+	}
+	var events []Event
+	if len(w.Events) > 0 {
+		if err := json.Unmarshal(w.Events, &events); err != nil {
+			return false
+		}
+	}
+	if len(events) == 0 {
+		return true
+	}
+	for _, e := range events {
+		if e == m.Event {
+			return true
+		}
+	}
+	return false
+}
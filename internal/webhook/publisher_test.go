@@ -0,0 +1,33 @@
+package webhook
+
+import (
+	"collectionsservice/internal/models"
+	"testing"
+
+	"gorm.io/datatypes"
+)
+
+func TestSubscribed(t *testing.T) {
+	collection := "c1"
+	m := Message{Event: EventRequestAdded, WorkspaceID: "w", CollectionID: collection}
+
+	tests := []struct {
+		name    string
+		webhook models.Webhook
+		want    bool
+	}{
+		{"every event", models.Webhook{Enabled: true}, true},
+		{"empty event list", models.Webhook{Enabled: true, Events: datatypes.JSON(`[]`)}, true},
+		{"subscribed event", models.Webhook{Enabled: true, Events: datatypes.JSON(`["request.added","run.failed"]`)}, true},
+		{"other events", models.Webhook{Enabled: true, Events: datatypes.JSON(`["collection.deleted"]`)}, false},
+		{"disabled", models.Webhook{Enabled: false}, false},
+		{"same collection", models.Webhook{Enabled: true, CollectionID: &collection}, true},
+		{"other collection", models.Webhook{Enabled: true, CollectionID: new(string)}, false},
+		{"malformed events", models.Webhook{Enabled: true, Events: datatypes.JSON(`{`)}, false},
+	}
+	for _, tt := range tests {
+		if got := Subscribed(&tt.webhook, m); got != tt.want {
+			t.Errorf("%s: Subscribed = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
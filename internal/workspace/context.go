@@ -0,0 +1,39 @@
+package workspace
+
+import (
+	"collectionsservice/internal/apperr"
+	"collectionsservice/internal/models"
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKey is the gRPC metadata header callers use to select a workspace.
+const MetadataKey = "x-workspace-id"
+
+type ctxKey struct{}
+
+func NewContext(ctx context.Context, workspaceID string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, workspaceID)
+}
+
+// FromContext returns the workspace the current call is scoped to. A value set
+// with NewContext wins over the metadata header; callers that send neither are
+// placed in the default workspace.
+func FromContext(ctx context.Context) (string, error) {
+	if id, ok := ctx.Value(ctxKey{}).(string); ok && id != "" {
+		return id, nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(MetadataKey)) == 0 {
+		return models.DefaultWorkspaceID, nil
+	}
+
+	id := md.Get(MetadataKey)[0]
+	if _, err := uuid.Parse(id); err != nil {
+		return "", apperr.Invalid(MetadataKey, "invalid %s header %q", MetadataKey, id)
+	}
+	return id, nil
+}